@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/mojo-lang/mojo/go/pkg/cmd/commander"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/dumper"
+)
+
+type AstCmd struct {
+	BaseCmd
+	commander.AstPrinter
+}
+
+func init() {
+	cmd := NewAstCmd()
+	cmd.Build()
+	commands = append(commands, cmd)
+}
+
+func NewAstCmd() *AstCmd {
+	return &AstCmd{
+		BaseCmd: BaseCmd{
+			Command: &cli.Command{
+				Name:      "ast",
+				Usage:     "print the syntax tree of the mojo source file with the source positions",
+				ArgsUsage: "<file>",
+			},
+		},
+	}
+}
+
+func (b *AstCmd) Build() {
+	b.BaseCmd.Command.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "format",
+			Aliases:     []string{"f"},
+			Usage:       "the output format, json or yaml",
+			Value:       dumper.JsonFormat,
+			Destination: &b.Format,
+		},
+	}
+
+	b.BaseCmd.Command.Action = b.Execute
+}
+
+func (b *AstCmd) Execute(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return errors.New("the mojo source file is required")
+	}
+	b.File = ctx.Args().Get(0)
+	return b.AstPrinter.Execute()
+}
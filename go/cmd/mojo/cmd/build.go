@@ -7,7 +7,9 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/mojo-lang/mojo/go/pkg/avro/schema"
 	"github.com/mojo-lang/mojo/go/pkg/cmd/commander"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/dumper"
 )
 
 type BuildCmd struct {
@@ -71,21 +73,94 @@ func (b *BuildCmd) Build() {
 		&cli.StringFlag{
 			Name:        "targets",
 			Aliases:     []string{"t"},
-			Usage:       "the target to generate",
+			Usage:       "the targets (or the target aliases) to generate, separated by comma, the dependent targets are included automatically, see --list-targets. the `service` alias generates the ncraft service only, use `api,service` for the api files too",
 			Destination: &b.Targets,
 		},
+		&cli.BoolFlag{
+			Name:        "list-targets",
+			Usage:       "list the available targets, with the artifacts they require and provide",
+			Destination: &b.ListTargets,
+		},
+		&cli.IntFlag{
+			Name:        "jobs",
+			Aliases:     []string{"j"},
+			Usage:       "the number of the independent targets built concurrently, default is the number of the CPUs",
+			Destination: &b.Jobs,
+		},
 		&cli.StringFlag{
 			Name:        "output",
 			Aliases:     []string{"o"},
 			Usage:       "the Output to generate",
 			Destination: &b.Output,
 		},
+		&cli.BoolFlag{
+			Name:        "source-info",
+			Usage:       "include the source code info, the locations and comments, in the descriptor set of the descriptor target",
+			Destination: &b.SourceInfo,
+		},
+		&cli.StringFlag{
+			Name:        "avro-compatibility",
+			Usage:       "the compatibility of the avro schemas checked against the previously generated ones, backward, forward, full or none",
+			Value:       schema.BackwardCompatibility,
+			Destination: &b.AvroCompatibility,
+		},
 		&cli.StringFlag{
 			Name:        "repo",
 			Aliases:     []string{"sr"},
 			Usage:       "the git repository of NCraft related code",
 			Destination: &b.Repository,
 		},
+		&cli.StringSliceFlag{
+			Name:        "plugin-opt",
+			Usage:       "the plugin option like `compiler.pagination.auto=false`, scoped by the plugin name or group name",
+			Destination: &b.PluginOptions,
+		},
+		&cli.StringSliceFlag{
+			Name:        "dump-before",
+			Usage:       "dump the package before the plugin or the plugin group runs, for debugging the plugins",
+			Destination: &b.DumpBefore,
+		},
+		&cli.StringSliceFlag{
+			Name:        "dump-after",
+			Usage:       "dump the package after the plugin or the plugin group runs, for debugging the plugins",
+			Destination: &b.DumpAfter,
+		},
+		&cli.StringFlag{
+			Name:        "dump-format",
+			Usage:       "the format of the dumped package, mojo, json or both",
+			Value:       dumper.MojoFormat,
+			Destination: &b.DumpFormat,
+		},
+		&cli.StringFlag{
+			Name:        "dump-output",
+			Usage:       "the directory to dump the package, default is .mojo-dump in the package path",
+			Destination: &b.DumpOutput,
+		},
+		&cli.StringFlag{
+			Name:        "trace",
+			Usage:       "write the timing of the plugins, generators and external processes to the file in the Chrome trace event format, and print the summary",
+			Destination: &b.Trace,
+		},
+		&cli.BoolFlag{
+			Name:        "dry-run",
+			Usage:       "list the files would be created, modified or deleted without writing them",
+			Destination: &b.DryRun,
+		},
+		&cli.BoolFlag{
+			Name:        "diff",
+			Usage:       "show the unified diffs of the generated files against the disk, implies --dry-run",
+			Destination: &b.Diff,
+		},
+		&cli.BoolFlag{
+			Name:        "verify",
+			Usage:       "fail if the generated files on the disk are out of date without writing them, for the CI",
+			Destination: &b.Verify,
+		},
+		&cli.BoolFlag{
+			Name:        "no-cache",
+			Usage:       "compile all the mojo packages from the source, without the build cache in $MOJO_HOME/cache",
+			Destination: &b.DisableCache,
+		},
 	}
 
 	b.BaseCmd.Command.Action = b.Execute
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/mojo-lang/mojo/go/pkg/cmd/commander"
+)
+
+type CleanCmd struct {
+	BaseCmd
+	commander.Cleaner
+}
+
+func init() {
+	cmd := NewCleanCmd()
+	cmd.Build()
+	commands = append(commands, cmd)
+}
+
+func NewCleanCmd() *CleanCmd {
+	return &CleanCmd{
+		BaseCmd: BaseCmd{
+			Command: &cli.Command{
+				Name:      "clean",
+				Usage:     "remove the generated files recorded in the .mojo-generated.json manifests under the paths",
+				ArgsUsage: "[path...]",
+			},
+		},
+		Cleaner: commander.Cleaner{
+			Pwd: getPwd(),
+		},
+	}
+}
+
+func (c *CleanCmd) Build() {
+	c.BaseCmd.Command.Flags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "targets",
+			Aliases:     []string{"t"},
+			Usage:       "the targets (or the target aliases) to clean, separated by comma, default is all the targets",
+			Destination: &c.Targets,
+		},
+		&cli.BoolFlag{
+			Name:        "dry-run",
+			Usage:       "list the files would be deleted without deleting them",
+			Destination: &c.DryRun,
+		},
+	}
+
+	c.BaseCmd.Command.Action = c.Execute
+}
+
+func (c *CleanCmd) Execute(ctx *cli.Context) error {
+	for _, p := range ctx.Args().Slice() {
+		if strings.HasPrefix(p, "--") {
+			return fmt.Errorf("failed to parse path from commandline, path: %s", p)
+		}
+		c.Paths = append(c.Paths, p)
+	}
+	return c.Cleaner.Execute()
+}
@@ -42,6 +42,13 @@ func (b *FormatCmd) Build() {
 			Usage:       "the mojo package name to compile",
 			Destination: &b.TargetPackage,
 		},
+		&cli.StringFlag{
+			Name:        "lang",
+			Aliases:     []string{"l"},
+			Usage:       "the language of the source files to format, mojo or proto",
+			Destination: &b.Lang,
+			DefaultText: "mojo",
+		},
 		&cli.StringFlag{
 			Name:        "output",
 			Aliases:     []string{"o"},
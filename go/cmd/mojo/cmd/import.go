@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/mojo-lang/mojo/go/pkg/cmd/commander"
+)
+
+type ImportCmd struct {
+	BaseCmd
+	commander.Importer
+}
+
+func init() {
+	cmd := NewImportCmd()
+	cmd.Build()
+	commands = append(commands, cmd)
+}
+
+func NewImportCmd() *ImportCmd {
+	return &ImportCmd{
+		BaseCmd: BaseCmd{
+			Command: &cli.Command{
+				Name:  "import",
+				Usage: "import the definitions in the other IDLs into a mojo package",
+			},
+		},
+		Importer: commander.Importer{
+			Pwd: getPwd(),
+		},
+	}
+}
+
+func (c *ImportCmd) Build() {
+	proto := &cli.Command{
+		Name:      "proto",
+		Usage:     "import the tree of the .proto files into a mojo package",
+		ArgsUsage: "<dir>",
+		Flags:     c.flags("the mojo package name, default is the common prefix of the proto packages"),
+		Action:    c.ExecuteProto,
+	}
+	thrift := &cli.Command{
+		Name:      "thrift",
+		Usage:     "import the tree of the .thrift files into a mojo package",
+		ArgsUsage: "<dir>",
+		Flags:     c.flags("the mojo package name, default is the common prefix of the thrift `namespace *`"),
+		Action:    c.ExecuteThrift,
+	}
+
+	c.BaseCmd.Command.Subcommands = []*cli.Command{proto, thrift}
+}
+
+func (c *ImportCmd) flags(packageUsage string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "package",
+			Aliases:     []string{"p"},
+			Usage:       packageUsage,
+			Destination: &c.PackageName,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Aliases:     []string{"o"},
+			Usage:       "the directory to write the mojo package",
+			Destination: &c.Output,
+			DefaultText: ".",
+		},
+		&cli.StringFlag{
+			Name:        "version",
+			Aliases:     []string{"v"},
+			Usage:       "the version of the mojo package",
+			Destination: &c.Version,
+			DefaultText: "0.1.0",
+		},
+		&cli.BoolFlag{
+			Name:        "force",
+			Aliases:     []string{"f"},
+			Usage:       "overwrite the existing mojo files",
+			Destination: &c.Force,
+		},
+		&cli.BoolFlag{
+			Name:        "dry-run",
+			Usage:       "list the files would be written without writing them",
+			Destination: &c.DryRun,
+		},
+	}
+}
+
+func (c *ImportCmd) ExecuteProto(ctx *cli.Context) error {
+	return c.execute(ctx, "proto")
+}
+
+func (c *ImportCmd) ExecuteThrift(ctx *cli.Context) error {
+	return c.execute(ctx, "thrift")
+}
+
+func (c *ImportCmd) execute(ctx *cli.Context, lang string) error {
+	if ctx.Args().Len() == 0 {
+		return fmt.Errorf("the directory of the %s files is required", lang)
+	}
+	c.Path = ctx.Args().Get(0)
+	c.Lang = lang
+	if len(c.Output) == 0 {
+		c.Output = "./"
+	}
+	return c.Importer.Execute()
+}
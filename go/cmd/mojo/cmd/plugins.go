@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/mojo-lang/mojo/go/pkg/cmd/commander"
+	"github.com/mojo-lang/mojo/go/pkg/plugin/external"
+)
+
+type PluginsCmd struct {
+	BaseCmd
+	commander.PluginLister
+}
+
+func init() {
+	cmd := NewPluginsCmd()
+	cmd.Build()
+	commands = append(commands, cmd)
+}
+
+func NewPluginsCmd() *PluginsCmd {
+	return &PluginsCmd{
+		BaseCmd: BaseCmd{
+			Command: &cli.Command{
+				Name:  "plugins",
+				Usage: "manage the mojo plugins",
+			},
+		},
+	}
+}
+
+func (b *PluginsCmd) Build() {
+	b.BaseCmd.Command.Subcommands = []*cli.Command{{
+		Name:  "list",
+		Usage: "print the plugins pipeline in the order of execution, the optional required artifacts end with '?'",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "plugins",
+				Usage:       "the plugins or plugin groups in the pipeline, separated by comma",
+				Value:       commander.DefaultPlugins,
+				Destination: &b.Plugins,
+			},
+		},
+		Action: b.List,
+	}, {
+		Name:   "proto",
+		Usage:  "print the plugin.proto of the requests and responses of the generator plugins mojo-gen-<target>",
+		Action: b.Proto,
+	}}
+}
+
+func (b *PluginsCmd) List(ctx *cli.Context) error {
+	return b.PluginLister.Execute()
+}
+
+func (b *PluginsCmd) Proto(ctx *cli.Context) error {
+	fmt.Print(external.Proto)
+	return nil
+}
@@ -25,6 +25,7 @@ require (
 	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.8.4
 	github.com/urfave/cli/v2 v2.26.0
+	// pinned, the internal_gengo is used to generate the go files, see pkg/go/generator/generator/protoc_gen_go.go
 	google.golang.org/protobuf v1.33.0
 )
 
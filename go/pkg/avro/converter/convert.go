@@ -0,0 +1,362 @@
+package converter
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/core/go/pkg/mojo/core/strcase"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/avro/schema"
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+// Converter converts the top-level structs of the mojo package to the avro record schemas, each of
+// them is written to the `<package path>/<Name>.avsc`, and is self-contained, the named types it
+// references are defined inline at the first use, and referenced by the full name after that. the structs
+// mapped to the avro types by the mojo.yaml are not written
+type Converter struct {
+	Files []*schema.File
+}
+
+func New() *Converter {
+	return &Converter{}
+}
+
+// ConvertPackage converts the structs of the package and its children
+func (c *Converter) ConvertPackage(ctx context.Context, pkg *lang.Package) error {
+	for _, sourceFile := range pkg.SourceFiles {
+		if sourceFile.IsGenericInstantiated() {
+			continue
+		}
+
+		for _, statement := range sourceFile.Statements {
+			decl := statement.GetDeclaration().GetStructDecl()
+			if decl == nil || len(decl.GenericParameters) > 0 || isDisabled(decl.Attributes) || isMapped(decl) {
+				continue
+			}
+
+			s, err := ConvertStruct(decl)
+			if err != nil {
+				return err
+			}
+			c.Files = append(c.Files, &schema.File{Name: FileName(decl), Schema: s})
+		}
+	}
+
+	for _, child := range pkg.Children {
+		if err := c.ConvertPackage(ctx, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FileName the avsc file of the struct in the directory of its package
+func FileName(decl *lang.StructDecl) string {
+	return path.Join(strings.ReplaceAll(decl.PackageName, ".", "/"), decl.Name+".avsc")
+}
+
+// ConvertStruct converts the struct to the avro record schema
+func ConvertStruct(decl *lang.StructDecl) (*schema.Schema, error) {
+	c := &structConverter{defined: make(map[string]bool)}
+	return c.convertRecord(decl)
+}
+
+type structConverter struct {
+	// the named types defined in the schema
+	defined map[string]bool
+
+	// the full name of the record converting, the namespace of the records wrapping the union branches
+	scope string
+}
+
+func isDisabled(attributes []*lang.Attribute) bool {
+	options, _ := lang.GetDisableGenerateAttribute(attributes)
+	return options.Including("avro", "")
+}
+
+func isMapped(decl *lang.StructDecl) bool {
+	return config.Get().GetTypeMapping(config.AvroTypeMappingTarget, decl.GetFullName()) != nil
+}
+
+func document(doc *lang.Document) string {
+	var lines []string
+	for _, line := range doc.GetLines() {
+		lines = append(lines, line.Content)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// aliases the `@alias` of the declaration and the type
+func aliases(attributes ...[]*lang.Attribute) []string {
+	var values []string
+	for _, attrs := range attributes {
+		for _, attribute := range attrs {
+			if attribute.IsSameName(core.AliasAttributeName) {
+				if value, err := attribute.GetString(); err == nil && len(value) > 0 {
+					values = append(values, value)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// namespace the namespace of the named type, the package name and the enclosing names
+func namespace(fullName string, name string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(fullName, name), ".")
+}
+
+func (c *structConverter) convertRecord(decl *lang.StructDecl) (*schema.Schema, error) {
+	fullName := decl.GetFullName()
+	if c.defined[fullName] {
+		return schema.NewType(fullName), nil
+	}
+	c.defined[fullName] = true
+
+	scope := c.scope
+	c.scope = fullName
+	defer func() { c.scope = scope }()
+
+	record := &schema.Schema{
+		Type:      schema.RecordType,
+		Name:      decl.Name,
+		Namespace: namespace(fullName, decl.Name),
+		Doc:       document(decl.Document),
+		Aliases:   aliases(decl.Attributes),
+		Fields:    []*schema.Field{},
+	}
+	for _, field := range structFields(decl) {
+		f, err := c.convertField(field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the field %s in %s: %w", field.Name, fullName, err)
+		}
+		record.Fields = append(record.Fields, f)
+	}
+	return record, nil
+}
+
+// structFields the fields of the struct, including the ones inherited, which are placed first
+func structFields(decl *lang.StructDecl) []*lang.ValueDecl {
+	var fields []*lang.ValueDecl
+	for _, inherit := range decl.GetType().GetInherits() {
+		if s := inherit.GetTypeDeclaration().GetStructDecl(); s != nil {
+			fields = append(fields, structFields(s)...)
+		}
+	}
+	return append(fields, decl.GetType().GetFields()...)
+}
+
+// convertField converts the field, the optional field is the union with the null and defaults to null,
+// the array and map fields default to the empty ones, so they can be added compatibly
+func (c *structConverter) convertField(decl *lang.ValueDecl) (*schema.Field, error) {
+	typ, err := c.convertType(decl.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	field := &schema.Field{
+		Name:    decl.Name,
+		Type:    typ,
+		Doc:     document(decl.Document),
+		Aliases: aliases(decl.Attributes, decl.Type.Attributes),
+	}
+	if lang.HasAttribute(decl.Type.Attributes, core.OptionalAttributeName) && !typ.HasNull() {
+		field.Type = schema.NewOptional(typ)
+	}
+
+	switch {
+	case field.Type.IsUnion() && field.Type.Union[0].Type == schema.NullType:
+		field.Default = schema.NullDefault
+	case field.Type.Type == schema.ArrayType:
+		field.Default = []byte("[]")
+	case field.Type.Type == schema.MapType:
+		field.Default = []byte("{}")
+	}
+	return field, nil
+}
+
+func (c *structConverter) convertType(typ *lang.NominalType) (*schema.Schema, error) {
+	fullName := typ.GetFullName()
+	if mapped, err := mappedType(fullName); mapped != nil || err != nil {
+		return mapped, err
+	}
+	if primitive, ok := primitiveTypes[fullName]; ok {
+		return schema.NewType(primitive), nil
+	}
+	if wrapper, ok := wrapperTypes[fullName]; ok {
+		return schema.NewOptional(schema.NewType(wrapper)), nil
+	}
+	if logical, ok := logicalTypes[fullName]; ok {
+		return logical(), nil
+	}
+
+	switch fullName {
+	case core.ArrayTypeFullName:
+		if len(typ.GenericArguments) != 1 {
+			return nil, fmt.Errorf("the array type should have one generic argument")
+		}
+		items, err := c.convertType(typ.GenericArguments[0])
+		if err != nil {
+			return nil, err
+		}
+		return schema.NewArray(items), nil
+	case core.MapTypeFullName:
+		if len(typ.GenericArguments) != 2 {
+			return nil, fmt.Errorf("the map type should have two generic arguments")
+		}
+		if key := typ.GenericArguments[0].GetFullName(); key != core.StringTypeFullName {
+			return nil, fmt.Errorf("the key type %s of the map should be String for the avro", key)
+		}
+		values, err := c.convertType(typ.GenericArguments[1])
+		if err != nil {
+			return nil, err
+		}
+		return schema.NewMap(values), nil
+	case core.UnionTypeFullName:
+		return c.convertUnion(typ)
+	}
+
+	decl := typ.TypeDeclaration
+	switch {
+	case decl.GetStructDecl() != nil:
+		return c.convertRecord(decl.GetStructDecl())
+	case decl.GetEnumDecl() != nil:
+		return c.convertEnum(decl.GetEnumDecl()), nil
+	case decl.GetTypeAliasDecl() != nil:
+		return c.convertType(decl.GetTypeAliasDecl().Type)
+	}
+	return nil, fmt.Errorf("the type %s is not resolved", fullName)
+}
+
+// convertUnion converts the union type to the avro union, which can not have two branches of the
+// same unnamed type or the nested unions. the branches of the same type are distinguished by the `@label`
+// like the oneof, each labeled branch is wrapped in the record named by the label with the only field
+func (c *structConverter) convertUnion(typ *lang.NominalType) (*schema.Schema, error) {
+	var branches []*schema.Schema
+	for _, argument := range typ.GenericArguments {
+		branch, err := c.convertType(argument)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, branch)
+	}
+
+	if hasSameBranches(branches) && isLabeled(typ.GenericArguments) {
+		for i, argument := range typ.GenericArguments {
+			label, _ := lang.GetStringAttribute(argument.Attributes, core.LabelAttributeName)
+			branches[i] = c.wrapBranch(label, branches[i])
+		}
+	}
+
+	union := schema.NewUnion()
+	keys := make(map[string]bool)
+	for _, branch := range branches {
+		for _, t := range unionTypes(branch) {
+			key := branchKey(t)
+			if keys[key] {
+				if t.Type == schema.NullType {
+					continue
+				}
+				return nil, fmt.Errorf("the union has more than one %s type, label the members to distinguish them", key)
+			}
+			keys[key] = true
+			union.Union = append(union.Union, t)
+		}
+	}
+	if keys[schema.NullType] {
+		return schema.NewOptional(union), nil
+	}
+	return union, nil
+}
+
+// unionTypes the types of the branch in the union, the wrapper types are the unions with the null
+func unionTypes(branch *schema.Schema) []*schema.Schema {
+	if branch.IsUnion() {
+		return branch.Union
+	}
+	return []*schema.Schema{branch}
+}
+
+func branchKey(t *schema.Schema) string {
+	if t.IsNamed() {
+		return lang.GetFullName(t.Namespace, nil, t.Name)
+	}
+	return t.Type
+}
+
+func hasSameBranches(branches []*schema.Schema) bool {
+	keys := make(map[string]bool)
+	for _, branch := range branches {
+		for _, t := range unionTypes(branch) {
+			key := branchKey(t)
+			if keys[key] && t.Type != schema.NullType {
+				return true
+			}
+			keys[key] = true
+		}
+	}
+	return false
+}
+
+func isLabeled(arguments []*lang.NominalType) bool {
+	for _, argument := range arguments {
+		if label, _ := lang.GetStringAttribute(argument.Attributes, core.LabelAttributeName); len(label) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// wrapBranch wraps the union branch in the record named by the label in the namespace of the enclosing record
+func (c *structConverter) wrapBranch(label string, branch *schema.Schema) *schema.Schema {
+	name := strcase.ToCamel(label)
+	fullName := lang.GetFullName(c.scope, nil, name)
+	if c.defined[fullName] {
+		return schema.NewType(fullName)
+	}
+	c.defined[fullName] = true
+
+	field := &schema.Field{Name: label, Type: branch}
+	if branch.IsUnion() && branch.Union[0].Type == schema.NullType {
+		field.Default = schema.NullDefault
+	}
+	return &schema.Schema{
+		Type:      schema.RecordType,
+		Name:      name,
+		Namespace: c.scope,
+		Fields:    []*schema.Field{field},
+	}
+}
+
+// convertEnum converts the enum, the default symbol read for the unknown ones is the enumerator numbered
+// 0, or the first one
+func (c *structConverter) convertEnum(decl *lang.EnumDecl) *schema.Schema {
+	fullName := decl.GetFullName()
+	if c.defined[fullName] {
+		return schema.NewType(fullName)
+	}
+	c.defined[fullName] = true
+
+	enum := &schema.Schema{
+		Type:      schema.EnumType,
+		Name:      decl.Name,
+		Namespace: namespace(fullName, decl.Name),
+		Doc:       document(decl.Document),
+		Aliases:   aliases(decl.Attributes),
+	}
+	for _, enumerator := range decl.GetType().GetEnumerators() {
+		enum.Symbols = append(enum.Symbols, enumerator.Name)
+		if number, err := lang.GetIntegerAttribute(enumerator.Attributes, core.NumberAttributeName); err == nil && number == 0 {
+			enum.Default = enumerator.Name
+		}
+	}
+	if len(enum.Default) == 0 && len(enum.Symbols) > 0 {
+		enum.Default = enum.Symbols[0]
+	}
+	return enum
+}
@@ -0,0 +1,78 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/avro/schema"
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+)
+
+func convertTestPackage(t *testing.T, files int) *schema.Schema {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "./testdata/mojo-test")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	c := New()
+	if !assert.NoError(t, c.ConvertPackage(context.Empty(), pkg)) || !assert.Equal(t, files, len(c.Files)) {
+		t.FailNow()
+	}
+	assert.Equal(t, "test/Book.avsc", c.Files[0].Name)
+	assert.Equal(t, "test/Author.avsc", c.Files[1].Name)
+	return c.Files[0].Schema
+}
+
+func fieldType(t *testing.T, s *schema.Schema, name string) string {
+	field := s.GetField(name)
+	if !assert.NotNil(t, field, name) {
+		t.FailNow()
+	}
+	content, err := json.Marshal(field.Type)
+	assert.NoError(t, err)
+	return string(content)
+}
+
+func TestConverter_ConvertPackage(t *testing.T) {
+	s := convertTestPackage(t, 3)
+
+	assert.Equal(t, "test.Book", s.FullName(""))
+	assert.Equal(t, "the book in the library", s.Doc)
+	assert.Equal(t, []string{"title"}, s.GetField("name").Aliases)
+	assert.Equal(t, `"string"`, fieldType(t, s, "name"))
+
+	assert.Equal(t, `["null","string"]`, fieldType(t, s, "subtitle"))
+	assert.Equal(t, schema.NullDefault, s.GetField("subtitle").Default)
+
+	assert.Equal(t, `{"type":"enum","name":"Format","namespace":"test.Book","symbols":["paper","e_book"],"default":"paper"}`, fieldType(t, s, "format"))
+	assert.Equal(t, `["string","long"]`, fieldType(t, s, "source"))
+	assert.Equal(t, `{"type":"array","items":{"type":"record","name":"Author","namespace":"test","fields":[{"name":"name","type":"string"},{"name":"format","type":"test.Book.Format"}]}}`, fieldType(t, s, "authors"))
+	assert.Equal(t, `["null","test.Author"]`, fieldType(t, s, "editor"))
+	assert.Equal(t, `{"type":"map","values":"int"}`, fieldType(t, s, "labels"))
+	assert.Equal(t, "{}", string(s.GetField("labels").Default))
+	assert.Equal(t, `{"type":"record","name":"Decimal","namespace":"test","doc":"the decimal mapped to the avro decimal by the mojo.yaml","fields":[{"name":"value","type":"string"}]}`, fieldType(t, s, "price"))
+	assert.Equal(t, `["null","double"]`, fieldType(t, s, "rating"))
+	assert.Equal(t, `{"type":"string","logicalType":"uuid"}`, fieldType(t, s, "id"))
+	assert.Equal(t, `{"type":"int","logicalType":"date"}`, fieldType(t, s, "publish_date"))
+	assert.Equal(t, `{"type":"long","logicalType":"timestamp-millis"}`, fieldType(t, s, "create_time"))
+	assert.Equal(t, `[{"type":"record","name":"Isbn","namespace":"test.Book","fields":[{"name":"isbn","type":"string"}]},{"type":"record","name":"Url","namespace":"test.Book","fields":[{"name":"url","type":"string"}]}]`, fieldType(t, s, "link"))
+}
+
+func TestConverter_ConvertPackage_TypeMappings(t *testing.T) {
+	config.Get().SetTypeMapping(config.AvroTypeMappingTarget, "test.Decimal", &config.TypeMapping{Type: "decimal(18, 4)"})
+	config.Get().SetTypeMapping(config.AvroTypeMappingTarget, "mojo.core.Timestamp", &config.TypeMapping{Type: "timestamp-micros"})
+	t.Cleanup(func() { delete(config.Get().Types, config.AvroTypeMappingTarget) })
+
+	// the mapped struct is not written
+	s := convertTestPackage(t, 2)
+	assert.Equal(t, `{"type":"bytes","logicalType":"decimal","precision":18,"scale":4}`, fieldType(t, s, "price"))
+	assert.Equal(t, `{"type":"long","logicalType":"timestamp-micros"}`, fieldType(t, s, "create_time"))
+}
@@ -0,0 +1,104 @@
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+
+	"github.com/mojo-lang/mojo/go/pkg/avro/schema"
+	"github.com/mojo-lang/mojo/go/pkg/config"
+)
+
+// primitiveTypes the mojo.core types to the avro primitive types, the unsigned integers are widened
+// to the signed ones as the avro has no unsigned integer
+var primitiveTypes = map[string]string{
+	core.NullTypeFullName:     schema.NullType,
+	core.BoolTypeFullName:     schema.BooleanType,
+	core.Int8TypeFullName:     schema.IntType,
+	core.ByteTypeFullName:     schema.IntType,
+	core.Int16TypeFullName:    schema.IntType,
+	core.Int32TypeFullName:    schema.IntType,
+	core.UInt8TypeFullName:    schema.IntType,
+	core.UInt16TypeFullName:   schema.IntType,
+	core.Int64TypeFullName:    schema.LongType,
+	core.IntTypeFullName:      schema.LongType,
+	core.UInt32TypeFullName:   schema.LongType,
+	core.UInt64TypeFullName:   schema.LongType,
+	core.UIntTypeFullName:     schema.LongType,
+	core.SizeTypeFullName:     schema.LongType,
+	core.PositiveTypeFullName: schema.LongType,
+	core.NegativeTypeFullName: schema.LongType,
+	core.Float32TypeFullName:  schema.FloatType,
+	core.FloatTypeFullName:    schema.FloatType,
+	core.Float64TypeFullName:  schema.DoubleType,
+	core.DoubleTypeFullName:   schema.DoubleType,
+	core.StringTypeFullName:   schema.StringType,
+	core.BytesTypeFullName:    schema.BytesType,
+}
+
+// wrapperTypes the mojo.core wrapper types to the avro primitive types, which are the optional ones
+var wrapperTypes = map[string]string{
+	core.BoolValueTypeFullName:    schema.BooleanType,
+	core.Int32ValueTypeFullName:   schema.IntType,
+	core.Int64ValueTypeFullName:   schema.LongType,
+	core.IntValueTypeFullName:     schema.LongType,
+	core.UInt32ValueTypeFullName:  schema.LongType,
+	core.UInt64ValueTypeFullName:  schema.LongType,
+	core.UIntValueTypeFullName:    schema.LongType,
+	core.Float32ValueTypeFullName: schema.FloatType,
+	core.FloatValueTypeFullName:   schema.FloatType,
+	core.Float64ValueTypeFullName: schema.DoubleType,
+	core.DoubleValueTypeFullName:  schema.DoubleType,
+	core.StringValueTypeFullName:  schema.StringType,
+	core.BytesValueTypeFullName:   schema.BytesType,
+}
+
+// logicalTypes the mojo.core types to the avro logical types, the decimal has no mojo.core type, so it
+// is mapped by the `types.avro` in the mojo.yaml, like `acme.Decimal: decimal(18, 4)`
+var logicalTypes = map[string]func() *schema.Schema{
+	core.TimestampTypeFullName: func() *schema.Schema {
+		return schema.NewLogicalType(schema.LongType, schema.TimestampMillisLogicalType)
+	},
+	core.DateTypeFullName: func() *schema.Schema {
+		return schema.NewLogicalType(schema.IntType, schema.DateLogicalType)
+	},
+	core.UuidTypeFullName: func() *schema.Schema {
+		return schema.NewLogicalType(schema.StringType, schema.UuidLogicalType)
+	},
+}
+
+var decimalType = regexp.MustCompile(`^decimal\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)$`)
+
+// mappedType the avro type which the mojo type is mapped to by the `types.avro` in the mojo.yaml, the
+// mapped type is a primitive type, a logical type like `timestamp-micros` and `decimal(18, 4)`, or the
+// full name of the named type
+func mappedType(fullName string) (*schema.Schema, error) {
+	mapping := config.Get().GetTypeMapping(config.AvroTypeMappingTarget, fullName)
+	if mapping == nil {
+		return nil, nil
+	}
+
+	switch typ := mapping.Type; typ {
+	case schema.DateLogicalType, schema.TimeMillisLogicalType:
+		return schema.NewLogicalType(schema.IntType, typ), nil
+	case schema.TimeMicrosLogicalType, schema.TimestampMillisLogicalType, schema.TimestampMicrosLogicalType:
+		return schema.NewLogicalType(schema.LongType, typ), nil
+	case schema.UuidLogicalType:
+		return schema.NewLogicalType(schema.StringType, typ), nil
+	default:
+		if matches := decimalType.FindStringSubmatch(typ); matches != nil {
+			decimal := schema.NewLogicalType(schema.BytesType, schema.DecimalLogicalType)
+			decimal.Precision, _ = strconv.Atoi(matches[1])
+			if len(matches[2]) > 0 {
+				decimal.Scale, _ = strconv.Atoi(matches[2])
+			}
+			if decimal.Precision == 0 || decimal.Scale > decimal.Precision {
+				return nil, fmt.Errorf("invalid decimal type %s mapped from %s", typ, fullName)
+			}
+			return decimal, nil
+		}
+		return schema.NewType(typ), nil
+	}
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+
+	"github.com/mojo-lang/mojo/go/pkg/avro/schema"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+type Generator struct {
+	// the compatibility checked against the schemas generated before, backward if empty
+	Compatibility string
+}
+
+func New() *Generator {
+	return &Generator{}
+}
+
+// GenerateFilesTo checks the compatibility of the schemas against the ones in the output directory,
+// and writes them if all compatible
+func (g *Generator) GenerateFilesTo(files []*schema.File, out string) error {
+	if err := g.CheckCompatibility(files, out); err != nil {
+		return err
+	}
+
+	outs, err := g.GenerateFiles(files)
+	if err != nil {
+		return err
+	}
+
+	// the avsc file has no generated header, so the stale files are only pruned by the manifest
+	guard := &util.PathGuard{DisableClear: true, Target: "avro"}
+	if err = guard.Record(out); err != nil {
+		return err
+	}
+	for _, file := range outs {
+		if err = file.WriteTo(out, guard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Generator) GenerateFiles(files []*schema.File) ([]*util.GeneratedFile, error) {
+	var out []*util.GeneratedFile
+	for _, file := range files {
+		content, err := json.MarshalIndent(file.Schema, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, &util.GeneratedFile{
+			Name:    file.Name,
+			Content: string(content) + "\n",
+		})
+	}
+	return out, nil
+}
+
+// CheckCompatibility checks the schemas against the ones previously generated in the output directory
+func (g *Generator) CheckCompatibility(files []*schema.File, out string) error {
+	var errs []error
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(out, file.Name))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+
+		previous, err := schema.Parse(content)
+		if err != nil {
+			return fmt.Errorf("failed to parse the previous avro schema %s: %w", file.Name, err)
+		}
+		if err = schema.CheckCompatibility(g.Compatibility, previous, file.Schema); err != nil {
+			logs.Errorw("the avro schema is incompatible with the previous one", "file", file.Name, "error", err.Error())
+			errs = append(errs, fmt.Errorf("%s: %w", file.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
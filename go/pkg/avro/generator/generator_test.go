@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/avro/schema"
+)
+
+func bookFile(fields ...*schema.Field) *schema.File {
+	return &schema.File{
+		Name: "test/Book.avsc",
+		Schema: &schema.Schema{
+			Type:      schema.RecordType,
+			Name:      "Book",
+			Namespace: "test",
+			Fields:    fields,
+		},
+	}
+}
+
+func TestGenerator_GenerateFilesTo(t *testing.T) {
+	out := t.TempDir()
+	name := &schema.Field{Name: "name", Type: schema.NewType(schema.StringType)}
+
+	g := New()
+	if !assert.NoError(t, g.GenerateFilesTo([]*schema.File{bookFile(name)}, out)) {
+		t.FailNow()
+	}
+	content, err := os.ReadFile(filepath.Join(out, "test/Book.avsc"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"type\": \"record\",\n  \"name\": \"Book\",\n  \"namespace\": \"test\",\n  \"fields\": [\n    {\n      \"name\": \"name\",\n      \"type\": \"string\"\n    }\n  ]\n}\n", string(content))
+
+	subtitle := &schema.Field{Name: "subtitle", Type: schema.NewOptional(schema.NewType(schema.StringType)), Default: schema.NullDefault}
+	assert.NoError(t, g.CheckCompatibility([]*schema.File{bookFile(name, subtitle)}, out))
+
+	pages := &schema.Field{Name: "pages", Type: schema.NewType(schema.IntType)}
+	assert.EqualError(t, g.CheckCompatibility([]*schema.File{bookFile(name, pages)}, out),
+		"test/Book.avsc: the field pages is added without the default value")
+
+	g.Compatibility = schema.NoneCompatibility
+	assert.NoError(t, g.CheckCompatibility([]*schema.File{bookFile(name, pages)}, out))
+}
@@ -0,0 +1,266 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// the compatibility of the new schema against the previous one, the same as the schema registry
+const (
+	NoneCompatibility     = "none"
+	BackwardCompatibility = "backward"
+	ForwardCompatibility  = "forward"
+	FullCompatibility     = "full"
+)
+
+// CheckCompatibility checks the current schema against the previous one, the backward compatibility
+// means the data written by the previous schema can be read by the current one, and the forward one is
+// the reverse, the full one is both
+func CheckCompatibility(compatibility string, previous *Schema, current *Schema) error {
+	switch strings.ToLower(compatibility) {
+	case NoneCompatibility:
+		return nil
+	case "", BackwardCompatibility:
+		return CanRead(current, previous)
+	case ForwardCompatibility:
+		return CanRead(previous, current)
+	case FullCompatibility:
+		if err := CanRead(current, previous); err != nil {
+			return err
+		}
+		return CanRead(previous, current)
+	}
+	return fmt.Errorf("unsupported avro compatibility %s", compatibility)
+}
+
+// CanRead checks the data written by the writer schema can be read by the reader one by the schema
+// resolution rules of the avro specification, the logical types are resolved as their underlying types,
+// except they should be the same with the same precision and scale if both sides have one
+func CanRead(reader *Schema, writer *Schema) error {
+	c := &checker{
+		readers: make(map[string]*Schema),
+		writers: make(map[string]*Schema),
+		checked: make(map[string]bool),
+	}
+	c.collect(reader, "", c.readers)
+	c.collect(writer, "", c.writers)
+	return c.check(reader, writer, "")
+}
+
+type checker struct {
+	readers map[string]*Schema
+	writers map[string]*Schema
+
+	// the pairs of the records checked or being checked, for the recursive records
+	checked map[string]bool
+}
+
+// collect the named types, and qualify the references with the enclosing namespace
+func (c *checker) collect(s *Schema, namespace string, names map[string]*Schema) {
+	switch {
+	case s == nil:
+	case s.IsUnion():
+		for _, branch := range s.Union {
+			c.collect(branch, namespace, names)
+		}
+	case s.IsNamed():
+		fullName := s.FullName(namespace)
+		names[fullName] = s
+		if i := strings.LastIndex(fullName, "."); i > 0 {
+			namespace = fullName[:i]
+		}
+		for _, field := range s.Fields {
+			c.collect(field.Type, namespace, names)
+		}
+	case s.Type == ArrayType:
+		c.collect(s.Items, namespace, names)
+	case s.Type == MapType:
+		c.collect(s.Values, namespace, names)
+	case s.IsReference():
+		if !strings.Contains(s.Type, ".") && len(namespace) > 0 {
+			s.Type = namespace + "." + s.Type
+		}
+	}
+}
+
+func (c *checker) resolve(s *Schema, names map[string]*Schema) (*Schema, error) {
+	if s.IsReference() {
+		if named, ok := names[s.Type]; ok {
+			return named, nil
+		}
+		return nil, fmt.Errorf("the avro type %s is not defined", s.Type)
+	}
+	return s, nil
+}
+
+func (c *checker) check(reader *Schema, writer *Schema, path string) error {
+	if reader == nil || writer == nil {
+		return fmt.Errorf("the schema of %s is missing", fieldPath(path))
+	}
+
+	r, err := c.resolve(reader, c.readers)
+	if err != nil {
+		return err
+	}
+	w, err := c.resolve(writer, c.writers)
+	if err != nil {
+		return err
+	}
+
+	if w.IsUnion() {
+		for _, branch := range w.Union {
+			if err = c.check(r, branch, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if r.IsUnion() {
+		for _, branch := range r.Union {
+			if c.check(branch, w, path) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("the %s of %s can not be read by any type of the union", typeName(w), fieldPath(path))
+	}
+
+	if len(r.LogicalType) > 0 && len(w.LogicalType) > 0 {
+		if r.LogicalType != w.LogicalType {
+			return fmt.Errorf("the logical type %s of %s can not be read as %s", w.LogicalType, fieldPath(path), r.LogicalType)
+		}
+		if r.Precision != w.Precision || r.Scale != w.Scale {
+			return fmt.Errorf("the %s(%d, %d) of %s can not be read as %s(%d, %d)", w.LogicalType, w.Precision, w.Scale,
+				fieldPath(path), r.LogicalType, r.Precision, r.Scale)
+		}
+	}
+
+	switch {
+	case IsPrimitiveType(w.Type):
+		if !isPromotable(w.Type, r.Type) {
+			return fmt.Errorf("the %s of %s can not be read as %s", typeName(w), fieldPath(path), typeName(r))
+		}
+	case w.Type != r.Type:
+		return fmt.Errorf("the %s of %s can not be read as %s", typeName(w), fieldPath(path), typeName(r))
+	case w.Type == ArrayType:
+		return c.check(r.Items, w.Items, path+"[]")
+	case w.Type == MapType:
+		return c.check(r.Values, w.Values, path+"{}")
+	case !isSameName(r, w):
+		return fmt.Errorf("the %s of %s can not be read as %s", typeName(w), fieldPath(path), typeName(r))
+	case w.Type == FixedType:
+		if r.Size != w.Size {
+			return fmt.Errorf("the size %d of the fixed %s is changed to %d", w.Size, w.Name, r.Size)
+		}
+	case w.Type == EnumType:
+		if len(r.Default) == 0 {
+			for _, symbol := range w.Symbols {
+				if !contains(r.Symbols, symbol) {
+					return fmt.Errorf("the symbol %s of the enum %s is removed without the default symbol", symbol, w.Name)
+				}
+			}
+		}
+	case w.Type == RecordType:
+		return c.checkRecord(r, w, path)
+	}
+	return nil
+}
+
+func (c *checker) checkRecord(reader *Schema, writer *Schema, path string) error {
+	key := fmt.Sprintf("%p|%p", reader, writer)
+	if c.checked[key] {
+		return nil
+	}
+	c.checked[key] = true
+
+	for _, field := range reader.Fields {
+		name := joinPath(path, field.Name)
+		written := writer.GetField(field.Name)
+		for _, alias := range field.Aliases {
+			if written == nil {
+				written = writer.GetField(alias)
+			}
+		}
+
+		if written == nil {
+			if field.Default == nil {
+				return fmt.Errorf("%s is added without the default value", fieldPath(name))
+			}
+			continue
+		}
+		if err := c.check(field.Type, written.Type, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isPromotable the writer primitive type can be read as the reader one
+func isPromotable(writer string, reader string) bool {
+	if writer == reader {
+		return true
+	}
+	switch writer {
+	case IntType:
+		return reader == LongType || reader == FloatType || reader == DoubleType
+	case LongType:
+		return reader == FloatType || reader == DoubleType
+	case FloatType:
+		return reader == DoubleType
+	case StringType:
+		return reader == BytesType
+	case BytesType:
+		return reader == StringType
+	}
+	return false
+}
+
+// isSameName the unqualified names are the same, or the writer name is one of the reader aliases
+func isSameName(reader *Schema, writer *Schema) bool {
+	name := unqualified(writer.Name)
+	if unqualified(reader.Name) == name {
+		return true
+	}
+	for _, alias := range reader.Aliases {
+		if unqualified(alias) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func unqualified(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func typeName(s *Schema) string {
+	if s.IsNamed() {
+		return s.Type + " " + s.Name
+	}
+	return s.Type
+}
+
+func joinPath(path string, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
+
+func fieldPath(path string) string {
+	if len(path) == 0 {
+		return "the schema"
+	}
+	return "the field " + path
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parse(t *testing.T, content string) *Schema {
+	s, err := Parse([]byte(content))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return s
+}
+
+const previousSchema = `{"type": "record", "name": "Book", "namespace": "acme", "fields": [
+	{"name": "name", "type": "string"},
+	{"name": "pages", "type": "int"},
+	{"name": "format", "type": {"type": "enum", "name": "Format", "symbols": ["paper", "e_book"]}},
+	{"name": "related", "type": ["null", {"type": "array", "items": "Book"}], "default": null}
+]}`
+
+func TestCheckCompatibility(t *testing.T) {
+	cases := []struct {
+		name          string
+		current       string
+		compatibility string
+		err           string
+	}{{
+		name: "add the field with default",
+		current: `{"type": "record", "name": "Book", "namespace": "acme", "fields": [
+			{"name": "name", "type": "string"},
+			{"name": "pages", "type": "int"},
+			{"name": "format", "type": {"type": "enum", "name": "Format", "symbols": ["paper", "e_book"]}},
+			{"name": "related", "type": ["null", {"type": "array", "items": "Book"}], "default": null},
+			{"name": "tags", "type": {"type": "array", "items": "string"}, "default": []}
+		]}`,
+		compatibility: FullCompatibility,
+	}, {
+		name: "add the field without default",
+		current: `{"type": "record", "name": "Book", "namespace": "acme", "fields": [
+			{"name": "name", "type": "string"},
+			{"name": "pages", "type": "int"},
+			{"name": "format", "type": {"type": "enum", "name": "Format", "symbols": ["paper", "e_book"]}},
+			{"name": "isbn", "type": "string"}
+		]}`,
+		compatibility: BackwardCompatibility,
+		err:           "the field isbn is added without the default value",
+	}, {
+		name: "promote and rename by the alias",
+		current: `{"type": "record", "name": "Book", "namespace": "acme", "fields": [
+			{"name": "title", "type": "string", "aliases": ["name"]},
+			{"name": "pages", "type": "long"},
+			{"name": "format", "type": {"type": "enum", "name": "Format", "symbols": ["paper", "e_book", "audio"]}}
+		]}`,
+		compatibility: BackwardCompatibility,
+	}, {
+		name: "the promoted field can not be read by the previous",
+		current: `{"type": "record", "name": "Book", "namespace": "acme", "fields": [
+			{"name": "name", "type": "string"},
+			{"name": "pages", "type": "long"},
+			{"name": "format", "type": {"type": "enum", "name": "Format", "symbols": ["paper", "e_book"]}}
+		]}`,
+		compatibility: ForwardCompatibility,
+		err:           "the long of the field pages can not be read as int",
+	}, {
+		name: "remove the enum symbol",
+		current: `{"type": "record", "name": "Book", "namespace": "acme", "fields": [
+			{"name": "name", "type": "string"},
+			{"name": "pages", "type": "int"},
+			{"name": "format", "type": {"type": "enum", "name": "Format", "symbols": ["paper"]}}
+		]}`,
+		compatibility: BackwardCompatibility,
+		err:           "the symbol e_book of the enum Format is removed without the default symbol",
+	}, {
+		name: "the union without the previous type",
+		current: `{"type": "record", "name": "Book", "namespace": "acme", "fields": [
+			{"name": "name", "type": ["null", "long"], "default": null},
+			{"name": "pages", "type": "int"},
+			{"name": "format", "type": {"type": "enum", "name": "Format", "symbols": ["paper", "e_book"]}}
+		]}`,
+		compatibility: BackwardCompatibility,
+		err:           "the string of the field name can not be read by any type of the union",
+	}, {
+		name: "incompatible but none",
+		current: `{"type": "record", "name": "Book", "namespace": "acme", "fields": [
+			{"name": "name", "type": "int"}
+		]}`,
+		compatibility: NoneCompatibility,
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := CheckCompatibility(c.compatibility, parse(t, previousSchema), parse(t, c.current))
+			if len(c.err) > 0 {
+				assert.EqualError(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckCompatibility_Unsupported(t *testing.T) {
+	s := parse(t, previousSchema)
+	assert.Error(t, CheckCompatibility("transitive", s, s))
+}
+
+func TestCheckCompatibility_LogicalTypes(t *testing.T) {
+	previous := parse(t, `{"type": "record", "name": "Order", "fields": [
+		{"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 18, "scale": 4}},
+		{"name": "create_time", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+	]}`)
+
+	assert.NoError(t, CheckCompatibility(FullCompatibility, previous, parse(t, `{"type": "record", "name": "Order", "fields": [
+		{"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 18, "scale": 4}},
+		{"name": "create_time", "type": "long"}
+	]}`)))
+
+	assert.EqualError(t, CheckCompatibility(BackwardCompatibility, previous, parse(t, `{"type": "record", "name": "Order", "fields": [
+		{"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 18, "scale": 2}},
+		{"name": "create_time", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+	]}`)), "the decimal(18, 4) of the field amount can not be read as decimal(18, 2)")
+
+	assert.EqualError(t, CheckCompatibility(BackwardCompatibility, previous, parse(t, `{"type": "record", "name": "Order", "fields": [
+		{"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 18, "scale": 4}},
+		{"name": "create_time", "type": {"type": "long", "logicalType": "timestamp-micros"}}
+	]}`)), "the logical type timestamp-millis of the field create_time can not be read as timestamp-micros")
+}
@@ -0,0 +1,288 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// the primitive types
+const (
+	NullType    = "null"
+	BooleanType = "boolean"
+	IntType     = "int"
+	LongType    = "long"
+	FloatType   = "float"
+	DoubleType  = "double"
+	BytesType   = "bytes"
+	StringType  = "string"
+)
+
+// the complex types
+const (
+	RecordType = "record"
+	EnumType   = "enum"
+	ArrayType  = "array"
+	MapType    = "map"
+	FixedType  = "fixed"
+)
+
+// the logical types
+const (
+	DateLogicalType            = "date"
+	TimeMillisLogicalType      = "time-millis"
+	TimeMicrosLogicalType      = "time-micros"
+	TimestampMillisLogicalType = "timestamp-millis"
+	TimestampMicrosLogicalType = "timestamp-micros"
+	UuidLogicalType            = "uuid"
+	DecimalLogicalType         = "decimal"
+)
+
+func IsPrimitiveType(name string) bool {
+	switch name {
+	case NullType, BooleanType, IntType, LongType, FloatType, DoubleType, BytesType, StringType:
+		return true
+	}
+	return false
+}
+
+func isComplexType(name string) bool {
+	switch name {
+	case RecordType, EnumType, ArrayType, MapType, FixedType:
+		return true
+	}
+	return false
+}
+
+// Schema the avro schema, which is a union if the Union is not nil, or a reference to the named type
+// declared before if the Type is neither the primitive nor the complex type
+type Schema struct {
+	Type      string
+	Name      string
+	Namespace string
+	Doc       string
+	Aliases   []string
+
+	// the record fields
+	Fields []*Field
+
+	// the enum symbols, and the default one for the unknown symbols
+	Symbols []string
+	Default string
+
+	// the array items and the map values
+	Items  *Schema
+	Values *Schema
+
+	// the size of the fixed
+	Size int
+
+	LogicalType string
+	Precision   int
+	Scale       int
+
+	Union []*Schema
+}
+
+// Field the field of the record, the default value is the JSON encoded one, nil if no default value
+type Field struct {
+	Name    string          `json:"name"`
+	Type    *Schema         `json:"type"`
+	Doc     string          `json:"doc,omitempty"`
+	Aliases []string        `json:"aliases,omitempty"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+// NullDefault the JSON default value of the optional fields
+var NullDefault = json.RawMessage("null")
+
+func NewType(name string) *Schema {
+	return &Schema{Type: name}
+}
+
+func NewLogicalType(typ string, logicalType string) *Schema {
+	return &Schema{Type: typ, LogicalType: logicalType}
+}
+
+func NewArray(items *Schema) *Schema {
+	return &Schema{Type: ArrayType, Items: items}
+}
+
+func NewMap(values *Schema) *Schema {
+	return &Schema{Type: MapType, Values: values}
+}
+
+func NewUnion(branches ...*Schema) *Schema {
+	return &Schema{Union: branches}
+}
+
+// NewOptional the union of the null and the type, the null is the first to be the type of the default value
+func NewOptional(s *Schema) *Schema {
+	if s.IsUnion() {
+		if !s.HasNull() {
+			s.Union = append([]*Schema{NewType(NullType)}, s.Union...)
+		}
+		return s
+	}
+	return NewUnion(NewType(NullType), s)
+}
+
+func (s *Schema) IsUnion() bool {
+	return s != nil && s.Union != nil
+}
+
+func (s *Schema) HasNull() bool {
+	for _, branch := range s.Union {
+		if branch.Type == NullType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReference the reference to the named type by the full name
+func (s *Schema) IsReference() bool {
+	return s != nil && !s.IsUnion() && !IsPrimitiveType(s.Type) && !isComplexType(s.Type)
+}
+
+// IsNamed the record, enum or fixed
+func (s *Schema) IsNamed() bool {
+	return s != nil && (s.Type == RecordType || s.Type == EnumType || s.Type == FixedType)
+}
+
+// FullName the full name of the named type, the namespace is inherited from the enclosing one if not set
+func (s *Schema) FullName(namespace string) string {
+	if strings.Contains(s.Name, ".") {
+		return s.Name
+	}
+	if len(s.Namespace) > 0 {
+		namespace = s.Namespace
+	}
+	if len(namespace) == 0 {
+		return s.Name
+	}
+	return namespace + "." + s.Name
+}
+
+// GetField the field of the record by the name
+func (s *Schema) GetField(name string) *Field {
+	if s != nil {
+		for _, field := range s.Fields {
+			if field.Name == name {
+				return field
+			}
+		}
+	}
+	return nil
+}
+
+// isSimple the primitive type or the reference without the other attributes, written as the type name
+func (s *Schema) isSimple() bool {
+	return len(s.Name) == 0 && len(s.Namespace) == 0 && len(s.Doc) == 0 && len(s.Aliases) == 0 &&
+		s.Fields == nil && s.Symbols == nil && len(s.Default) == 0 && s.Items == nil && s.Values == nil &&
+		s.Size == 0 && len(s.LogicalType) == 0 && s.Precision == 0 && s.Scale == 0
+}
+
+// the object form of the schema, keeping the order of the keys
+type object struct {
+	Type        string   `json:"type"`
+	Name        string   `json:"name,omitempty"`
+	Namespace   string   `json:"namespace,omitempty"`
+	Doc         string   `json:"doc,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Fields      []*Field `json:"fields,omitempty"`
+	Symbols     []string `json:"symbols,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Items       *Schema  `json:"items,omitempty"`
+	Values      *Schema  `json:"values,omitempty"`
+	Size        int      `json:"size,omitempty"`
+	LogicalType string   `json:"logicalType,omitempty"`
+	Precision   int      `json:"precision,omitempty"`
+	Scale       int      `json:"scale,omitempty"`
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.IsUnion() {
+		return json.Marshal(s.Union)
+	}
+
+	o := object{
+		Type:        s.Type,
+		Name:        s.Name,
+		Namespace:   s.Namespace,
+		Doc:         s.Doc,
+		Aliases:     s.Aliases,
+		Fields:      s.Fields,
+		Symbols:     s.Symbols,
+		Default:     s.Default,
+		Items:       s.Items,
+		Values:      s.Values,
+		Size:        s.Size,
+		LogicalType: s.LogicalType,
+		Precision:   s.Precision,
+		Scale:       s.Scale,
+	}
+	if s.isSimple() {
+		return json.Marshal(s.Type)
+	}
+	if s.Type == RecordType && o.Fields == nil {
+		// the record without fields should have the empty fields
+		o.Fields = []*Field{}
+	}
+	return json.Marshal(o)
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return fmt.Errorf("empty avro schema")
+	}
+
+	switch data[0] {
+	case '"':
+		*s = Schema{}
+		return json.Unmarshal(data, &s.Type)
+	case '[':
+		*s = Schema{Union: []*Schema{}}
+		return json.Unmarshal(data, &s.Union)
+	case '{':
+		o := object{}
+		if err := json.Unmarshal(data, &o); err != nil {
+			return err
+		}
+		*s = Schema{
+			Type:        o.Type,
+			Name:        o.Name,
+			Namespace:   o.Namespace,
+			Doc:         o.Doc,
+			Aliases:     o.Aliases,
+			Fields:      o.Fields,
+			Symbols:     o.Symbols,
+			Default:     o.Default,
+			Items:       o.Items,
+			Values:      o.Values,
+			Size:        o.Size,
+			LogicalType: o.LogicalType,
+			Precision:   o.Precision,
+			Scale:       o.Scale,
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid avro schema %s", string(data))
+}
+
+// File the schema of the top-level record written to the `.avsc` file
+type File struct {
+	Name   string
+	Schema *Schema
+}
+
+// Parse the avro schema in JSON
+func Parse(content []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(content, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const bookSchema = `{
+  "type": "record",
+  "name": "Book",
+  "namespace": "acme.library",
+  "doc": "the book",
+  "fields": [
+    {
+      "name": "name",
+      "type": "string",
+      "aliases": [
+        "title"
+      ]
+    },
+    {
+      "name": "format",
+      "type": {
+        "type": "enum",
+        "name": "Format",
+        "namespace": "acme.library.Book",
+        "symbols": [
+          "paper",
+          "e_book"
+        ]
+      }
+    },
+    {
+      "name": "price",
+      "type": [
+        "null",
+        {
+          "type": "bytes",
+          "logicalType": "decimal",
+          "precision": 18,
+          "scale": 4
+        }
+      ],
+      "default": null
+    },
+    {
+      "name": "labels",
+      "type": {
+        "type": "map",
+        "values": "string"
+      },
+      "default": {}
+    }
+  ]
+}`
+
+func TestSchema_JSON(t *testing.T) {
+	s, err := Parse([]byte(bookSchema))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, RecordType, s.Type)
+	assert.Equal(t, "acme.library.Book", s.FullName(""))
+	assert.Equal(t, []string{"title"}, s.GetField("name").Aliases)
+	assert.Equal(t, []string{"paper", "e_book"}, s.GetField("format").Type.Symbols)
+	assert.True(t, s.GetField("price").Type.HasNull())
+	assert.Equal(t, 18, s.GetField("price").Type.Union[1].Precision)
+	assert.Equal(t, NullDefault, s.GetField("price").Default)
+
+	content, err := json.MarshalIndent(s, "", "  ")
+	assert.NoError(t, err)
+	assert.Equal(t, bookSchema, string(content))
+}
+
+func TestNewOptional(t *testing.T) {
+	optional := NewOptional(NewUnion(NewType(StringType), NewType(LongType)))
+	content, err := json.Marshal(optional)
+	assert.NoError(t, err)
+	assert.Equal(t, `["null","string","long"]`, string(content))
+
+	content, err = json.Marshal(NewOptional(optional))
+	assert.NoError(t, err)
+	assert.Equal(t, `["null","string","long"]`, string(content))
+}
@@ -32,10 +32,9 @@ func (p Parser) ParseStream(fileName string, input antlr.CharStream) (*lang.Sour
 	errorListener := util.NewErrorListener(fileName, false)
 
 	parser := NewCParser(stream)
-	parser.AddErrorListener(errorListener)
 	parser.BuildParseTrees = true
 
-	tree := parser.File()
+	tree := util.ParseTwoStage(parser, errorListener, parser.File)
 	if sourceFile, ok := tree.Accept(NewFileVisitor()).(*lang.SourceFile); ok {
 		if len(errorListener.Errors) == 0 {
 			return sourceFile, nil
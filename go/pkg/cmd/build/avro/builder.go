@@ -0,0 +1,44 @@
+package avro
+
+import (
+	"path"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+
+	"github.com/mojo-lang/mojo/go/pkg/avro/converter"
+	"github.com/mojo-lang/mojo/go/pkg/avro/generator"
+	"github.com/mojo-lang/mojo/go/pkg/cmd/build/builder"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+type Builder struct {
+	builder.Builder
+	Output string
+
+	// the compatibility checked against the schemas generated before, backward, forward, full or none
+	Compatibility string
+}
+
+func (b Builder) Build() error {
+	logs.Infow("begin to build avro.", "package", b.Package.FullName, "path", b.Path)
+
+	c := converter.New()
+	if err := c.ConvertPackage(b.GetContext(), b.Package); err != nil {
+		logs.Errorw("failed to convert avro", "package", b.Package.FullName, "error", err.Error())
+		return err
+	}
+
+	if !b.APIEnabled {
+		logs.Infow("disable generation, skip to generate avro.")
+		return nil
+	}
+
+	output := path.Join(b.GetAbsolutePath(), "avro")
+	if len(b.Output) > 0 {
+		output = util.GetAbsolutePath(b.PWD, b.Output)
+	}
+
+	g := generator.New()
+	g.Compatibility = b.Compatibility
+	return g.GenerateFilesTo(c.Files, output)
+}
@@ -3,10 +3,14 @@ package builder
 import (
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 
+	"github.com/mojo-lang/mojo/go/pkg/context"
 	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
 type Builder struct {
+	// Context carries the trace span of the target, empty if not set
+	Context context.Context
+
 	PWD     string
 	Path    string
 	Package *lang.Package
@@ -17,3 +21,10 @@ type Builder struct {
 func (b Builder) GetAbsolutePath() string {
 	return util.GetAbsolutePath(b.PWD, b.Path)
 }
+
+func (b Builder) GetContext() context.Context {
+	if b.Context == nil {
+		return context.Empty()
+	}
+	return b.Context
+}
@@ -0,0 +1,54 @@
+package descriptor
+
+import (
+	"path"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mojo-lang/mojo/go/pkg/cmd/build/builder"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/compiler"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+const fileExt = ".binpb"
+
+type Builder struct {
+	builder.Builder
+	Output string
+	Files  []*descriptor.File
+
+	// include the source code info, the locations and the comments of the mojo declarations
+	SourceInfo bool
+}
+
+// Build writes the serialized FileDescriptorSet of the package and its dependencies to the `<package>.binpb`,
+// which can be consumed by the grpcurl, buf, envoy transcoder or the schema registry
+func (b Builder) Build() error {
+	logs.Infow("begin to build descriptor set.", "package", b.Package.FullName, "path", b.Path)
+
+	set, err := compiler.DescriptorSet(b.Package, b.Files, b.SourceInfo)
+	if err != nil {
+		logs.Errorw("failed to build the descriptor set", "package", b.Package.FullName, "error", err.Error())
+		return err
+	}
+
+	content, err := proto.MarshalOptions{Deterministic: true}.Marshal(set)
+	if err != nil {
+		logs.Errorw("failed to marshal the descriptor set", "package", b.Package.FullName, "error", err.Error())
+		return err
+	}
+
+	output := path.Join(b.GetAbsolutePath(), "descriptor")
+	if len(b.Output) > 0 {
+		output = util.GetAbsolutePath(b.PWD, b.Output)
+	}
+
+	file := &util.GeneratedFile{
+		Name:    b.Package.FullName + fileExt,
+		Content: string(content),
+	}
+	// the binary file has no generated header, so the stale files are only pruned by the manifest
+	return file.WriteTo(output, &util.PathGuard{DisableClear: true, Target: "descriptor"})
+}
@@ -0,0 +1,56 @@
+package external
+
+import (
+	path2 "path"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+
+	"github.com/mojo-lang/mojo/go/pkg/cmd/build/builder"
+	"github.com/mojo-lang/mojo/go/pkg/plugin/external"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+// Builder build the target by the out-of-process generator plugin `mojo-gen-<target>` in the PATH
+type Builder struct {
+	builder.Builder
+	Target  string
+	Output  string
+	Options map[string]string
+}
+
+func (b Builder) Build() error {
+	logs.Infow("begin to build the external target.", "target", b.Target, "pwd", b.PWD, "path", b.Path)
+
+	generator, err := external.LookupGenerator(b.Target)
+	if err != nil {
+		return err
+	}
+
+	files, err := generator.Generate(b.GetContext(), &external.Request{
+		Target:  b.Target,
+		Package: b.Package,
+		Options: b.Options,
+	})
+	if err != nil {
+		logs.Errorw("failed to generate the external target", "target", b.Target, "package", b.Package.FullName, "error", err.Error())
+		return err
+	}
+
+	output := path2.Join(b.GetAbsolutePath(), b.Target)
+	if len(b.Output) > 0 {
+		output = b.Output
+	}
+	guard := &util.PathGuard{
+		OnlyClearGenerated: true,
+		Target:             b.Target,
+	}
+	if err = guard.Record(output); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err = f.WriteTo(output, guard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package _go
 import (
 	"os/exec"
 	path2 "path"
+	"path/filepath"
 
 	"github.com/mojo-lang/mojo/go/pkg/go/generator"
 	"github.com/mojo-lang/mojo/go/pkg/util"
@@ -11,6 +12,7 @@ import (
 	desc "github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
 
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/builder"
+	"github.com/mojo-lang/mojo/go/pkg/context"
 )
 
 type Builder struct {
@@ -28,7 +30,7 @@ func (b Builder) Build() error {
 	logs.Infow("begin to build go.", "pwd", b.PWD, "path", b.Path)
 
 	compiler := generator.NewCompiler(b.GetAbsolutePath(), b.Files)
-	files, err := compiler.CompilePackage(b.Package)
+	files, err := compiler.CompilePackage(b.GetContext(), b.Package)
 	if err != nil {
 		logs.Errorw("failed to compile go", "package", b.Package.FullName, "error", err.Error())
 		return err
@@ -45,15 +47,29 @@ func (b Builder) Build() error {
 		return err
 	}
 
-	return GoModTidy(output)
+	return GoModTidy(b.GetContext(), output)
 }
 
-func GoModTidy(pwd string) error {
+// GoModTidy run `go mod tidy` in the dir, in the dry run it runs in a copy of the parent dir,
+// so the modules replaced by the sibling dirs like `../go` are resolved as well
+func GoModTidy(ctx context.Context, pwd string) error {
+	pwd, err := filepath.Abs(pwd)
+	if err != nil {
+		return err
+	}
+	return util.RunInOverlay(path2.Dir(pwd), func(root string) error {
+		return goModTidy(ctx, path2.Join(root, path2.Base(pwd)))
+	})
+}
+
+func goModTidy(ctx context.Context, pwd string) error {
 	cmd := exec.Command("go", "mod", "tidy")
 	cmd.Dir = pwd
 
 	logs.Info("begin to running go mod tidy")
+	span, _ := util.BeginSpan(ctx, util.ProcessSpan, "go mod tidy", "dir", pwd)
 	out, err := cmd.CombinedOutput()
+	span.End()
 	if err != nil {
 		logs.Errorw("failed to run go mod tidy", "error", string(out))
 		return err
@@ -8,9 +8,11 @@ import (
 	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
 	"github.com/otiai10/copy"
 	"github.com/pkg/errors"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/builder"
 	gogen "github.com/mojo-lang/mojo/go/pkg/go/generator/generator"
@@ -23,9 +25,10 @@ type Builder struct {
 	Files  []*descriptor.File
 }
 
-func (b Builder) protocJava() error {
+// protocJava generate the java files of the protobuf files by protoc, returns the generated files relative to the java dir
+func (b Builder) protocJava() ([]string, error) {
 	if b.Package == nil {
-		return errors.New("")
+		return nil, errors.New("")
 	}
 
 	var tempPbDirs []string
@@ -37,7 +40,7 @@ func (b Builder) protocJava() error {
 			pbDir, err := gogen.GenerateMojoPackageProtobuf(dep)
 			if err != nil {
 				logs.Errorw("failed to generate mojo package's protobuf files", "package", dep.FullName, "error", err)
-				return err
+				return nil, err
 			}
 			if len(pbDir) > 0 {
 				tempPbDirs = append(tempPbDirs, pbDir)
@@ -67,10 +70,12 @@ func (b Builder) protocJava() error {
 			Dir:  cmd.Dir,
 		}
 		fileCmd.Args = append(fileCmd.Args, file.GetName())
+		span, _ := util.BeginSpan(b.GetContext(), util.ProcessSpan, "protoc", "lang", "java", "file", file.GetName())
 		out, err := fileCmd.CombinedOutput()
+		span.End()
 		if err != nil {
 			logs.Errorw("failed to run protoc cmd", "error", string(out), "cmd", cmd.String())
-			return err
+			return nil, err
 		}
 	}
 	defer func() {
@@ -85,29 +90,42 @@ func (b Builder) protocJava() error {
 	destDir := path.Join(b.GetAbsolutePath(), "java/src/main/java")
 	if !core.IsExist(destDir) {
 		if err := core.CreateDir(destDir); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	if err := util.DeepClearGeneratedFiles(destDir, ".java"); err != nil {
-		return err
+		return nil, err
 	}
 
+	var files []string
+
 	for _, domain := range []string{"ai", "biz", "cn", "com", "edu", "gov", "net", "org", "info", "io", "tech"} {
 		srcDir := path.Join(b.GetAbsolutePath(), "protobuf", domain)
 		_, err := os.ReadDir(srcDir)
 		if err == nil {
-			if err = copy.Copy(srcDir, path.Join(destDir, domain)); err != nil {
+			err = filepath.WalkDir(srcDir, func(p string, entry fs.DirEntry, err error) error {
+				if err != nil || entry.IsDir() {
+					return err
+				}
+				rel, err := filepath.Rel(srcDir, p)
+				files = append(files, path.Join("src/main/java", domain, filepath.ToSlash(rel)))
 				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err = copy.Copy(srcDir, path.Join(destDir, domain)); err != nil {
+				return nil, err
 			}
 			if err = os.RemoveAll(srcDir); err != nil {
-				return err
+				return nil, err
 			}
 			break
 		}
 	}
 
-	return nil
+	return files, nil
 }
 
 func (b Builder) build() error {
@@ -144,19 +162,26 @@ func (b Builder) Build() error {
 	}
 	b.Output = path.Join(b.Output, "java")
 
-	// protoc the protobuf files to java files
-	if err := b.protocJava(); err != nil {
-		return err
-	}
-
-	// other java files builder
-	if err := b.build(); err != nil {
+	// protoc writes the java files to the disk directly, which are written back to the overlay in the dry run
+	var files []string
+	err := util.RunInOverlay(b.GetAbsolutePath(), func(root string) (err error) {
+		protoc := b
+		protoc.PWD, protoc.Path = root, ""
+		if files, err = protoc.protocJava(); err != nil {
+			return err
+		}
+		return generator.UpdateProtoJavaFiles(path.Join(root, "java"))
+	})
+	if err != nil {
 		return err
 	}
 
-	if err := generator.UpdateProtoJavaFiles(b.Output); err != nil {
+	// record the java files of protoc to the manifest, so they are pruned when the protobuf files are removed
+	guard := &util.PathGuard{DisableClear: true, Target: "java"}
+	if err = guard.Record(path.Join(b.GetAbsolutePath(), "java"), files...); err != nil {
 		return err
 	}
 
-	return nil
+	// other java files builder
+	return b.build()
 }
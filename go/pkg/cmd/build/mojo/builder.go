@@ -8,26 +8,49 @@ import (
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/builder"
-	"github.com/mojo-lang/mojo/go/pkg/context"
 	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
-	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/dumper"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
 	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
 	"github.com/mojo-lang/mojo/go/pkg/plugin"
 )
 
+// DumpDir the default directory in the package to dump the packages
+const DumpDir = ".mojo-dump"
+
 type Builder struct {
 	builder.Builder
+
+	// DisableCache compile all the packages from the source, without the build cache
+	DisableCache bool
+
+	// Dumper write the package before or after the plugins for debugging, the build cache will be disabled
+	Dumper *dumper.Dumper
 }
 
 func (b Builder) Build() (*lang.Package, error) {
 	logs.Infow("begin to parse mojo package.", "pwd", b.PWD, "path", b.Path)
 
-	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	plugins, err := plugin.ResolvePlugins("mpm", "syntax", "semantic", "compiler")
+	if err != nil {
+		return nil, err
+	}
 
 	if strings.HasPrefix(b.Path, b.PWD) {
 		b.Path = strings.TrimPrefix(b.Path, b.PWD)
 	}
-	pkg, err := plugins.ParsePath(context.Empty(), path.Join(b.PWD, b.Path))
+	ctx := b.GetContext()
+	if b.Dumper != nil && len(b.Dumper.Before)+len(b.Dumper.After) > 0 {
+		if len(b.Dumper.Output) == 0 {
+			b.Dumper.Output = path.Join(b.GetAbsolutePath(), DumpDir)
+		}
+		ctx = plugin.WithPluginHook(ctx, b.Dumper)
+		b.DisableCache = true
+	}
+	if b.DisableCache {
+		ctx = mpm.WithCacheDisabled(ctx)
+	}
+	pkg, err := plugins.ParsePath(ctx, path.Join(b.PWD, b.Path))
 	if err != nil {
 		return nil, err
 	}
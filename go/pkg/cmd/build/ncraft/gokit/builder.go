@@ -61,21 +61,23 @@ func (b Builder) Build() error {
 	}
 
 	if b.Type == "client" {
-		setDefaultRepository("client")
+		setDefaultRepository("client-go")
 		b.Output = path2.Join(b.Output, path2.Base(b.Repository))
 		cb := &ClientBuilder{
-			Builder:    b.Builder,
-			Output:     b.Output,
-			Repository: b.Repository,
+			Builder:       b.Builder,
+			Output:        b.Output,
+			Repository:    b.Repository,
+			ApiRepository: path2.Join(b.Package.Repository.FormatWithoutSchema(), "go"),
 		}
 		return cb.Build()
 	} else if b.Type == "sidecar" {
-		setDefaultRepository("sidecar")
+		setDefaultRepository("sidecar-go")
 		b.Output = path2.Join(b.Output, path2.Base(b.Repository))
 		sb := &SidecarBuilder{
-			Builder:    b.Builder,
-			Output:     b.Output,
-			Repository: b.Repository,
+			Builder:       b.Builder,
+			Output:        b.Output,
+			Repository:    b.Repository,
+			ApiRepository: path2.Join(b.Package.Repository.FormatWithoutSchema(), "go"),
 		}
 		return sb.Build()
 	}
@@ -103,15 +105,32 @@ func (b Builder) Build() error {
 		ApiRepository: path2.Join(b.Package.Repository.FormatWithoutSchema(), "go"),
 		Output:        b.Output,
 		MixedInAPI:    b.APIEnabled,
-		PreviousFiles: make(map[string]io.Reader),
 	}
 
-	prefixPath := b.Output
+	if conf.PreviousFiles, err = loadPreviousFiles(b.Output); err != nil {
+		return err
+	}
+
+	for _, s := range services {
+		err = gokit.GenerateService(s, conf)
+		if err != nil {
+			logs.Errorw("generate ncraft gokit failed", "pwd", b.PWD, "path", b.Path, "package", b.Package.FullName, "error", err.Error())
+			return err
+		}
+	}
+
+	return _go.GoModTidy(b.GetContext(), b.Output)
+}
+
+// loadPreviousFiles opens the previous generated files in the output, to keep the user codes
+func loadPreviousFiles(output string) (map[string]io.Reader, error) {
+	files := make(map[string]io.Reader)
+	prefixPath := output
 	if !strings.HasSuffix(prefixPath, "/") {
 		prefixPath += "/"
 	}
-	if core.IsExist(b.Output) {
-		err = filepath.Walk(b.Output, func(path string, f os.FileInfo, err error) error {
+	if core.IsExist(output) {
+		err := filepath.Walk(output, func(path string, f os.FileInfo, err error) error {
 			if f == nil {
 				return err
 			}
@@ -122,21 +141,12 @@ func (b Builder) Build() error {
 
 			reader, err := os.Open(path)
 			name := strings.TrimPrefix(path, prefixPath)
-			conf.PreviousFiles[name] = reader
+			files[name] = reader
 			return nil
 		})
 		if err != nil {
-			return errors.Wrap(err, "filepath.Walk() failed")
+			return nil, errors.Wrap(err, "filepath.Walk() failed")
 		}
 	}
-
-	for _, s := range services {
-		err = gokit.GenerateService(s, conf)
-		if err != nil {
-			logs.Errorw("generate ncraft gokit failed", "pwd", b.PWD, "path", b.Path, "package", b.Package.FullName, "error", err.Error())
-			return err
-		}
-	}
-
-	return _go.GoModTidy(b.Output)
+	return files, nil
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/mojo-lang/core/go/pkg/mojo/core"
 
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/builder"
+	_go "github.com/mojo-lang/mojo/go/pkg/cmd/build/go"
 	"github.com/mojo-lang/mojo/go/pkg/context"
 	"github.com/mojo-lang/mojo/go/pkg/ncraft/compiler"
 	"github.com/mojo-lang/mojo/go/pkg/ncraft/gokit"
@@ -14,7 +15,8 @@ type ClientBuilder struct {
 	builder.Builder
 	Output string
 
-	Repository string
+	Repository    string
+	ApiRepository string
 }
 
 func (b ClientBuilder) Build() error {
@@ -24,7 +26,7 @@ func (b ClientBuilder) Build() error {
 	for _, pkg := range b.Package.GetAllPackages() {
 		options[pkg.FullName] = getPackageImport(pkg)
 	}
-	for _, pkg := range b.Package.ResolvedDependencies {
+	for _, pkg := range b.Package.GetAllDependentPackages() {
 		options[pkg.FullName] = getPackageImport(pkg)
 	}
 
@@ -38,8 +40,10 @@ func (b ClientBuilder) Build() error {
 
 	services := cmp.Services
 	conf := gokit.Options{
-		Repository: b.Repository,
-		Output:     b.Output,
+		Repository:    b.Repository,
+		ApiRepository: b.ApiRepository,
+		Output:        b.Output,
+		MixedInAPI:    b.APIEnabled,
 	}
 
 	for _, s := range services {
@@ -50,5 +54,5 @@ func (b ClientBuilder) Build() error {
 		}
 	}
 
-	return nil
+	return _go.GoModTidy(b.GetContext(), b.Output)
 }
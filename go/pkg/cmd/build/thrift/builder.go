@@ -0,0 +1,40 @@
+package thrift
+
+import (
+	"path"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+
+	"github.com/mojo-lang/mojo/go/pkg/cmd/build/builder"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/converter"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/generator"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+type Builder struct {
+	builder.Builder
+	Output string
+}
+
+func (b Builder) Build() ([]*descriptor.File, error) {
+	logs.Infow("begin to build thrift.", "package", b.Package.FullName, "path", b.Path)
+
+	c := converter.New()
+	if err := c.ConvertPackage(b.GetContext(), b.Package); err != nil {
+		logs.Errorw("failed to convert thrift", "package", b.Package.FullName, "error", err.Error())
+		return nil, err
+	}
+
+	if !b.APIEnabled {
+		logs.Infow("disable generation, skip to generate thrift.")
+		return c.Files, nil
+	}
+
+	output := path.Join(b.GetAbsolutePath(), "thrift")
+	if len(b.Output) > 0 {
+		output = util.GetAbsolutePath(b.PWD, b.Output)
+	}
+
+	return c.Files, generator.New().GenerateFilesTo(c.Files, output)
+}
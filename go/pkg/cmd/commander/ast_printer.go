@@ -0,0 +1,42 @@
+package commander
+
+import (
+	"io"
+	"os"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/dumper"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+)
+
+// AstPrinter print the syntax tree of the mojo source file as json or yaml, with the source positions
+type AstPrinter struct {
+	File string
+
+	// json or yaml, default is json
+	Format string
+
+	Output io.Writer
+}
+
+func (p *AstPrinter) Execute() error {
+	if p.Output == nil {
+		p.Output = os.Stdout
+	}
+
+	plugins, err := plugin.ResolvePlugins("syntax")
+	if err != nil {
+		return err
+	}
+	file, err := plugins.ParseFile(context.Empty(), p.File)
+	if err != nil {
+		return err
+	}
+
+	content, err := dumper.Marshal(file, p.Format)
+	if err != nil {
+		return err
+	}
+	_, err = p.Output.Write(content)
+	return err
+}
@@ -0,0 +1,154 @@
+package commander
+
+import (
+	"fmt"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+const (
+	MojoTarget          = "mojo"
+	OpenAPITarget       = "openapi"
+	DocumentTarget      = "document"
+	ProtobufTarget      = "protobuf"
+	DescriptorTarget    = "descriptor"
+	ThriftTarget        = "thrift"
+	AvroTarget          = "avro"
+	GoTarget            = "go"
+	JavaTarget          = "java"
+	NcraftServiceTarget = "ncraft.service"
+	NcraftClientTarget  = "ncraft.client"
+	NcraftSidecarTarget = "ncraft.sidecar"
+
+	APITargets    = "api"
+	NcraftTargets = "ncraft"
+)
+
+func init() {
+	RegisterTarget(&BasicTarget{
+		Name:     MojoTarget,
+		Usage:    "parse and compile the mojo package",
+		Provides: []string{PackageArtifact},
+		Builder:  (*Builder).buildMojo,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     OpenAPITarget,
+		Usage:    "compile the package to openapi & generate the openapi files",
+		Requires: []string{PackageArtifact},
+		Provides: []string{OpenAPIsArtifact},
+		Builder:  (*Builder).buildOpenapi,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     DocumentTarget,
+		Usage:    "generate the markdown documents of the package",
+		Requires: []string{PackageArtifact, OpenAPIsArtifact},
+		Builder:  (*Builder).buildDocument,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     ProtobufTarget,
+		Usage:    "compile the package to protobuf & generate the protobuf files",
+		Requires: []string{PackageArtifact},
+		Provides: []string{DescriptorsArtifact},
+		Builder:  (*Builder).buildProtobuf,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     DescriptorTarget,
+		Usage:    "generate the serialized protobuf FileDescriptorSet of the package and its dependencies",
+		Requires: []string{PackageArtifact, DescriptorsArtifact},
+		Builder:  (*Builder).buildDescriptor,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     ThriftTarget,
+		Usage:    "compile the package to thrift & generate the thrift IDL files",
+		Requires: []string{PackageArtifact},
+		Builder:  (*Builder).buildThrift,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     AvroTarget,
+		Usage:    "generate the avro schemas of the structs & check the compatibility against the previous ones",
+		Requires: []string{PackageArtifact},
+		Builder:  (*Builder).buildAvro,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     GoTarget,
+		Usage:    "generate the golang api files",
+		Requires: []string{PackageArtifact, DescriptorsArtifact},
+		Provides: []string{GoAPIArtifact},
+		Builder:  (*Builder).buildGo,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     JavaTarget,
+		Usage:    "generate the java api files",
+		Requires: []string{PackageArtifact, DescriptorsArtifact},
+		Builder:  (*Builder).buildJava,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     NcraftServiceTarget,
+		Usage:    "generate the ncraft service by the engine, gokit or boot",
+		Requires: []string{PackageArtifact},
+		// the go api is generated before, so the go.mod of the service could refer to it
+		OptionalRequires: []string{GoAPIArtifact},
+		Builder:          (*Builder).buildNcraftService,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     NcraftClientTarget,
+		Usage:    "generate the ncraft client sdk & cli by the engine, gokit",
+		Requires: []string{PackageArtifact},
+		// the go api is generated before, so the go.mod of the service could refer to it
+		OptionalRequires: []string{GoAPIArtifact},
+		Builder:          (*Builder).buildNcraftClient,
+	})
+	RegisterTarget(&BasicTarget{
+		Name:     NcraftSidecarTarget,
+		Usage:    "generate the ncraft sidecar forwarding to the upstream service by the engine, gokit",
+		Requires: []string{PackageArtifact},
+		// the go api is generated before, so the go.mod of the service could refer to it
+		OptionalRequires: []string{GoAPIArtifact},
+		Builder:          (*Builder).buildNcraftSidecar,
+	})
+
+	RegisterTargetAlias(APITargets, OpenAPITarget, DocumentTarget, ProtobufTarget, GoTarget, JavaTarget)
+	RegisterTargetAlias(NcraftTargets, NcraftServiceTarget, NcraftClientTarget, NcraftSidecarTarget)
+	// the `service` only generates the ncraft service, the same files as before the target registry, as the api
+	// targets were only compiled without generating unless the `api` is also specified, like `-t api,service`
+	RegisterTargetAlias("service", NcraftServiceTarget)
+	RegisterTargetAlias("client", NcraftClientTarget)
+	RegisterTargetAlias("sidecar", NcraftSidecarTarget)
+}
+
+// engine returns the ncraft engine, gokit by default. the ncraft targets may be built concurrently, so not set it back
+func (b *Builder) engine() string {
+	if len(b.Engine) == 0 {
+		return "gokit"
+	}
+	return b.Engine
+}
+
+func (b *Builder) buildNcraftService(ctx context.Context) error {
+	switch b.engine() {
+	case "gokit":
+		return b.buildGokit(ctx, "service")
+	case "boot":
+		return b.buildBoot(ctx, "service")
+	default:
+		return fmt.Errorf("unsupported ncraft engine: %s", b.engine())
+	}
+}
+
+func (b *Builder) buildNcraftClient(ctx context.Context) error {
+	switch b.engine() {
+	case "gokit":
+		return b.buildGokit(ctx, "client")
+	default:
+		return fmt.Errorf("unsupported ncraft engine for the client: %s", b.engine())
+	}
+}
+
+func (b *Builder) buildNcraftSidecar(ctx context.Context) error {
+	switch b.engine() {
+	case "gokit":
+		return b.buildGokit(ctx, "sidecar")
+	default:
+		return fmt.Errorf("unsupported ncraft engine for the sidecar: %s", b.engine())
+	}
+}
@@ -1,23 +1,36 @@
 package commander
 
 import (
-	"github.com/mojo-lang/mojo/go/pkg/cmd/build/ncraft/boot"
+	"fmt"
+	"os"
 	"path"
 	"strings"
 
+	"github.com/urfave/cli/v2"
+
 	"github.com/mojo-lang/core/go/pkg/mojo/core"
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 	api "github.com/mojo-lang/openapi/go/pkg/mojo/openapi"
 	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
 
+	"github.com/mojo-lang/mojo/go/pkg/cmd/build/avro"
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/builder"
+	descriptorset "github.com/mojo-lang/mojo/go/pkg/cmd/build/descriptor"
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/document"
+	"github.com/mojo-lang/mojo/go/pkg/cmd/build/external"
 	_go "github.com/mojo-lang/mojo/go/pkg/cmd/build/go"
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/java"
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/mojo"
+	"github.com/mojo-lang/mojo/go/pkg/cmd/build/ncraft/boot"
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/ncraft/gokit"
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/openapi"
 	"github.com/mojo-lang/mojo/go/pkg/cmd/build/protobuf"
+	"github.com/mojo-lang/mojo/go/pkg/cmd/build/thrift"
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/dumper"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
 type Builder struct {
@@ -30,23 +43,78 @@ type Builder struct {
 	Targets string
 	Engine  string
 
+	// print the available targets instead of building
+	ListTargets bool
+
+	// the number of the independent targets built concurrently, less than 1 means the number of the CPUs
+	Jobs int
+
 	Output string
 
+	// include the source code info in the descriptor set
+	SourceInfo bool
+
+	// the compatibility of the avro schemas checked against the previously generated ones
+	AvroCompatibility string
+
 	Pwd  string
 	Path string
 
 	APIEnabled bool
 
-	NcraftAllEnabled     bool
-	NcraftServiceEnabled bool
-	NcraftClientEnabled  bool
-	NcraftSidecarEnabled bool
+	DisableCache bool
+
+	// dump the package before or after the plugins (or plugin groups) for debugging
+	DumpBefore cli.StringSlice
+	DumpAfter  cli.StringSlice
+	DumpFormat string
+	DumpOutput string
+
+	// print the files would be created, modified or deleted instead of writing them
+	DryRun bool
+	// print the unified diffs of the files against the disk, implies DryRun
+	Diff bool
+	// fail if any file would be changed, for checking the committed generated code is up-to-date
+	Verify bool
+
+	// write the spans of the plugins, generators and external processes to the file in the Chrome trace event format
+	Trace string
+
+	// the plugin options like `compiler.pagination.auto=false`, override the ones in the `mojo.yaml`
+	PluginOptions cli.StringSlice
 
 	// the git repository for the generated code
 	Repository string
 }
 
-func (b *Builder) Execute() error {
+func (b *Builder) Execute() (err error) {
+	if b.ListTargets {
+		return (&TargetLister{}).Execute()
+	}
+
+	if len(b.Trace) > 0 {
+		util.StartTrace()
+		defer func() {
+			if e := b.writeTrace(util.StopTrace()); e != nil && err == nil {
+				err = e
+			}
+		}()
+	}
+
+	if b.DryRun || b.Diff || b.Verify {
+		util.StartDryRun()
+		defer func() {
+			dryRun := util.StopDryRun()
+			if err == nil {
+				err = b.reportChanges(dryRun.Changes())
+			}
+		}()
+	}
+
+	return b.execute()
+}
+
+func (b *Builder) execute() error {
 	if len(b.Path) == 0 {
 		b.Path = "./"
 	}
@@ -58,90 +126,141 @@ func (b *Builder) Execute() error {
 		}
 	}
 
-	targets := strings.Split(b.Targets, ",")
-	for _, target := range targets {
-		switch target {
-		case "api":
+	names := ExpandTargets(strings.Split(b.Targets, ",")...)
+	for _, name := range names {
+		// generate the api files only if any api target is specified, otherwise they are only compiled for the others
+		if isAPITarget(name) {
 			b.APIEnabled = true
-		case "ncraft":
-			b.NcraftAllEnabled = true
-		case "ncraft.service", "service":
-			b.NcraftServiceEnabled = true
-		case "ncraft.client", "client":
-			b.NcraftClientEnabled = true
-		case "ncraft.sidecar", "sidecar":
-			b.NcraftSidecarEnabled = true
 		}
 	}
-
-	// build the mojo package
-	if err := b.buildMojo(); err != nil {
+	stages, err := ResolveTargets(names...)
+	if err != nil {
 		return err
 	}
 
-	// compile the target package to openapi
-	if err := b.buildOpenapi(); err != nil {
+	if err = b.loadConfig(); err != nil {
 		return err
 	}
 
-	// compile the target package to document
-	if err := b.buildDocument(); err != nil {
-		return err
+	manifests := util.StartManifests()
+	defer util.StopManifests()
+
+	for _, stage := range stages {
+		err = util.ParallelWorkers(len(stage), b.Jobs, func(worker int, i int) error {
+			// the targets in the same stage are shown in the threads of the workers in the trace
+			return b.buildTarget(util.WithTid(context.Empty(), util.MainTid+worker), stage[i])
+		})
+		if err != nil {
+			return err
+		}
 	}
 
-	// compile the target package to protobuf & generate the protobuf files
-	if err := b.buildProtobuf(); err != nil {
-		return err
+	// remove the files not generated anymore, and update the manifests in the output directories
+	return manifests.Prune()
+}
+
+func (b *Builder) buildTarget(ctx context.Context, target Target) error {
+	span, ctx := util.BeginSpan(ctx, util.GeneratorSpan, target.GetName())
+	defer span.End()
+	return target.Build(ctx, b)
+}
+
+func isAPITarget(name string) bool {
+	for _, n := range targetAliases[APITargets] {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// writeTrace write the Chrome trace file and print the summary of the spans
+func (b *Builder) writeTrace(tracer *util.Tracer) error {
+	if tracer == nil {
+		return nil
 	}
 
-	// generate the target package to golang
-	if err := b.buildGo(); err != nil {
+	file, err := os.Create(util.GetAbsolutePath(b.Pwd, b.Trace))
+	if err != nil {
 		return err
 	}
+	defer file.Close()
 
-	// compile the resource to sql orm file (including sql script, create table)
-	if b.NcraftAllEnabled || b.NcraftServiceEnabled {
-		if len(b.Engine) == 0 {
-			b.Engine = "gokit"
-		}
+	if err = tracer.WriteChromeTrace(file); err != nil {
+		return err
+	}
+	return tracer.PrintSummary(os.Stdout)
+}
 
-		switch b.Engine {
-		case "gokit":
-			if err := b.buildGokit("service"); err != nil {
-				return err
-			}
-		case "boot":
-			if err := b.buildBoot("service"); err != nil {
-				return err
-			}
-		}
+// reportChanges print the files changed in the dry run, and fail if verifying and any file is changed
+func (b *Builder) reportChanges(changes []*util.FileChange) error {
+	if len(changes) == 0 {
+		fmt.Println("all the generated files are up-to-date")
+		return nil
 	}
-	if b.NcraftAllEnabled || b.NcraftClientEnabled {
+
+	if err := util.PrintChanges(os.Stdout, util.GetAbsolutePath(b.Pwd, ""), changes, b.Diff); err != nil {
+		return err
 	}
-	if b.NcraftAllEnabled || b.NcraftSidecarEnabled {
+	if b.Verify {
+		return fmt.Errorf("the generated files are out of date, %d files would be changed, please run `mojo build` to regenerate them", len(changes))
 	}
+	return nil
+}
 
-	// generate the target package to java
-	if err := b.buildJava(); err != nil {
+// loadConfig merge the `mojo.yaml` in the package path and the plugin options from the command line,
+// the environment still has the highest priority over the `mojo.yaml`, and the unknown plugin options are rejected
+func (b *Builder) loadConfig() error {
+	c, err := config.Load(util.GetAbsolutePath(b.Pwd, b.Path))
+	if err != nil {
 		return err
 	}
+	config.Get().Merge(c).Merge(config.FromEnv())
 
-	return nil
+	for _, option := range b.PluginOptions.Value() {
+		if err = plugin.SetOption(option); err != nil {
+			return err
+		}
+	}
+	return plugin.ValidateOptions(config.Get().Plugins)
 }
 
-func (b *Builder) buildMojo() (err error) {
+func (b *Builder) buildMojo(ctx context.Context) (err error) {
 	b.Package, err = mojo.Builder{
 		Builder: builder.Builder{
-			PWD:  b.Pwd,
-			Path: b.Path,
+			Context: ctx,
+			PWD:     b.Pwd,
+			Path:    b.Path,
+		},
+		DisableCache: b.DisableCache,
+		Dumper: &dumper.Dumper{
+			Before: b.DumpBefore.Value(),
+			After:  b.DumpAfter.Value(),
+			Format: b.DumpFormat,
+			Output: b.DumpOutput,
 		},
 	}.Build()
 	return err
 }
 
-func (b *Builder) buildProtobuf() (err error) {
+func (b *Builder) buildProtobuf(ctx context.Context) (err error) {
 	b.Files, err = protobuf.Builder{
 		Builder: builder.Builder{
+			Context:    ctx,
+			PWD:        b.Pwd,
+			Path:       b.Path,
+			Package:    b.Package,
+			APIEnabled: b.APIEnabled,
+		},
+		Output: b.Output,
+	}.Build()
+	return err
+}
+
+func (b *Builder) buildThrift(ctx context.Context) error {
+	_, err := thrift.Builder{
+		Builder: builder.Builder{
+			Context:    ctx,
 			PWD:        b.Pwd,
 			Path:       b.Path,
 			Package:    b.Package,
@@ -152,9 +271,38 @@ func (b *Builder) buildProtobuf() (err error) {
 	return err
 }
 
-func (b *Builder) buildGo() error {
+func (b *Builder) buildAvro(ctx context.Context) error {
+	return avro.Builder{
+		Builder: builder.Builder{
+			Context:    ctx,
+			PWD:        b.Pwd,
+			Path:       b.Path,
+			Package:    b.Package,
+			APIEnabled: b.APIEnabled,
+		},
+		Output:        b.Output,
+		Compatibility: b.AvroCompatibility,
+	}.Build()
+}
+
+func (b *Builder) buildDescriptor(ctx context.Context) error {
+	return descriptorset.Builder{
+		Builder: builder.Builder{
+			Context: ctx,
+			PWD:     b.Pwd,
+			Path:    b.Path,
+			Package: b.Package,
+		},
+		Output:     b.Output,
+		Files:      b.Files,
+		SourceInfo: b.SourceInfo,
+	}.Build()
+}
+
+func (b *Builder) buildGo(ctx context.Context) error {
 	return _go.Builder{
 		Builder: builder.Builder{
+			Context:    ctx,
 			PWD:        b.Pwd,
 			Path:       b.Path,
 			Package:    b.Package,
@@ -165,9 +313,10 @@ func (b *Builder) buildGo() error {
 	}.Build()
 }
 
-func (b *Builder) buildJava() error {
+func (b *Builder) buildJava(ctx context.Context) error {
 	return java.Builder{
 		Builder: builder.Builder{
+			Context:    ctx,
 			PWD:        b.Pwd,
 			Path:       b.Path,
 			Package:    b.Package,
@@ -178,9 +327,10 @@ func (b *Builder) buildJava() error {
 	}.Build()
 }
 
-func (b *Builder) buildOpenapi() (err error) {
+func (b *Builder) buildOpenapi(ctx context.Context) (err error) {
 	b.OpenAPIs, err = openapi.Builder{
 		Builder: builder.Builder{
+			Context:    ctx,
 			PWD:        b.Pwd,
 			Path:       b.Path,
 			Package:    b.Package,
@@ -191,9 +341,10 @@ func (b *Builder) buildOpenapi() (err error) {
 	return err
 }
 
-func (b *Builder) buildDocument() error {
+func (b *Builder) buildDocument(ctx context.Context) error {
 	return document.Builder{
 		Builder: builder.Builder{
+			Context:    ctx,
 			PWD:        b.Pwd,
 			Path:       b.Path,
 			Package:    b.Package,
@@ -204,9 +355,10 @@ func (b *Builder) buildDocument() error {
 	}.Build()
 }
 
-func (b *Builder) buildGokit(ncraftType string) error {
+func (b *Builder) buildGokit(ctx context.Context, ncraftType string) error {
 	return gokit.Builder{
 		Builder: builder.Builder{
+			Context:    ctx,
 			PWD:        b.Pwd,
 			Path:       b.Path,
 			Package:    b.Package,
@@ -218,9 +370,10 @@ func (b *Builder) buildGokit(ncraftType string) error {
 	}.Build()
 }
 
-func (b *Builder) buildBoot(ncraftType string) error {
+func (b *Builder) buildBoot(ctx context.Context, ncraftType string) error {
 	return boot.Builder{
 		Builder: builder.Builder{
+			Context:    ctx,
 			PWD:        b.Pwd,
 			Path:       b.Path,
 			Package:    b.Package,
@@ -231,3 +384,27 @@ func (b *Builder) buildBoot(ncraftType string) error {
 		Repository: b.Repository,
 	}.Build()
 }
+
+func (b *Builder) buildExternal(ctx context.Context, target string) error {
+	return external.Builder{
+		Builder: builder.Builder{
+			Context:    ctx,
+			PWD:        b.Pwd,
+			Path:       b.Path,
+			Package:    b.Package,
+			APIEnabled: b.APIEnabled,
+		},
+		Target:  target,
+		Output:  b.Output,
+		Options: externalOptions(target),
+	}.Build()
+}
+
+// externalOptions the options of the external target, which are all passed as strings
+func externalOptions(target string) map[string]string {
+	options := make(map[string]string)
+	for k, v := range config.Get().Plugins[target] {
+		options[k] = fmt.Sprint(v)
+	}
+	return options
+}
@@ -0,0 +1,97 @@
+package commander
+
+import (
+	"errors"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+func TestBuilder_LoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	content := `packages:
+  mojo.db: v0.1.0
+  mojo.geom: v0.2.0
+plugins:
+  compiler.pagination:
+    page_size_max: 500
+`
+	assert.NoError(t, os.WriteFile(path.Join(dir, config.FileName), []byte(content), 0o644))
+	t.Setenv(config.MojoPackagesEnv, "mojo.db=v0.3.0")
+	t.Cleanup(func() {
+		delete(config.Get().Packages, "mojo.db")
+		delete(config.Get().Packages, "mojo.geom")
+		delete(config.Get().Plugins, "compiler.pagination")
+	})
+
+	b := &Builder{Path: dir}
+	assert.NoError(t, b.loadConfig())
+
+	// the environment overrides the mojo.yaml of the package
+	assert.Equal(t, "v0.3.0", config.Get().Packages["mojo.db"])
+	assert.Equal(t, "v0.2.0", config.Get().Packages["mojo.geom"])
+
+	b.PluginOptions = *cli.NewStringSlice("compiler.pagination.page_size=100")
+	assert.EqualError(t, b.loadConfig(), "unknown option compiler.pagination.page_size, the plugin compiler.pagination does not support it")
+}
+
+func TestBuilder_ExecuteConcurrentTargets(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{"test.concurrent.a", "test.concurrent.b"}
+
+	// the targets wait for each other, so they are only built when running concurrently
+	started := sync.WaitGroup{}
+	started.Add(len(names))
+	for _, name := range names {
+		name := name
+		RegisterTarget(&BasicTarget{
+			Name: name,
+			Builder: func(b *Builder, ctx context.Context) error {
+				started.Done()
+				done := make(chan struct{})
+				go func() {
+					started.Wait()
+					close(done)
+				}()
+				select {
+				case <-done:
+				case <-time.After(10 * time.Second):
+					return errors.New("the targets in the same stage are not built concurrently")
+				}
+
+				guard := &util.PathGuard{Target: name}
+				if err := guard.Record(dir); err != nil {
+					return err
+				}
+				file := &util.GeneratedFile{Name: name + ".txt", Content: name}
+				return file.WriteTo(dir, guard)
+			},
+		})
+	}
+	t.Cleanup(func() {
+		for _, name := range names {
+			delete(targets, name)
+		}
+	})
+
+	stages, err := ResolveTargets(names...)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{names}, targetNames(stages))
+
+	b := &Builder{Path: dir, Targets: names[0] + "," + names[1], Jobs: 2}
+	assert.NoError(t, b.execute())
+	for _, name := range names {
+		content, err := os.ReadFile(path.Join(dir, name+".txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, name, string(content))
+	}
+}
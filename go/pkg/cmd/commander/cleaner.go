@@ -0,0 +1,94 @@
+package commander
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+// Cleaner removes the generated files recorded in the manifests under the paths
+type Cleaner struct {
+	Pwd   string
+	Paths []string
+
+	// the targets (or the target aliases) to clean, separated by comma, all the targets if empty
+	Targets string
+
+	// print the files would be deleted instead of deleting them
+	DryRun bool
+}
+
+func (c *Cleaner) Execute() (err error) {
+	if c.DryRun {
+		util.StartDryRun()
+		defer func() {
+			dryRun := util.StopDryRun()
+			if err == nil {
+				err = util.PrintChanges(os.Stdout, util.GetAbsolutePath(c.Pwd, ""), dryRun.Changes(), false)
+			}
+		}()
+	}
+
+	paths := c.Paths
+	if len(paths) == 0 {
+		paths = []string{"./"}
+	}
+
+	var targets []string
+	if len(c.Targets) > 0 {
+		targets = ExpandTargets(strings.Split(c.Targets, ",")...)
+	}
+
+	for _, p := range paths {
+		dirs, err := findManifestDirs(util.GetAbsolutePath(c.Pwd, p))
+		if err != nil {
+			return err
+		}
+		for _, dir := range dirs {
+			if err = cleanManifestDir(dir, targets...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func cleanManifestDir(dir string, targets ...string) error {
+	manifest, err := util.LoadManifest(dir)
+	if err != nil || manifest == nil {
+		return err
+	}
+
+	logs.Infow("begin to clean the generated files", "dir", dir, "targets", targets)
+	if err = manifest.Clean(dir, targets...); err != nil {
+		return fmt.Errorf("failed to clean the generated files in %s: %w", dir, err)
+	}
+	return manifest.Save(dir)
+}
+
+// findManifestDirs find the output directories which have the manifest under the path
+func findManifestDirs(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && (d.Name() == ".git" || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == util.ManifestFileName {
+			dirs = append(dirs, filepath.Dir(p))
+		}
+		return nil
+	})
+	return dirs, err
+}
@@ -1,9 +1,12 @@
 package commander
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/mojo-lang/mojo/go/pkg/cmd/format"
+	"github.com/mojo-lang/mojo/go/pkg/cmd/format/protobuf"
 )
 
 type Formatter struct {
@@ -11,12 +14,28 @@ type Formatter struct {
 	Path       string
 	Output     string
 
+	// the language of the source files, mojo if empty
+	Lang string
+
 	BackupSource  bool
 	TargetFiles   cli.StringSlice
 	TargetPackage string
 }
 
 func (f *Formatter) Execute() error {
+	switch f.Lang {
+	case "", "mojo":
+	case "proto", "protobuf":
+		formatter := &protobuf.Formatter{
+			WorkingDir:   f.WorkingDir,
+			Path:         f.Path,
+			BackupSource: f.BackupSource,
+		}
+		return formatter.Format()
+	default:
+		return fmt.Errorf("unsupported language %s to format", f.Lang)
+	}
+
 	formatter := &format.Formatter{
 		WorkingDir: f.WorkingDir,
 		Path:       f.Path,
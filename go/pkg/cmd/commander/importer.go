@@ -0,0 +1,85 @@
+package commander
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	protobuf "github.com/mojo-lang/mojo/go/pkg/protobuf/importer"
+	thrift "github.com/mojo-lang/mojo/go/pkg/thrift/importer"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+// Importer imports the tree of the proto or thrift files into a mojo package
+type Importer struct {
+	Pwd  string
+	Path string
+
+	// the language of the files to import, proto or thrift
+	Lang string
+
+	// the mojo package name, the common prefix of the proto packages or the thrift namespaces if empty
+	PackageName string
+	Version     string
+	Output      string
+
+	// overwrite the existing mojo files
+	Force bool
+
+	// print the files would be written instead of writing them
+	DryRun bool
+}
+
+func (i *Importer) Execute() (err error) {
+	output := util.GetAbsolutePath(i.Pwd, i.Output)
+	if i.DryRun {
+		util.StartDryRun()
+		defer func() {
+			dryRun := util.StopDryRun()
+			if err == nil {
+				err = util.PrintChanges(os.Stdout, output, dryRun.Changes(), false)
+			}
+		}()
+	}
+
+	// the type mappings in the mojo.yaml of the proto tree
+	path := util.GetAbsolutePath(i.Pwd, i.Path)
+	c, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	var files util.GeneratedFiles
+	switch i.Lang {
+	case "proto", "protobuf":
+		imp := protobuf.New(path)
+		imp.PackageName = i.PackageName
+		imp.Version = i.Version
+		imp.TypeMappings = c.GetTypeMappings(config.ProtobufTypeMappingTarget)
+		files, err = imp.Generate(context.Empty())
+	case "thrift":
+		imp := thrift.New(path)
+		imp.PackageName = i.PackageName
+		imp.Version = i.Version
+		imp.TypeMappings = c.GetTypeMappings(config.ThriftTypeMappingTarget)
+		files, err = imp.Generate(context.Empty())
+	default:
+		return fmt.Errorf("unsupported language %s to import", i.Lang)
+	}
+	if err != nil {
+		return err
+	}
+
+	logs.Infow("begin to write the imported mojo files", "output", output, "files", len(files))
+	guard := &util.PathGuard{DisableClear: true}
+	for _, file := range files {
+		file.SkipIfExist = !i.Force
+		if err = file.WriteTo(output, guard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package commander
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+)
+
+// DefaultPlugins the plugins pipeline to build the mojo package
+const DefaultPlugins = "mpm,syntax,semantic,compiler"
+
+// PluginLister print the plugins pipeline in the order of execution, with the artifacts they require and provide
+type PluginLister struct {
+	Plugins string
+
+	Output io.Writer
+}
+
+func (l *PluginLister) Execute() error {
+	if len(l.Plugins) == 0 {
+		l.Plugins = DefaultPlugins
+	}
+	if l.Output == nil {
+		l.Output = os.Stdout
+	}
+
+	plugins, err := plugin.ResolvePlugins(strings.Split(l.Plugins, ",")...)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(l.Output, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tNAME\tGROUP\tREQUIRES\tPROVIDES")
+	for i, p := range plugins.Plugins() {
+		requires := append([]string{}, plugin.Requires(p)...)
+		for _, optional := range plugin.OptionalRequires(p) {
+			requires = append(requires, optional+"?")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, p.GetName(), p.GetGroup(),
+			strings.Join(requires, ","), strings.Join(plugin.Provides(p), ","))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,238 @@
+package commander
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+// the artifacts passed between the build targets
+const (
+	PackageArtifact     = "package"     // the compiled lang.Package, Builder.Package
+	DescriptorsArtifact = "descriptors" // the protobuf file descriptors, Builder.Files
+	OpenAPIsArtifact    = "openapis"    // the OpenAPIs, Builder.OpenAPIs
+	GoAPIArtifact       = "go-api"      // the golang api files in the go directory, which the ncraft go services depend on
+)
+
+// Target the target of `mojo build -t`, which builds with the artifacts provided by the other targets
+type Target interface {
+	GetName() string
+	GetUsage() string
+
+	// GetRequires the artifacts must be built before the target
+	GetRequires() []string
+	// GetOptionalRequires the artifacts must be built before the target only if their providers are in the build
+	GetOptionalRequires() []string
+	// GetProvides the artifacts set to the Builder by the target
+	GetProvides() []string
+
+	// Build the target in the ctx, which carries the trace span of the target
+	Build(ctx context.Context, b *Builder) error
+}
+
+type BasicTarget struct {
+	Name  string
+	Usage string
+
+	Requires         []string
+	OptionalRequires []string
+	Provides         []string
+
+	Builder func(b *Builder, ctx context.Context) error
+}
+
+func (t *BasicTarget) GetName() string {
+	return t.Name
+}
+
+func (t *BasicTarget) GetUsage() string {
+	return t.Usage
+}
+
+func (t *BasicTarget) GetRequires() []string {
+	return t.Requires
+}
+
+func (t *BasicTarget) GetOptionalRequires() []string {
+	return t.OptionalRequires
+}
+
+func (t *BasicTarget) GetProvides() []string {
+	return t.Provides
+}
+
+func (t *BasicTarget) Build(ctx context.Context, b *Builder) error {
+	if t.Builder == nil {
+		return nil
+	}
+	return t.Builder(b, ctx)
+}
+
+var targets = make(map[string]Target)
+var targetAliases = make(map[string][]string)
+
+// RegisterTarget register the target, the one with the same name will be replaced
+func RegisterTarget(target Target) {
+	targets[target.GetName()] = target
+}
+
+// RegisterTargetAlias register the name for a set of the targets, like `api` for all the api targets
+func RegisterTargetAlias(alias string, names ...string) {
+	targetAliases[alias] = names
+}
+
+func GetTarget(name string) Target {
+	return targets[name]
+}
+
+// GetTargets return all the registered targets sorted by the name
+func GetTargets() []Target {
+	var ts []Target
+	for _, t := range targets {
+		ts = append(ts, t)
+	}
+	sort.Slice(ts, func(i, j int) bool {
+		return ts[i].GetName() < ts[j].GetName()
+	})
+	return ts
+}
+
+// GetTargetAliases return all the registered aliases and the targets they stand for
+func GetTargetAliases() map[string][]string {
+	return targetAliases
+}
+
+// ExpandTargets replace the aliases by the targets, and remove the duplicated ones
+func ExpandTargets(names ...string) []string {
+	var expanded []string
+	visited := make(map[string]bool)
+
+	var expand func(name string)
+	expand = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+
+		if alias, ok := targetAliases[name]; ok {
+			for _, n := range alias {
+				expand(n)
+			}
+			return
+		}
+		expanded = append(expanded, name)
+	}
+	for _, name := range names {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			expand(name)
+		}
+	}
+	return expanded
+}
+
+// ResolveTargets find the targets by the names (or aliases) with all the targets providing their required artifacts,
+// the unregistered names are built by the external generator plugins.
+//
+// the targets are grouped into the stages, the targets in the same stage are independent of each other,
+// and only require the artifacts provided by the previous stages. the optional required artifacts are
+// only waited for when their providers are in the build, but never add the providers.
+func ResolveTargets(names ...string) ([][]Target, error) {
+	providers := make(map[string]Target)
+	for _, t := range GetTargets() {
+		for _, artifact := range t.GetProvides() {
+			providers[artifact] = t
+		}
+	}
+
+	var resolved []Target
+	added := make(map[string]bool)
+	var add func(t Target) error
+	add = func(t Target) error {
+		if added[t.GetName()] {
+			return nil
+		}
+		added[t.GetName()] = true
+
+		for _, artifact := range t.GetRequires() {
+			provider := providers[artifact]
+			if provider == nil {
+				return fmt.Errorf("the artifact %s required by the target %s is not provided by any target", artifact, t.GetName())
+			}
+			if err := add(provider); err != nil {
+				return err
+			}
+		}
+		resolved = append(resolved, t)
+		return nil
+	}
+
+	for _, name := range ExpandTargets(names...) {
+		t := GetTarget(name)
+		if t == nil {
+			t = NewExternalTarget(name)
+		}
+		if err := add(t); err != nil {
+			return nil, err
+		}
+	}
+
+	// order the targets after the providers of their optional requirements in the build
+	var ordered []Target
+	visited := make(map[string]bool)
+	var visit func(t Target)
+	visit = func(t Target) {
+		if visited[t.GetName()] {
+			return
+		}
+		visited[t.GetName()] = true
+
+		for _, artifact := range requiredArtifacts(t) {
+			if provider := providers[artifact]; provider != nil && added[provider.GetName()] {
+				visit(provider)
+			}
+		}
+		ordered = append(ordered, t)
+	}
+	for _, t := range resolved {
+		visit(t)
+	}
+
+	// the requirements are ordered before the target, so the stage of each target could be decided in one pass
+	var stages [][]Target
+	stageOfArtifacts := make(map[string]int)
+	for _, t := range ordered {
+		stage := 0
+		for _, artifact := range requiredArtifacts(t) {
+			if s, ok := stageOfArtifacts[artifact]; ok && s+1 > stage {
+				stage = s + 1
+			}
+		}
+		for _, artifact := range t.GetProvides() {
+			stageOfArtifacts[artifact] = stage
+		}
+
+		for len(stages) <= stage {
+			stages = append(stages, nil)
+		}
+		stages[stage] = append(stages[stage], t)
+	}
+	return stages, nil
+}
+
+func requiredArtifacts(t Target) []string {
+	return append(append([]string{}, t.GetRequires()...), t.GetOptionalRequires()...)
+}
+
+// NewExternalTarget the target generated by the generator plugin `mojo-gen-<name>` in the PATH
+func NewExternalTarget(name string) Target {
+	return &BasicTarget{
+		Name:     name,
+		Usage:    "generated by the generator plugin mojo-gen-" + name,
+		Requires: []string{PackageArtifact},
+		Builder: func(b *Builder, ctx context.Context) error {
+			return b.buildExternal(ctx, name)
+		},
+	}
+}
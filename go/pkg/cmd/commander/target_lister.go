@@ -0,0 +1,49 @@
+package commander
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// TargetLister print the registered build targets with the artifacts they require and provide, and the target aliases
+type TargetLister struct {
+	Output io.Writer
+}
+
+func (l *TargetLister) Execute() error {
+	if l.Output == nil {
+		l.Output = os.Stdout
+	}
+
+	w := tabwriter.NewWriter(l.Output, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TARGET\tREQUIRES\tPROVIDES\tUSAGE")
+	for _, t := range GetTargets() {
+		requires := append([]string{}, t.GetRequires()...)
+		for _, optional := range t.GetOptionalRequires() {
+			requires = append(requires, optional+"?")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.GetName(),
+			strings.Join(requires, ","), strings.Join(t.GetProvides(), ","), t.GetUsage())
+	}
+
+	aliases := GetTargetAliases()
+	var names []string
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ALIAS\tTARGETS")
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%s\n", name, strings.Join(aliases[name], ","))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "the other targets are generated by the generator plugins `mojo-gen-<target>` in the PATH")
+	return w.Flush()
+}
@@ -0,0 +1,80 @@
+package commander
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func targetNames(stages [][]Target) [][]string {
+	var names [][]string
+	for _, stage := range stages {
+		var ns []string
+		for _, t := range stage {
+			ns = append(ns, t.GetName())
+		}
+		names = append(names, ns)
+	}
+	return names
+}
+
+func TestResolveTargets(t *testing.T) {
+	stages, err := ResolveTargets("api")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{MojoTarget},
+		{OpenAPITarget, ProtobufTarget},
+		{DocumentTarget, GoTarget, JavaTarget},
+	}, targetNames(stages))
+}
+
+func TestResolveTargets_Dependencies(t *testing.T) {
+	stages, err := ResolveTargets("go", "service", "go")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{MojoTarget},
+		{ProtobufTarget},
+		{GoTarget},
+		{NcraftServiceTarget},
+	}, targetNames(stages))
+}
+
+func TestResolveTargets_ServiceAlias(t *testing.T) {
+	// the service alias generates the ncraft service only, the api targets are only built with the api alias
+	stages, err := ResolveTargets("service")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{MojoTarget}, {NcraftServiceTarget}}, targetNames(stages))
+
+	stages, err = ResolveTargets("api", "service")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{MojoTarget},
+		{OpenAPITarget, ProtobufTarget},
+		{DocumentTarget, GoTarget, JavaTarget},
+		{NcraftServiceTarget},
+	}, targetNames(stages))
+}
+
+func TestResolveTargets_OptionalRequires(t *testing.T) {
+	// the go api is not in the build, so the service does not wait for it
+	stages, err := ResolveTargets("ncraft")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{MojoTarget},
+		{NcraftServiceTarget, NcraftClientTarget, NcraftSidecarTarget},
+	}, targetNames(stages))
+}
+
+func TestResolveTargets_External(t *testing.T) {
+	stages, err := ResolveTargets("typescript")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{MojoTarget}, {"typescript"}}, targetNames(stages))
+}
+
+func TestResolveTargets_MissingArtifact(t *testing.T) {
+	RegisterTarget(&BasicTarget{Name: "test.missing", Requires: []string{"test.artifact"}})
+	defer delete(targets, "test.missing")
+
+	_, err := ResolveTargets("test.missing")
+	assert.Error(t, err)
+}
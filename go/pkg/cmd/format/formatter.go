@@ -21,7 +21,10 @@ type Formatter struct {
 func (f *Formatter) Format() error {
 	logs.Infow("begin to parse mojo package.", "pwd", f.WorkingDir, "path", f.Path)
 
-	plugins := plugin.NewPlugins("mpm", "syntax", "semantic")
+	plugins, err := plugin.ResolvePlugins("mpm", "syntax", "semantic")
+	if err != nil {
+		return err
+	}
 	if strings.HasPrefix(f.Path, f.WorkingDir) {
 		f.Path = strings.TrimPrefix(f.Path, f.WorkingDir)
 	}
@@ -1 +1,29 @@
 package protobuf
+
+import (
+	"path"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/formatter"
+)
+
+// Formatter formats the proto files under the path in place
+type Formatter struct {
+	WorkingDir   string
+	Path         string
+	BackupSource bool
+}
+
+func (f *Formatter) Format() error {
+	logs.Infow("begin to format the proto files.", "pwd", f.WorkingDir, "path", f.Path)
+
+	if strings.HasPrefix(f.Path, f.WorkingDir) {
+		f.Path = strings.TrimPrefix(f.Path, f.WorkingDir)
+	}
+	protoFormatter := formatter.New()
+	protoFormatter.Backup = f.BackupSource
+	return protoFormatter.FormatPath(context.Empty(), path.Join(f.WorkingDir, f.Path))
+}
@@ -0,0 +1,262 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/mojo-lang/yaml/go/pkg/mojo/yaml"
+)
+
+// the targets of the type mappings in the mojo.yaml, like the `types.protobuf`
+const (
+	ProtobufTypeMappingTarget = "protobuf"
+	ThriftTypeMappingTarget   = "thrift"
+	AvroTypeMappingTarget     = "avro"
+)
+
+const (
+	FileName = "mojo.yaml"
+
+	MojoHomeEnv     = "MOJO_HOME"
+	MojoPackagesEnv = "MOJO_PACKAGES"
+)
+
+// Config the configuration of the mojo tool, loaded from the `mojo.yaml` in the MOJO_HOME
+// and the working directory, the later one has the higher priority.
+type Config struct {
+	// Packages replaces the embedded mojo standard packages (mojo.core, mojo.db ...),
+	// the value is a local source checkout of the package, or a git revision (tag, branch or commit)
+	// of the package's upstream repository.
+	//
+	// the environment `MOJO_PACKAGES=mojo.core=../core,mojo.db=v0.2.0` has the highest priority.
+	Packages map[string]string `json:"packages,omitempty"`
+
+	// Plugins the options of the plugins, keyed by the plugin name (compiler.pagination) or the group name (compiler),
+	//
+	//	plugins:
+	//	  compiler.pagination:
+	//	    auto: false
+	Plugins map[string]core.Options `json:"plugins,omitempty"`
+
+	// Types the mappings of the mojo types to the types of the target languages, keyed by the target (protobuf),
+	// and then the full name of the mojo type, the mapping could be written as the type name only
+	//
+	//	types:
+	//	  protobuf:
+	//	    mojo.core.Timestamp: int64
+	//	    mojo.core.Decimal: string
+	//	    acme.Money:
+	//	      type: acme.common.v1.Money
+	//	      import: acme/common/v1/money.proto
+	Types map[string]map[string]*TypeMapping `json:"types,omitempty"`
+}
+
+// TypeMapping the type of the target language which the mojo type mapped to
+type TypeMapping struct {
+	Type string `json:"type"`
+
+	// the file declaring the type, could be omitted for the builtin and the well-known types
+	Import string `json:"import,omitempty"`
+}
+
+func (m *TypeMapping) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &m.Type)
+	}
+
+	type mapping TypeMapping
+	return json.Unmarshal(data, (*mapping)(m))
+}
+
+var config *Config
+var configOnce sync.Once
+
+// Get returns the configuration for the current working directory
+func Get() *Config {
+	configOnce.Do(func() {
+		config = &Config{}
+		for _, dir := range []string{MojoHome(), workingDir()} {
+			if c, err := Load(dir); err != nil {
+				logs.Warnw("failed to load the mojo config file, ignore it", "dir", dir, "error", err)
+			} else {
+				config.Merge(c)
+			}
+		}
+		config.Merge(FromEnv())
+	})
+	return config
+}
+
+// Load the `mojo.yaml` in the dir, relative package paths will be resolved against the dir.
+func Load(dir string) (*Config, error) {
+	fileName := path.Join(dir, FileName)
+	if !core.IsExist(fileName) {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Config{}
+	if err = yaml.Unmarshal(content, c); err != nil {
+		return nil, err
+	}
+
+	for name, pkg := range c.Packages {
+		c.Packages[name] = resolvePackagePath(dir, pkg)
+	}
+	return c, nil
+}
+
+func FromEnv() *Config {
+	c := &Config{}
+	if packages := os.Getenv(MojoPackagesEnv); len(packages) > 0 {
+		c.Packages = make(map[string]string)
+		for _, segment := range strings.Split(packages, ",") {
+			kv := strings.SplitN(strings.TrimSpace(segment), "=", 2)
+			if len(kv) != 2 || len(kv[0]) == 0 || len(kv[1]) == 0 {
+				logs.Warnw("invalid mojo package override in the environment, ignore it", "env", MojoPackagesEnv, "value", segment)
+				continue
+			}
+			c.Packages[kv[0]] = kv[1]
+		}
+	}
+	return c
+}
+
+func (c *Config) Merge(other *Config) *Config {
+	if c != nil && other != nil {
+		if len(other.Packages) > 0 && c.Packages == nil {
+			c.Packages = make(map[string]string)
+		}
+		for k, v := range other.Packages {
+			c.Packages[k] = v
+		}
+		for target, mappings := range other.Types {
+			for k, v := range mappings {
+				c.SetTypeMapping(target, k, v)
+			}
+		}
+		for scope, options := range other.Plugins {
+			for k, v := range options {
+				c.SetPluginOption(scope, k, v)
+			}
+		}
+	}
+	return c
+}
+
+// SetPluginOption set the option of the plugin or the plugin group
+func (c *Config) SetPluginOption(scope string, key string, value interface{}) *Config {
+	if c != nil {
+		if c.Plugins == nil {
+			c.Plugins = make(map[string]core.Options)
+		}
+		if c.Plugins[scope] == nil {
+			c.Plugins[scope] = make(core.Options)
+		}
+		c.Plugins[scope][key] = value
+	}
+	return c
+}
+
+// SetTypeMapping set the mapping of the mojo type for the target language
+func (c *Config) SetTypeMapping(target string, mojoType string, mapping *TypeMapping) *Config {
+	if c != nil && mapping != nil {
+		if c.Types == nil {
+			c.Types = make(map[string]map[string]*TypeMapping)
+		}
+		if c.Types[target] == nil {
+			c.Types[target] = make(map[string]*TypeMapping)
+		}
+		c.Types[target][mojoType] = mapping
+	}
+	return c
+}
+
+// GetTypeMappings the mappings of the mojo types for the target language, keyed by the full name of the mojo type
+func (c *Config) GetTypeMappings(target string) map[string]*TypeMapping {
+	if c != nil {
+		return c.Types[target]
+	}
+	return nil
+}
+
+// GetTypeMapping the mapping of the mojo type for the target language, nil if it is not mapped to any type
+func (c *Config) GetTypeMapping(target string, mojoType string) *TypeMapping {
+	if mapping := c.GetTypeMappings(target)[mojoType]; mapping != nil && len(mapping.Type) > 0 {
+		return mapping
+	}
+	return nil
+}
+
+// ReverseTypeMappings the types of the target language to the full names of the mojo types mapped to them,
+// the mappings to the builtin types are ignored for the builtin types are shared by the mojo types
+func ReverseTypeMappings(mappings map[string]*TypeMapping, builtin func(typ string) bool) map[string]string {
+	types := make(map[string]string)
+	for mojoType, mapping := range mappings {
+		name := strings.TrimPrefix(mapping.Type, ".")
+		if len(name) == 0 || builtin(name) {
+			continue
+		}
+		// choose the first mojo type in order to be stable if more than one mapped to the same type
+		if exist, ok := types[name]; !ok || mojoType < exist {
+			types[name] = mojoType
+		}
+	}
+	return types
+}
+
+// MojoType the mojo type of the full name, qualified with the package name if not in the package
+func MojoType(pkg string, fullName string) *lang.NominalType {
+	typ := &lang.NominalType{Name: fullName}
+	if i := strings.LastIndex(fullName, "."); i > 0 {
+		typ.PackageName, typ.Name = fullName[:i], fullName[i+1:]
+	}
+	if typ.PackageName == pkg {
+		typ.PackageName = ""
+	}
+	return typ
+}
+
+// MojoHome returns the `$MOJO_HOME`, default is `~/mojo`
+func MojoHome() string {
+	home := os.Getenv(MojoHomeEnv)
+	if len(home) == 0 {
+		userHome, _ := os.UserHomeDir()
+		home = path.Join(userHome, "mojo")
+	}
+	return home
+}
+
+func workingDir() string {
+	dir, _ := os.Getwd()
+	return dir
+}
+
+// resolvePackagePath resolves the relative package path against the dir, the `~/` is expanded to the user home,
+// and the value not existing as a path, like `v0.2.0`, is kept as the git revision
+func resolvePackagePath(dir string, p string) string {
+	if strings.HasPrefix(p, "~/") {
+		home, _ := os.UserHomeDir()
+		p = filepath.Join(home, p[2:])
+	}
+	if filepath.IsAbs(p) {
+		return p
+	}
+
+	resolved := filepath.Join(dir, p)
+	if strings.HasPrefix(p, "./") || strings.HasPrefix(p, "../") || core.IsExist(resolved) {
+		return resolved
+	}
+	return p
+}
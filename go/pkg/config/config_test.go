@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	content := `packages:
+  mojo.core: ../core
+  mojo.db: v0.2.0
+`
+	assert.NoError(t, os.WriteFile(path.Join(dir, FileName), []byte(content), 0o644))
+
+	c, err := Load(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, path.Join(path.Dir(dir), "core"), c.Packages["mojo.core"])
+	assert.Equal(t, "v0.2.0", c.Packages["mojo.db"])
+}
+
+func TestLoad_BareRelativePath(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(path.Join(dir, "mojo-core"), 0o755))
+	assert.NoError(t, os.MkdirAll(path.Join(dir, "libs", "db"), 0o755))
+	content := `packages:
+  mojo.core: mojo-core
+  mojo.db: libs/db
+  mojo.geom: main
+`
+	assert.NoError(t, os.WriteFile(path.Join(dir, FileName), []byte(content), 0o644))
+
+	c, err := Load(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, path.Join(dir, "mojo-core"), c.Packages["mojo.core"])
+	assert.Equal(t, path.Join(dir, "libs", "db"), c.Packages["mojo.db"])
+	assert.Equal(t, "main", c.Packages["mojo.geom"])
+}
+
+func TestLoad_NotExist(t *testing.T) {
+	c, err := Load(t.TempDir())
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv(MojoPackagesEnv, "mojo.core=/src/core, mojo.db=v0.2.0,invalid")
+
+	c := FromEnv()
+	assert.Equal(t, 2, len(c.Packages))
+	assert.Equal(t, "/src/core", c.Packages["mojo.core"])
+	assert.Equal(t, "v0.2.0", c.Packages["mojo.db"])
+}
+
+func TestConfig_Merge(t *testing.T) {
+	c := &Config{}
+	c.Merge(&Config{Packages: map[string]string{"mojo.core": "a", "mojo.db": "b"}})
+	c.Merge(&Config{Packages: map[string]string{"mojo.core": "c"}})
+	c.Merge(nil)
+
+	assert.Equal(t, "c", c.Packages["mojo.core"])
+	assert.Equal(t, "b", c.Packages["mojo.db"])
+}
+
+func TestLoad_Plugins(t *testing.T) {
+	dir := t.TempDir()
+	content := `plugins:
+  compiler.pagination:
+    auto: false
+    page_size_max: 500
+`
+	assert.NoError(t, os.WriteFile(path.Join(dir, FileName), []byte(content), 0o644))
+
+	c, err := Load(dir)
+	assert.NoError(t, err)
+
+	merged := (&Config{}).SetPluginOption("compiler.pagination", "auto", true).Merge(c)
+	assert.Equal(t, false, merged.Plugins["compiler.pagination"]["auto"])
+	assert.NotNil(t, merged.Plugins["compiler.pagination"]["page_size_max"])
+}
+
+func TestLoad_Types(t *testing.T) {
+	dir := t.TempDir()
+	content := `types:
+  protobuf:
+    mojo.core.Timestamp: int64
+    acme.Money:
+      type: acme.common.v1.Money
+      import: acme/common/v1/money.proto
+`
+	assert.NoError(t, os.WriteFile(path.Join(dir, FileName), []byte(content), 0o644))
+
+	c, err := Load(dir)
+	assert.NoError(t, err)
+
+	merged := (&Config{}).SetTypeMapping("protobuf", "mojo.core.Timestamp", &TypeMapping{Type: "google.protobuf.Timestamp"}).Merge(c)
+	mappings := merged.GetTypeMappings("protobuf")
+	assert.Equal(t, &TypeMapping{Type: "int64"}, mappings["mojo.core.Timestamp"])
+	assert.Equal(t, &TypeMapping{Type: "acme.common.v1.Money", Import: "acme/common/v1/money.proto"}, mappings["acme.Money"])
+	assert.Nil(t, merged.GetTypeMappings("thrift"))
+}
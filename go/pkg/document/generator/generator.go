@@ -47,6 +47,10 @@ func (g *Generator) generateMarkdown() error {
 func (g *Generator) writeFiles(dir string) error {
 	guard := &util2.PathGuard{
 		Suffixes: []string{".md"},
+		Target:   "document",
+	}
+	if err := guard.Record(dir); err != nil {
+		return err
 	}
 
 	for _, file := range g.Files {
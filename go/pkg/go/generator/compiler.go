@@ -27,10 +27,10 @@ func NewCompiler(path string, files []*descriptor.File) *Compiler {
 	}
 }
 
-func (c *Compiler) CompilePackage(pkg *lang.Package) (util.GeneratedFiles, error) {
+func (c *Compiler) CompilePackage(ctx context.Context, pkg *lang.Package) (util.GeneratedFiles, error) {
 	var files util.GeneratedFiles
 
-	fs, err := generator.ProtocGenGo(c.Path, pkg, c.Files)
+	fs, err := generator.ProtocGenGo(ctx, pkg, c.Files)
 	if err != nil {
 		return nil, err
 	}
@@ -47,15 +47,15 @@ func (c *Compiler) CompilePackage(pkg *lang.Package) (util.GeneratedFiles, error
 		return nil, err
 	}
 
-	ctx := context.WithType(context.Empty(), pkg)
+	pkgCtx := context.WithType(context.Empty(), pkg)
 	for _, p := range pkg.Children {
-		err = c.compilePackage(ctx, p)
+		err = c.compilePackage(pkgCtx, p)
 		if err != nil {
 			return nil, err
 		}
 
 		for _, cp := range p.Children {
-			err = c.compilePackage(context.WithType(ctx, p), cp)
+			err = c.compilePackage(context.WithType(pkgCtx, p), cp)
 			if err != nil {
 				return nil, err
 			}
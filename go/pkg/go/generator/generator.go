@@ -29,6 +29,10 @@ func (g *Generator) Generate(output string) error {
 	guard := &util2.PathGuard{
 		OnlyClearGenerated: true,
 		Suffixes:           []string{".go"},
+		Target:             "go",
+	}
+	if err = guard.Record(output); err != nil {
+		return err
 	}
 
 	for _, f := range g.Files {
@@ -1,12 +1,10 @@
 package generator
 
 import (
-	"bytes"
-	"fmt"
+	"errors"
 	"go/ast"
 	"io/fs"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"strconv"
@@ -19,10 +17,16 @@ import (
 	"github.com/mojo-lang/db/go/pkg/mojo/db"
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
+	// internal_gengo has no compatibility guarantee, google.golang.org/protobuf is pinned to gengoVersion in go.mod,
+	// and checked by TestGengoVersion, review the generated files when bumping it
+	gengo "google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
 	"github.com/mojo-lang/mojo/go/pkg/go/generator/injection"
 	"github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/compiler"
 	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
@@ -51,126 +55,78 @@ func GenerateMojoPackageProtobuf(pkg *lang.Package) (string, error) {
 	return dir, nil
 }
 
-func isGoCommandExist(command string) bool {
-	cmd := exec.Command("which", command)
-	if out, err := cmd.Output(); err != nil {
-		if goPath := os.Getenv("GOPATH"); len(goPath) > 0 {
-			return core.IsExist(filepath.Join(goPath, "bin", command))
-		} else {
-			return false
-		}
-	} else {
-		return len(out) > 0 && !strings.Contains(string(out), "not found")
-	}
-}
-
-func prepareEnv() error {
-	if !isGoCommandExist("protoc-gen-go") {
-		err := exec.Command("go", "install", "google.golang.org/protobuf/cmd/protoc-gen-go@latest").Run()
-		if err != nil {
-			return err
+// ProtocGenGo generates the go messages and the grpc stubs of the files in-process, like the
+// protoc-gen-go and protoc-gen-go-grpc plugins, so neither the protoc nor the plugins is required.
+func ProtocGenGo(ctx context.Context, pkg *lang.Package, files []*descriptor.File) (util.GeneratedFiles, error) {
+	var names []string
+	for _, file := range files {
+		if !file.IsEmpty() {
+			names = append(names, file.GetName())
 		}
 	}
-	if !isGoCommandExist("protoc-gen-go-grpc") {
-		err := exec.Command("go", "install", "google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest").Run()
-		if err != nil {
-			return err
-		}
+	if len(names) == 0 {
+		return nil, nil
 	}
-	return nil
-}
 
-func ProtocGenGo(dir string, pkg *lang.Package, files []*descriptor.File) (util.GeneratedFiles, error) {
-	if err := prepareEnv(); err != nil {
+	resolver := compiler.NewResolver(files, pkg.ResolvedDependencies)
+	protoFiles, err := resolver.Resolve(names...)
+	if err != nil {
+		logs.Errorw("failed to resolve the imported proto files", "pkg", pkg.FullName, "error", err.Error())
 		return nil, err
 	}
 
-	var tempPbDirs []string
-	cmd := exec.Command("protoc", "-I.")
-	for _, dep := range pkg.ResolvedDependencies {
-		wd := dep.GetExtraString("workingDir")
-		p := dep.GetExtraString("path")
-		if len(wd) == 0 && len(p) == 0 {
-			pbDir, err := GenerateMojoPackageProtobuf(dep)
-			if err != nil {
-				logs.Errorw("failed to generate mojo package's protobuf files", "package", dep.FullName, "error", err)
-				return nil, err
-			}
-			if len(pbDir) > 0 {
-				tempPbDirs = append(tempPbDirs, pbDir)
-				cmd.Args = append(cmd.Args, "--proto_path="+pbDir)
-			}
-		} else {
-			cmd.Args = append(cmd.Args, "--proto_path="+path.Join(wd, p, "protobuf"))
-		}
+	parameter := "paths=source_relative"
+	request := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: names,
+		Parameter:      &parameter,
+		ProtoFile:      protoFiles,
 	}
-	defer func() {
-		for _, d := range tempPbDirs {
-			if err := os.RemoveAll(d); err != nil {
-				logs.Warnw("failed to remove all the files", "dir", d, "error", err)
-			}
-		}
-	}()
-
-	cmd.Dir = path.Join(dir, "protobuf")
 
-	outDir := filepath.Join(dir, "go.out")
-	if core.IsExist(outDir) {
-		if err := os.RemoveAll(outDir); err != nil {
-			return nil, err
-		}
-	}
-	if err := core.CreateDir(outDir); err != nil {
+	span, _ := util.BeginSpan(ctx, util.ProcessSpan, "protogen", "lang", "go", "pkg", pkg.FullName)
+	response, err := protogenGo(request)
+	span.End()
+	if err != nil {
+		logs.Errorw("failed to generate the go files from the protobuf", "pkg", pkg.FullName, "error", err.Error())
 		return nil, err
 	}
-	defer func() {
-		if err := os.RemoveAll(outDir); err != nil {
-			logs.Warnw("failed to remove all the files in the dir", "dir", outDir, "error", err)
-		}
-	}()
-
-	cmd.Args = append(cmd.Args, fmt.Sprintf("--go_out=%s", outDir))
-	cmd.Args = append(cmd.Args, "--go_opt=paths=source_relative")
-	cmd.Args = append(cmd.Args, fmt.Sprintf("--go-grpc_out=%s", outDir))
-	cmd.Args = append(cmd.Args, "--go-grpc_opt=paths=source_relative")
 
-	for _, file := range files {
-		if !file.IsEmpty() {
-			cmd.Args = append(cmd.Args, file.GetName())
-		}
+	var genFiles util.GeneratedFiles
+	for _, file := range response.File {
+		genFiles = append(genFiles, &util.GeneratedFile{
+			Name:    filepath.Join("pkg", file.GetName()),
+			Content: file.GetContent(),
+		})
 	}
 
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	_, err := cmd.Output()
+	injectGoTags(pkg, genFiles)
+	return genFiles, nil
+}
+
+// gengoVersion the version of google.golang.org/protobuf which the internal_gengo is from
+const gengoVersion = "v1.33.0"
+
+func protogenGo(request *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+	gen, err := protogen.Options{}.New(request)
 	if err != nil {
-		logs.Errorw(fmt.Sprintf("failed to run protoc cmd %s", stderr.String()), "cmd", cmd.String())
 		return nil, err
 	}
-	logs.Debugw("finish to run protoc cmd", "warning", stderr.String(), "cmd", cmd.String())
 
-	var genFiles util.GeneratedFiles
-	err = filepath.WalkDir(outDir, func(path string, d fs.DirEntry, err error) error {
-		if !d.IsDir() {
-			if rel, err := filepath.Rel(outDir, path); err != nil {
-				return err
-			} else {
-				if content, err := os.ReadFile(path); err != nil {
-					return err
-				} else {
-					genFiles = append(genFiles, &util.GeneratedFile{
-						Name:    filepath.Join("pkg", rel),
-						Content: string(content),
-					})
-				}
-			}
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
 		}
-		return nil
-	})
-	if err != nil {
-		return nil, err
+		gengo.GenerateFile(gen, f)
+		generateGrpcFile(gen, f)
 	}
 
+	response := gen.Response()
+	if response.Error != nil {
+		return nil, errors.New(response.GetError())
+	}
+	return response, nil
+}
+
+func injectGoTags(pkg *lang.Package, genFiles util.GeneratedFiles) {
 	for _, f := range genFiles {
 		xxxSkip := []string{"gorm", "xml", "bson"}
 		injector := injection.NewTagInjector(xxxSkip, nil)
@@ -265,8 +221,6 @@ func ProtocGenGo(dir string, pkg *lang.Package, files []*descriptor.File) (util.
 		// 	f.Content = string(bs)
 		// }
 	}
-
-	return genFiles, nil
 }
 
 func AddTagsOptions(tags *structtag.Tags, key string, name string, options ...string) error {
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/converter"
+)
+
+func TestProtocGenGo(t *testing.T) {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "../../../ncraft/testdata/mojo-ncraft")
+	if !assert.NoError(t, err) || pkg == nil {
+		t.FailNow()
+	}
+
+	c := converter.New()
+	assert.NoError(t, c.CompilePackage(context.Empty(), pkg))
+
+	files, err := ProtocGenGo(context.Empty(), pkg, c.Descriptors.Filter(pkg.FullName, false))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	contents := make(map[string]string)
+	for _, file := range files {
+		contents[file.Name] = file.Content
+		_, err = parser.ParseFile(token.NewFileSet(), file.Name, file.Content, parser.AllErrors)
+		assert.NoError(t, err, file.Name)
+	}
+
+	assert.Contains(t, contents["pkg/ncraft/address.pb.go"], "type Address struct")
+	assert.Contains(t, contents["pkg/ncraft/v1/geocoding.pb.go"], "type GeocodeRequest struct")
+
+	grpc := contents["pkg/ncraft/v1/geocoding_grpc.pb.go"]
+	assert.Contains(t, grpc, "type GeocodingClient interface")
+	assert.Contains(t, grpc, "func RegisterGeocodingServer(s grpc.ServiceRegistrar, srv GeocodingServer)")
+	assert.Contains(t, grpc, "(*ncraft.Address, error)")
+}
+
+func TestGengoVersion(t *testing.T) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		t.Skip("no build info")
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == "google.golang.org/protobuf" {
+			assert.Equal(t, gengoVersion, dep.Version, "the internal_gengo is changed, review the generated go files")
+			return
+		}
+	}
+	t.Fatal("google.golang.org/protobuf is not found in the build info")
+}
@@ -54,6 +54,10 @@ func generate(d *data.Data, output string) error {
 	guard := &util.PathGuard{
 		DisableClear: true,
 		Suffixes:     []string{".java"},
+		Target:       "java",
+	}
+	if err = guard.Record(output); err != nil {
+		return err
 	}
 
 	for _, f := range files {
@@ -27,10 +27,13 @@ func NewCircleCompiler(options core.Options) *CircleCompiler {
 	_ = options
 	return &CircleCompiler{
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          circleName,
-			Group:         "compiler",
-			GroupPriority: 10,
-			Priority:      9,
+			Name:             circleName,
+			Group:            "compiler",
+			Provides:         []string{plugin.CircleSourceFiles},
+			Requires:         []string{plugin.SemanticTree},
+			OptionalRequires: []string{plugin.ResolvedGenericAliases},
+			GroupPriority:    10,
+			Priority:         9,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewCircleCompiler(options)
 			},
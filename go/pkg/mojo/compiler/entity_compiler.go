@@ -37,10 +37,13 @@ type EntityCompiler struct {
 func NewEntityCompiler(options core.Options) *EntityCompiler {
 	return &EntityCompiler{
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          entityName,
-			Group:         "compiler",
-			GroupPriority: 10,
-			Priority:      20,
+			Name:             entityName,
+			Group:            "compiler",
+			Provides:         []string{plugin.EntityGraph},
+			Requires:         []string{plugin.SemanticTree},
+			OptionalRequires: []string{plugin.ResolvedGenericAliases, plugin.CircleSourceFiles},
+			GroupPriority:    10,
+			Priority:         20,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewEntityCompiler(options)
 			},
@@ -35,10 +35,13 @@ func NewGenericCompiler(options core.Options) *GenericCompiler {
 	compiler := &GenericCompiler{
 		Options: options,
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          genericName,
-			Group:         "compiler",
-			GroupPriority: 10,
-			Priority:      3,
+			Name:             genericName,
+			Group:            "compiler",
+			Provides:         []string{plugin.InstantiatedGenerics},
+			Requires:         []string{plugin.ResolvedTypeAliases},
+			OptionalRequires: []string{plugin.IrregularCaseRules},
+			GroupPriority:    10,
+			Priority:         3,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewGenericCompiler(options)
 			},
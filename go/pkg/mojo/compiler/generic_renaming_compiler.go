@@ -49,6 +49,8 @@ func NewGenericRenamingCompiler(options core.Options) *GenericRenamingCompiler {
 		BasicPlugin: plugin.BasicPlugin{
 			Name:          genericRenamingName,
 			Group:         "compiler",
+			Provides:      []string{plugin.RenamedGenerics},
+			Requires:      []string{plugin.InstantiatedGenerics},
 			GroupPriority: 10,
 			Priority:      5,
 			Creator: func(options core.Options) plugin.Plugin {
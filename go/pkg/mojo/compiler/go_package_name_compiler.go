@@ -24,10 +24,14 @@ type GoPackageNameCompiler struct {
 func NewGoPackageNameCompiler(options core.Options) *GoPackageNameCompiler {
 	return &GoPackageNameCompiler{
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          goPackageNameName,
-			Group:         "compiler",
-			GroupPriority: 10,
-			Priority:      11,
+			Name:             goPackageNameName,
+			Group:            "compiler",
+			Provides:         []string{plugin.GoPackageNames},
+			Requires:         []string{plugin.SemanticTree},
+			OptionalRequires: []string{plugin.CircleSourceFiles},
+			GroupPriority:    10,
+			Priority:         11,
+			Concurrent:       true,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewGoPackageNameCompiler(options)
 			},
@@ -41,8 +45,7 @@ func (c *GoPackageNameCompiler) CompileStruct(ctx context.Context, decl *lang.St
 	pkg := context.Package(ctx)
 	pkgFullName := pkg.GetFullName()
 
-	if !c.MarkedPackages[pkgFullName] {
-		c.MarkedPackages[pkgFullName] = true
+	if c.MarkPackage(pkgFullName) {
 		logs.Infow("enter the plugin", "plugin", c.Name, "method", "CompilePackage", "pkg", pkg.FullName)
 	}
 
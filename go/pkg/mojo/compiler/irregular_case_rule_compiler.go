@@ -26,6 +26,8 @@ func NewIrregularCaseRuleCompiler(options core.Options) *IrregularCaseRuleCompil
 		BasicPlugin: plugin.BasicPlugin{
 			Name:          irregularCaseRuleName,
 			Group:         "compiler",
+			Provides:      []string{plugin.IrregularCaseRules},
+			Requires:      []string{plugin.SemanticTree},
 			GroupPriority: 10,
 			Priority:      2,
 			Creator: func(options core.Options) plugin.Plugin {
@@ -41,8 +43,7 @@ func (c *IrregularCaseRuleCompiler) CompileSourceFile(ctx context.Context, sourc
 	pkg := context.Package(ctx)
 	pkgFullName := pkg.GetFullName()
 
-	if !c.MarkedPackages[pkgFullName] {
-		c.MarkedPackages[pkgFullName] = true
+	if c.MarkPackage(pkgFullName) {
 		logs.Infow("enter the plugin", "plugin", c.Name, "method", "CompilePackage", "pkg", pkgFullName)
 	}
 
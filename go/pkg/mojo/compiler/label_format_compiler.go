@@ -25,10 +25,14 @@ type LabelFormatCompiler struct {
 func NewLabelFormatCompiler(options core.Options) *LabelFormatCompiler {
 	return &LabelFormatCompiler{
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          labelFormatName,
-			Group:         "compiler",
-			GroupPriority: 10,
-			Priority:      13,
+			Name:             labelFormatName,
+			Group:            "compiler",
+			Provides:         []string{plugin.LabelFormats},
+			Requires:         []string{plugin.SemanticTree},
+			OptionalRequires: []string{plugin.ResolvedGenericAliases},
+			GroupPriority:    10,
+			Priority:         13,
+			Concurrent:       true,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewLabelFormatCompiler(options)
 			},
@@ -42,8 +46,7 @@ func (c *LabelFormatCompiler) CompileTypeAlias(ctx context.Context, decl *lang.T
 	pkg := context.Package(ctx)
 	pkgFullName := pkg.GetFullName()
 
-	if !c.MarkedPackages[pkgFullName] {
-		c.MarkedPackages[pkgFullName] = true
+	if c.MarkPackage(pkgFullName) {
 		logs.Infow("enter the plugin", "plugin", c.Name, "method", "CompileTypeAlias", "pkg", pkg.FullName, "decl", decl.Name)
 	}
 
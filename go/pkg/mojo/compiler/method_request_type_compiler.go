@@ -26,10 +26,14 @@ type MethodRequestTypeCompiler struct {
 func NewMethodRequestTypeCompiler(options core.Options) *MethodRequestTypeCompiler {
 	return &MethodRequestTypeCompiler{
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          methodRequestTypeName,
-			Group:         "compiler",
-			GroupPriority: 10,
-			Priority:      55,
+			Name:             methodRequestTypeName,
+			Group:            "compiler",
+			Provides:         []string{plugin.MethodRequestTypes},
+			Requires:         []string{plugin.SemanticTree},
+			OptionalRequires: []string{plugin.PaginationMethods, plugin.QueryMethods, plugin.EntityGraph},
+			GroupPriority:    10,
+			Priority:         55,
+			Concurrent:       true,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewMethodRequestTypeCompiler(options)
 			},
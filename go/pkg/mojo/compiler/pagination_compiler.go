@@ -1,11 +1,13 @@
 package compiler
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/mojo-lang/core/go/pkg/logs"
 	"github.com/mojo-lang/core/go/pkg/mojo/core"
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
 	"github.com/mojo-lang/mojo/go/pkg/plugin"
@@ -17,6 +19,15 @@ func init() {
 
 const paginationName = "compiler.pagination"
 
+// AutoOption the option of the pagination and query compilers, set false to not add the attribute to the list methods automatically
+const AutoOption = "auto"
+
+// PageSizeMaxOption the option of the pagination compiler, the maximum page size of the pagination requests,
+// which is set to the pagination methods as the `@page_size_max` attribute, and then to the `page_size` as `@maximum`
+const PageSizeMaxOption = "page_size_max"
+
+const PageSizeMaxAttributeName = "page_size_max"
+
 type PaginationCompiler struct {
 	plugin.BasicPlugin
 
@@ -26,10 +37,15 @@ type PaginationCompiler struct {
 func NewPaginationCompiler(options core.Options) *PaginationCompiler {
 	return &PaginationCompiler{
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          paginationName,
-			Group:         "compiler",
-			GroupPriority: 10,
-			Priority:      15,
+			Name:             paginationName,
+			Group:            "compiler",
+			Provides:         []string{plugin.PaginationMethods},
+			Requires:         []string{plugin.SemanticTree},
+			OptionalRequires: []string{plugin.ResolvedGenericAliases},
+			GroupPriority:    10,
+			Priority:         15,
+			SupportedOptions: []string{AutoOption, PageSizeMaxOption},
+			Concurrent:       true,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewPaginationCompiler(options)
 			},
@@ -42,8 +58,13 @@ func (c *PaginationCompiler) CompileInterface(ctx context.Context, decl *lang.In
 	pkg := context.Package(ctx)
 	logs.Infow("enter the plugin", "plugin", c.Name, "method", "CompilePackage", "pkg", pkg.FullName, "interface", decl.Name)
 
+	pageSizeMax, err := c.pageSizeMax()
+	if err != nil {
+		return err
+	}
+
 	for _, method := range decl.GetType().GetMethods() {
-		if !method.HasAttribute(core.PaginationAttributeName) {
+		if c.isAuto() && !method.HasAttribute(core.PaginationAttributeName) {
 			name := method.Name
 			resultTypeName := method.GetSignature().GetResultType().GetFullName()
 			if resultTypeName == core.ArrayTypeFullName &&
@@ -51,6 +72,11 @@ func (c *PaginationCompiler) CompileInterface(ctx context.Context, decl *lang.In
 				method.SetBoolAttribute(core.PaginationAttributeName, true)
 			}
 		}
+
+		if pagination, _ := lang.GetBoolAttribute(method.Attributes, core.PaginationAttributeName); pagination && pageSizeMax > 0 &&
+			!method.HasAttribute(PageSizeMaxAttributeName) {
+			method.SetIntegerAttribute(PageSizeMaxAttributeName, pageSizeMax)
+		}
 	}
 	return nil
 }
@@ -196,6 +222,34 @@ func PaginationRequestFields() []*lang.ValueDecl {
 	return []*lang.ValueDecl{pageSize, pageToken, skip}
 }
 
+// PaginationRequestFieldsOf the pagination request fields of the method, the `page_size` is limited by the `@page_size_max`
+func PaginationRequestFieldsOf(method *lang.FunctionDecl) []*lang.ValueDecl {
+	pageSizeMax, err := lang.GetIntegerAttribute(method.GetAttributes(), PageSizeMaxAttributeName)
+	if err != nil || pageSizeMax <= 0 {
+		return PaginationRequestFields()
+	}
+
+	limited := proto.Clone(pageSize).(*lang.ValueDecl)
+	limited.Type.Attributes = lang.SetIntegerAttribute(limited.Type.Attributes, core.MaximumLengthAttributeName, pageSizeMax)
+	return []*lang.ValueDecl{limited, pageToken, skip}
+}
+
 func PaginationResponseFields() []*lang.ValueDecl {
 	return []*lang.ValueDecl{totalCount, nextPageToken}
 }
+
+// isAuto whether to add the `@pagination` to the list methods automatically, default is true
+func (c *PaginationCompiler) isAuto() bool {
+	return !c.Options.HasValue(AutoOption) || c.Options.GetBool(AutoOption)
+}
+
+// pageSizeMax the maximum page size, 0 means unlimited
+func (c *PaginationCompiler) pageSizeMax() (int64, error) {
+	if !c.Options.HasValue(PageSizeMaxOption) {
+		return 0, nil
+	}
+	if value, ok := c.Options[PageSizeMaxOption].(int); ok && value > 0 {
+		return int64(value), nil
+	}
+	return 0, fmt.Errorf("invalid option %s.%s %v, should be a positive integer", paginationName, PageSizeMaxOption, c.Options[PageSizeMaxOption])
+}
@@ -0,0 +1,50 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+func newListInterface() *lang.InterfaceDecl {
+	return &lang.InterfaceDecl{
+		Name: "Library",
+		Type: &lang.InterfaceType{
+			Methods: []*lang.FunctionDecl{{
+				Name: "list_books",
+				Signature: &lang.FunctionSignature{
+					Result: &lang.FunctionSignature_Result{Type: &lang.NominalType{PackageName: "mojo.core", Name: "Array"}},
+				},
+			}},
+		},
+	}
+}
+
+func TestPaginationCompiler_PageSizeMax(t *testing.T) {
+	ctx := context.WithType(context.Empty(), &lang.Package{FullName: "test"})
+
+	decl := newListInterface()
+	assert.NoError(t, NewPaginationCompiler(core.Options{PageSizeMaxOption: 500}).CompileInterface(ctx, decl))
+
+	method := decl.Type.Methods[0]
+	pagination, _ := lang.GetBoolAttribute(method.Attributes, core.PaginationAttributeName)
+	assert.True(t, pagination)
+
+	fields := PaginationRequestFieldsOf(method)
+	maximum, err := lang.GetIntegerAttribute(fields[0].Type.Attributes, core.MaximumLengthAttributeName)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), maximum)
+
+	// the shared page size field is not changed
+	assert.False(t, lang.HasAttribute(PaginationRequestFields()[0].Type.Attributes, core.MaximumLengthAttributeName))
+}
+
+func TestPaginationCompiler_PageSizeMaxInvalid(t *testing.T) {
+	ctx := context.WithType(context.Empty(), &lang.Package{FullName: "test"})
+	assert.Error(t, NewPaginationCompiler(core.Options{PageSizeMaxOption: "many"}).CompileInterface(ctx, newListInterface()))
+	assert.Error(t, NewPaginationCompiler(core.Options{PageSizeMaxOption: 0}).CompileInterface(ctx, newListInterface()))
+}
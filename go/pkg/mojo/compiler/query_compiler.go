@@ -26,10 +26,15 @@ type QueryCompiler struct {
 func NewQueryCompiler(options core.Options) *QueryCompiler {
 	return &QueryCompiler{
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          queryName,
-			Group:         "compiler",
-			GroupPriority: 10,
-			Priority:      16,
+			Name:             queryName,
+			Group:            "compiler",
+			Provides:         []string{plugin.QueryMethods},
+			Requires:         []string{plugin.SemanticTree},
+			OptionalRequires: []string{plugin.PaginationMethods},
+			GroupPriority:    10,
+			Priority:         16,
+			SupportedOptions: []string{AutoOption},
+			Concurrent:       true,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewQueryCompiler(options)
 			},
@@ -42,6 +47,10 @@ func (c *QueryCompiler) CompileInterface(ctx context.Context, decl *lang.Interfa
 	pkg := context.Package(ctx)
 	logs.Infow("enter the plugin", "plugin", c.Name, "method", "CompilePackage", "pkg", pkg.FullName, "interface", decl.Name)
 
+	if !c.isAuto() {
+		return nil
+	}
+
 	for _, method := range decl.GetType().GetMethods() {
 		if !method.HasAttribute(core.QueryAttributeName) {
 			name := method.Name
@@ -168,3 +177,8 @@ var (
 func QueryRequestFields() []*lang.ValueDecl {
 	return []*lang.ValueDecl{filter, order, fieldMask, unique}
 }
+
+// isAuto whether to add the `@query` to the list methods automatically, default is true
+func (c *QueryCompiler) isAuto() bool {
+	return !c.Options.HasValue(AutoOption) || c.Options.GetBool(AutoOption)
+}
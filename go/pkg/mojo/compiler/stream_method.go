@@ -0,0 +1,34 @@
+package compiler
+
+import (
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+)
+
+// StreamAttributeName marks the parameter type or the result type of the method as a stream of the messages.
+//
+//	upload(chunk: Chunk @1 @stream) -> Summary         // client streaming
+//	watch(id: String @1) -> Event @stream               // server streaming
+//	chat(message: Message @1 @stream) -> Message @stream // bidirectional streaming
+//
+// the whole request message of the method is streamed if any parameter is marked
+const StreamAttributeName = "stream"
+
+// IsClientStreamingMethod the method receives a stream of the request messages
+func IsClientStreamingMethod(method *lang.FunctionDecl) bool {
+	for _, param := range method.GetSignature().GetParameters() {
+		if lang.HasAttribute(param.GetType().GetAttributes(), StreamAttributeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsServerStreamingMethod the method replies a stream of the response messages
+func IsServerStreamingMethod(method *lang.FunctionDecl) bool {
+	return lang.HasAttribute(method.GetSignature().GetResultType().GetAttributes(), StreamAttributeName)
+}
+
+// IsStreamingMethod the method is client, server or bidirectional streaming
+func IsStreamingMethod(method *lang.FunctionDecl) bool {
+	return IsClientStreamingMethod(method) || IsServerStreamingMethod(method)
+}
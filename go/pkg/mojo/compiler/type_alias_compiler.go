@@ -24,10 +24,18 @@ type TypeAliasCompiler struct {
 
 func NewTypeAliasCompiler(options core.Options, name string, priority int) *TypeAliasCompiler {
 	_ = options
+	requires, provides := plugin.SemanticTree, plugin.ResolvedTypeAliases
+	if name == typeAliasName2 {
+		// the second pass resolves the aliases of the instantiated generic types
+		requires, provides = plugin.RenamedGenerics, plugin.ResolvedGenericAliases
+	}
+
 	return &TypeAliasCompiler{
 		BasicPlugin: plugin.BasicPlugin{
 			Name:          name,
 			Group:         "compiler",
+			Provides:      []string{provides},
+			Requires:      []string{requires},
 			GroupPriority: 10,
 			Priority:      priority,
 			Creator: func(options core.Options) plugin.Plugin {
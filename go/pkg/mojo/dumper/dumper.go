@@ -0,0 +1,167 @@
+package dumper
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/mojo-lang/yaml/go/pkg/mojo/yaml"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/printer"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+const (
+	MojoFormat = "mojo"
+	JsonFormat = "json"
+	YamlFormat = "yaml"
+	BothFormat = "both"
+)
+
+// Dumper the PluginHook to write the package before or after the plugins, for debugging the plugins.
+//
+// the plugin could be the plugin name or the group name, the package will be dumped before the first plugin
+// or after the last plugin of the group.
+type Dumper struct {
+	Before []string
+	After  []string
+
+	// mojo, json or both, default is mojo
+	Format string
+
+	// the directory to write, `<Output>/<after|before>-<plugin>/`
+	Output string
+}
+
+func (d *Dumper) BeforePlugin(ctx context.Context, p plugin.Plugin, pkg *lang.Package) {
+	for _, name := range d.Before {
+		if name == p.GetName() || (name == p.GetGroup() && !sameGroup(ctx, p, -1)) {
+			d.dump(ctx, "before-"+name, pkg)
+		}
+	}
+}
+
+func (d *Dumper) AfterPlugin(ctx context.Context, p plugin.Plugin, pkg *lang.Package) {
+	for _, name := range d.After {
+		if name == p.GetName() || (name == p.GetGroup() && !sameGroup(ctx, p, 1)) {
+			d.dump(ctx, "after-"+name, pkg)
+		}
+	}
+}
+
+// sameGroup check whether the plugin with the offset to p in the pipeline is in the same group
+func sameGroup(ctx context.Context, p plugin.Plugin, offset int) bool {
+	if plugins := plugin.ContextPlugins(ctx); plugins != nil {
+		ps := plugins.Plugins()
+		for i, plug := range ps {
+			if plug == p {
+				j := i + offset
+				return j >= 0 && j < len(ps) && ps[j].GetGroup() == p.GetGroup()
+			}
+		}
+	}
+	return false
+}
+
+func (d *Dumper) dump(ctx context.Context, stage string, pkg *lang.Package) {
+	// the embedded mojo packages are not interested
+	if len(pkg.GetExtraString("path")) == 0 && len(pkg.GetExtraString("workingDir")) == 0 {
+		return
+	}
+
+	output := path.Join(d.Output, stage)
+	logs.Infow("dump the package", "pkg", pkg.FullName, "stage", stage, "output", output)
+
+	var err error
+	if d.Format != JsonFormat {
+		err = d.dumpMojo(ctx, output, pkg)
+	}
+	if err == nil && (d.Format == JsonFormat || d.Format == BothFormat) {
+		err = d.dumpJson(output, pkg)
+	}
+	if err != nil {
+		logs.Warnw("failed to dump the package", "pkg", pkg.FullName, "stage", stage, "error", err)
+	}
+}
+
+func (d *Dumper) dumpMojo(ctx context.Context, output string, pkg *lang.Package) error {
+	for _, p := range pkg.GetAllPackageArray() {
+		for _, file := range p.SourceFiles {
+			name := file.FullName
+			if len(name) == 0 {
+				name = path.Join(strings.ReplaceAll(p.FullName, ".", "/"), file.Name)
+			}
+			if err := writeFile(path.Join(output, name), []byte(PrintSourceFile(ctx, file))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Dumper) dumpJson(output string, pkg *lang.Package) error {
+	content, err := MarshalPackage(pkg, JsonFormat)
+	if err != nil {
+		return err
+	}
+	return writeFile(path.Join(output, pkg.FullName+".json"), content)
+}
+
+// PrintSourceFile print the source file to the mojo source, the printer does not support all the declarations,
+// the unsupported file will be printed as a comment
+func PrintSourceFile(ctx context.Context, file *lang.SourceFile) (source string) {
+	defer func() {
+		if r := recover(); r != nil {
+			source = fmt.Sprintf("// failed to print the source file %s: %v\n", file.FullName, r)
+		}
+	}()
+	return printer.New(nil).PrintSourceFile(ctx, file).Buffer.String()
+}
+
+// MarshalPackage marshal the package without its dependencies to json or yaml
+func MarshalPackage(pkg *lang.Package, format string) ([]byte, error) {
+	all := pkg.GetAllPackageArray()
+	dependencies := make([]map[string]*lang.Package, len(all))
+	for i, p := range all {
+		dependencies[i] = p.ResolvedDependencies
+		p.ResolvedDependencies = nil
+	}
+	defer func() {
+		for i, p := range all {
+			p.ResolvedDependencies = dependencies[i]
+		}
+	}()
+
+	return Marshal(pkg, format)
+}
+
+// Marshal the lang node to json or yaml, with the source positions
+func Marshal(message proto.Message, format string) ([]byte, error) {
+	if util.HasCircularReference(message) {
+		return nil, fmt.Errorf("the %s has circular references", message.ProtoReflect().Descriptor().Name())
+	}
+
+	content, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	if format == YamlFormat {
+		return yaml.JSONToYAML(content)
+	}
+	return content, nil
+}
+
+func writeFile(name string, content []byte) error {
+	if err := core.CreateDir(path.Dir(name)); err != nil {
+		return err
+	}
+	return os.WriteFile(name, content, 0o644)
+}
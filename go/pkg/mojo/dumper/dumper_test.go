@@ -0,0 +1,56 @@
+package dumper
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+)
+
+func TestDumper(t *testing.T) {
+	d := &Dumper{
+		Before: []string{"compiler"},
+		After:  []string{"compiler.generic"},
+		Format: BothFormat,
+		Output: t.TempDir(),
+	}
+
+	ctx := plugin.WithPluginHook(mpm.WithCacheDisabled(context.Empty()), d)
+	_, err := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler").ParsePath(ctx, "../testdata/mojo-alias")
+	assert.NoError(t, err)
+
+	for _, stage := range []string{"before-compiler", "after-compiler.generic"} {
+		source, err := os.ReadFile(path.Join(d.Output, stage, "test/alias.mojo"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(source), "type A")
+
+		content, err := os.ReadFile(path.Join(d.Output, stage, "test.json"))
+		assert.NoError(t, err)
+		pkg := &lang.Package{}
+		assert.NoError(t, protojson.Unmarshal(content, pkg))
+		assert.Equal(t, "test", pkg.FullName)
+		assert.Empty(t, pkg.ResolvedDependencies)
+	}
+
+	_, err = os.Stat(path.Join(d.Output, "before-compiler", "mojo.core.json"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestMarshal(t *testing.T) {
+	file, err := plugin.NewPlugins("syntax").ParseString(context.Empty(), "type A {\n    a: Int @1\n}\n")
+	assert.NoError(t, err)
+
+	content, err := Marshal(file, YamlFormat)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "startPosition:")
+	assert.Contains(t, string(content), "name: A")
+}
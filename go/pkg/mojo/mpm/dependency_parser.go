@@ -26,6 +26,11 @@ type DependencyParser struct {
 	plugin.BasicPlugin
 
 	parsedPackages map[string]*lang.Package
+	mojoPackages   *MojoPackages
+
+	// the packages without importing any mojo package explicitly,
+	// the mojo packages referenced in the sources will be resolved after the syntax parsing
+	implicitPackages map[string]bool
 }
 
 func NewDependencyParser(options core.Options) *DependencyParser {
@@ -33,13 +38,16 @@ func NewDependencyParser(options core.Options) *DependencyParser {
 		BasicPlugin: plugin.BasicPlugin{
 			Name:          pluginName,
 			Group:         "mpm",
+			Provides:      []string{plugin.ResolvedDependencies},
 			GroupPriority: 0,
 			Priority:      1,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewDependencyParser(options)
 			},
 		},
-		parsedPackages: make(map[string]*lang.Package),
+		parsedPackages:   make(map[string]*lang.Package),
+		mojoPackages:     NewMojoPackages(),
+		implicitPackages: make(map[string]bool),
 	}
 }
 
@@ -56,12 +64,15 @@ func (p *DependencyParser) ParsePackage(ctx context.Context, pkg *lang.Package)
 	}
 	logs.Infow("enter the plugin", "plugin", p.Name, "method", "ParsePackage", "pkg", pkg.FullName)
 
+	if p.implicitPackages[pkg.FullName] {
+		if err := p.resolveImplicitMojoPackages(ctx, pkg); err != nil {
+			return err
+		}
+	}
+
 	if plugins := plugin.ContextPlugins(ctx); plugins != nil {
 		for _, dep := range pkg.ResolvedDependencies {
-			logs.Infow("begin to parse mojo dependency", "dependency", dep.FullName)
-
-			cloned := plugins.Copy()
-			if err := cloned.ParsePackage(plugin.WithPlugins(ctx, cloned), dep); err != nil && !core.IsSkipError(err) {
+			if err := p.parseDependency(ctx, plugins, dep); err != nil {
 				return err
 			}
 		}
@@ -73,6 +84,26 @@ func (p *DependencyParser) ParsePackage(ctx context.Context, pkg *lang.Package)
 	}
 
 	util.SetPackageProcessed(pkg, pluginName)
+
+	// the package has been compiled by all the plugins here
+	if key := pkg.GetExtraString(cacheKeyName); len(key) > 0 && !util.IsPackageCached(pkg) {
+		if err := NewPackageCache().Put(key, pkg); errors.Is(err, ErrCircularReference) {
+			logs.Infow("the package with the circular references is not cached, compile it in every build", "pkg", pkg.FullName)
+		} else if err != nil {
+			logs.Warnw("failed to save the package to the build cache", "pkg", pkg.FullName, "error", err)
+		}
+	}
+	return nil
+}
+
+// parseDependency parse the dependency by the rest plugins
+func (p *DependencyParser) parseDependency(ctx context.Context, plugins *plugin.Plugins, dep *lang.Package) error {
+	logs.Infow("begin to parse mojo dependency", "dependency", dep.FullName)
+
+	cloned := plugins.Copy()
+	if err := cloned.ParsePackage(plugin.WithPlugins(ctx, cloned), dep); err != nil && !core.IsSkipError(err) {
+		return err
+	}
 	return nil
 }
 
@@ -103,7 +134,7 @@ func (p *DependencyParser) ParsePath(ctx context.Context, pkgPath string) (*lang
 	includedMojoPkg := false
 	for name, d := range pkg.Dependencies {
 		if strings.HasPrefix(name, "mojo.") {
-			depPkg := GetMojoPackage(name)
+			depPkg := p.mojoPackages.Get(name)
 			if depPkg == nil {
 				return nil, fmt.Errorf("failed to found the required package %s", name)
 			}
@@ -135,16 +166,20 @@ func (p *DependencyParser) ParsePath(ctx context.Context, pkgPath string) (*lang
 			pkg.ResolvedDependencies = make(map[string]*lang.Package)
 		}
 
-		if includedMojoPkg {
-			if _, ok := pkg.ResolvedDependencies["mojo.core"]; !ok {
-				corePkg := GetMojoPackage("mojo.core")
-				pkg.ResolvedDependencies[corePkg.FullName] = corePkg
-			}
-		} else {
-			mojoPkgs := GetMojoPackages()
-			for _, mp := range mojoPkgs {
-				pkg.ResolvedDependencies[mp.FullName] = mp
-			}
+		if _, ok := pkg.ResolvedDependencies["mojo.core"]; !ok {
+			corePkg := p.mojoPackages.Get("mojo.core")
+			pkg.ResolvedDependencies[corePkg.FullName] = corePkg
+		}
+		if !includedMojoPkg {
+			p.implicitPackages[pkg.FullName] = true
+		}
+	}
+
+	if !isCacheDisabled(ctx) {
+		pkg = p.loadCachedPackage(ctx, fullPath, pkg)
+		if util.IsPackageCached(pkg) && p.implicitPackages[pkg.FullName] {
+			delete(p.implicitPackages, pkg.FullName)
+			p.attachMojoPackages(pkg, referencedMojoPackages(pkg))
 		}
 	}
 
@@ -152,8 +187,83 @@ func (p *DependencyParser) ParsePath(ctx context.Context, pkgPath string) (*lang
 	return pkg, nil
 }
 
+// loadCachedPackage returns the compiled package from the build cache if exists,
+// otherwise set the cache key to the package, which will be saved into the cache after compiled
+func (p *DependencyParser) loadCachedPackage(ctx context.Context, fullPath string, pkg *lang.Package) *lang.Package {
+	plugins := plugin.ContextPlugins(ctx)
+	if plugins == nil {
+		return pkg
+	}
+
+	names := append(plugins.Names(), plugins.Options()...)
+	if p.implicitPackages[pkg.FullName] {
+		// the implicit mojo packages are unknown before parsing, so depends on all the overridden ones
+		names = append(names, overriddenMojoPackageKeys()...)
+	}
+
+	key, err := PackageCacheKey(fullPath, pkg, names)
+	if err != nil {
+		logs.Warnw("failed to calculate the cache key of the package, disable the cache", "pkg", pkg.FullName, "error", err)
+		return pkg
+	}
+	pkg.SetExtraString(cacheKeyName, key)
+
+	cached := NewPackageCache().Get(key)
+	if cached == nil {
+		return pkg
+	}
+
+	logs.Infow("load the compiled package from the build cache", "pkg", pkg.FullName, "key", key)
+	for _, child := range cached.GetAllPackages() {
+		child.ResolvedDependencies = pkg.ResolvedDependencies
+	}
+	for _, name := range []string{"path", "workingDir", cacheKeyName} {
+		cached.SetExtraString(name, pkg.GetExtraString(name))
+	}
+	// the dependencies are not cached with the package, which should be parsed again by this plugin
+	cached.SetExtraBool(pluginName, false)
+	return util.SetPackageCached(cached)
+}
+
+// resolveImplicitMojoPackages parse the sources of the package in advance, then attach the mojo packages referenced
+func (p *DependencyParser) resolveImplicitMojoPackages(ctx context.Context, pkg *lang.Package) error {
+	delete(p.implicitPackages, pkg.FullName)
+
+	plugins, err := plugin.ResolvePlugins("syntax")
+	if err != nil {
+		return err
+	}
+	if err = plugins.ParsePackage(ctx, pkg); err != nil {
+		return err
+	}
+	p.attachMojoPackages(pkg, referencedMojoPackages(pkg))
+	return nil
+}
+
+// attachMojoPackages attach the mojo packages and all their dependencies to the package
+func (p *DependencyParser) attachMojoPackages(pkg *lang.Package, names []string) {
+	for _, name := range names {
+		if _, ok := pkg.ResolvedDependencies[name]; ok {
+			continue
+		}
+		if mojoPkg := p.mojoPackages.Get(name); mojoPkg != nil {
+			logs.Infow("attach the mojo package referenced in the package", "pkg", pkg.FullName, "dependency", name)
+			pkg.ResolvedDependencies[name] = mojoPkg
+
+			var dependencies []string
+			for dependency := range mojoPkg.ResolvedDependencies {
+				dependencies = append(dependencies, dependency)
+			}
+			p.attachMojoPackages(pkg, dependencies)
+		}
+	}
+}
+
 func (p *DependencyParser) parsePackageFile(ctx context.Context, pkgPath string) (*lang.Package, error) {
-	plugins := plugin.NewPlugins("syntax")
+	plugins, err := plugin.ResolvePlugins("syntax")
+	if err != nil {
+		return nil, err
+	}
 	packageFile := path.Join(pkgPath, "package.mojo")
 	file, err := plugins.ParseFile(ctx, packageFile)
 	if err != nil {
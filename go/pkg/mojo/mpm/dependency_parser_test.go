@@ -1,14 +1,20 @@
 package mpm
 
 import (
+	"os"
+	"os/exec"
+	"strings"
 	"testing"
 
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
 	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
 	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
 	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
 func TestDependencyParser_ParsePath(t *testing.T) {
@@ -17,3 +23,103 @@ func TestDependencyParser_ParsePath(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, pkg)
 }
+
+func TestDependencyParser_ParsePathCached(t *testing.T) {
+	t.Setenv("MOJO_HOME", t.TempDir())
+
+	parse := func(ctx context.Context) *lang.Package {
+		plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+		pkg, err := plugins.ParsePath(ctx, "../testdata/mojo-inherits")
+		assert.NoError(t, err)
+		assert.NotNil(t, pkg)
+		return pkg
+	}
+
+	compiled := parse(context.Empty())
+	assert.False(t, util.IsPackageCached(compiled))
+
+	cached := parse(context.Empty())
+	assert.True(t, util.IsPackageCached(cached))
+	assert.Equal(t, compiled.GetExtraString(cacheKeyName), cached.GetExtraString(cacheKeyName))
+	assert.Equal(t, len(compiled.ResolvedDependencies), len(cached.ResolvedDependencies))
+	for name, pkg := range compiled.GetAllPackages() {
+		assert.True(t, proto.Equal(&lang.Package{SourceFiles: pkg.SourceFiles}, &lang.Package{SourceFiles: cached.GetAllPackages()[name].SourceFiles}))
+	}
+
+	disabled := parse(WithCacheDisabled(context.Empty()))
+	assert.False(t, util.IsPackageCached(disabled))
+}
+
+// TestDependencyParser_ParsePathCachedProcesses builds the package in two processes sharing the build cache,
+// the mojo packages are decoded freshly in the second one, which should be compiled though the package is cached
+func TestDependencyParser_ParsePathCachedProcesses(t *testing.T) {
+	if os.Getenv("MOJO_TEST_CACHED_BUILD") == "1" {
+		plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+		pkg, err := plugins.ParsePath(context.Empty(), "../testdata/mojo-inherits")
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.True(t, util.IsPackageProcessed(pkg.ResolvedDependencies["mojo.core"], "semantic.identifier-resolver"))
+		t.Logf("cached=%t", util.IsPackageCached(pkg))
+		return
+	}
+
+	home := t.TempDir()
+	build := func() string {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestDependencyParser_ParsePathCachedProcesses$", "-test.v")
+		cmd.Env = append(os.Environ(), "MOJO_TEST_CACHED_BUILD=1", "MOJO_HOME="+home)
+		out, err := cmd.CombinedOutput()
+		if !assert.NoError(t, err, string(out)) {
+			t.FailNow()
+		}
+		return string(out)
+	}
+
+	assert.True(t, strings.Contains(build(), "cached=false"))
+	assert.True(t, strings.Contains(build(), "cached=true"))
+}
+
+func TestDependencyParser_ParsePathImplicitMojoPackages(t *testing.T) {
+	plugins := plugin.NewPlugins("mpm", "syntax")
+	pkg, err := plugins.ParsePath(context.Empty(), "../testdata/mojo-alias")
+	assert.NoError(t, err)
+
+	var names []string
+	for name := range pkg.ResolvedDependencies {
+		names = append(names, name)
+	}
+	assert.Contains(t, names, "mojo.core")
+	assert.Less(t, len(names), len(mojoPackageNames))
+}
+
+func TestDependencyParser_ParsePathTwice(t *testing.T) {
+	var cores []*lang.Package
+	for i := 0; i < 2; i++ {
+		plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+		pkg, err := plugins.ParsePath(WithCacheDisabled(context.Empty()), "../testdata/mojo-inherits")
+		assert.NoError(t, err)
+		assert.NotNil(t, pkg)
+
+		core := pkg.ResolvedDependencies["mojo.core"]
+		assert.True(t, util.IsPackageProcessed(core, "semantic.identifier-resolver"))
+		cores = append(cores, core)
+	}
+
+	// each build compiles its own copy of the mojo packages with its plugin options, the decoded one is untouched
+	assert.NotSame(t, cores[0], cores[1])
+	assert.False(t, util.IsPackageProcessed(getMojoPackageTemplate("mojo.core"), "semantic.identifier-resolver"))
+}
+
+func TestDependencyParser_ParsePathConcurrently(t *testing.T) {
+	errs := make(chan error, 2)
+	for _, path := range []string{"../testdata/mojo-inherits", "../testdata/mojo-alias"} {
+		go func(path string) {
+			plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+			_, err := plugins.ParsePath(WithCacheDisabled(context.Empty()), path)
+			errs <- err
+		}(path)
+	}
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, <-errs)
+	}
+}
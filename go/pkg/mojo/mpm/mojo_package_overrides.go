@@ -0,0 +1,154 @@
+package mpm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+var checkOverridesOnce sync.Once
+
+// getMojoPackageOverride the source of the mojo package configured in the `packages` of the mojo config
+func getMojoPackageOverride(name string) (string, bool) {
+	overrides := config.Get().Packages
+	checkOverridesOnce.Do(func() {
+		for n := range overrides {
+			if _, ok := mojoPackageTemplates[n]; !ok {
+				logs.Warnw("the overridden package is not a mojo standard package, ignore it", "package", n)
+			}
+		}
+	})
+
+	source, ok := overrides[name]
+	return source, ok
+}
+
+// overriddenMojoPackageKeys the cache keys of all the overridden mojo packages
+func overriddenMojoPackageKeys() []string {
+	var keys []string
+	for _, name := range mojoPackageNames {
+		fullName := "mojo." + name
+		if _, ok := getMojoPackageOverride(fullName); ok {
+			if pkg := getMojoPackageTemplate(fullName); pkg != nil {
+				keys = append(keys, fullName+"="+pkg.GetExtraString(cacheKeyName))
+			}
+		}
+	}
+	return keys
+}
+
+// loadOverriddenPackage compile the mojo package from the source, and cache it in the PackageCache
+func loadOverriddenPackage(name string, source string) (*lang.Package, error) {
+	dir, err := getOverriddenPackageDir(name, source)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := hashPackageSource(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// the mojo packages only compiled by the syntax parser, so the dependencies are irrelevant to the key
+	h := sha256.New()
+	h.Write([]byte(mojoVersion()))
+	h.Write([]byte(name))
+	h.Write([]byte(hash))
+	key := hex.EncodeToString(h.Sum(nil))
+
+	cache := NewPackageCache()
+	if pkg := cache.Get(key); pkg != nil {
+		return setOverriddenPackage(pkg, dir, key), nil
+	}
+
+	pkg, err := compileMojoPackageDir(context.Empty(), dir)
+	if err != nil {
+		return nil, err
+	}
+	shrinkPackage(pkg)
+
+	if err = cache.Put(key, pkg); err != nil {
+		logs.Warnw("failed to cache the compiled mojo package", "package", name, "error", err)
+	}
+	return setOverriddenPackage(pkg, dir, key), nil
+}
+
+// getOverriddenPackageDir the source is a local directory, or a git revision of the package repository
+func getOverriddenPackageDir(name string, source string) (string, error) {
+	if strings.HasPrefix(source, "~/") {
+		home, _ := os.UserHomeDir()
+		source = path.Join(home, source[2:])
+	}
+	if core.IsExist(source) {
+		return filepath.Abs(source)
+	}
+
+	url, err := core.NewUrl("github.com/mojo-lang/" + lang.GetPackageName(name))
+	if err != nil {
+		return "", err
+	}
+	return GetPackageCenter().Checkout(name, &lang.Package_Requirement{Repository: url}, source)
+}
+
+// setOverriddenPackage keep the source dir, so the generators could use the protobuf files in it,
+// and the cache key, so the packages depending on it will be recompiled when it changes
+func setOverriddenPackage(pkg *lang.Package, dir string, key string) *lang.Package {
+	pkg.SetExtraString("workingDir", dir)
+	pkg.SetExtraString(cacheKeyName, key)
+	return pkg
+}
+
+// hashPackageSource the hash of the `package.mojo` and all the mojo and proto source files in the `mojo` dir of the package
+func hashPackageSource(dir string) (string, error) {
+	h := sha256.New()
+	hashFile := func(p string) error {
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		h.Write([]byte(filepath.ToSlash(rel)))
+		_, err = io.Copy(h, f)
+		return err
+	}
+
+	if err := hashFile(path.Join(dir, "package.mojo")); err != nil {
+		return "", err
+	}
+
+	sourceDir := path.Join(dir, "mojo")
+	if !core.IsExist(sourceDir) {
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+	err := filepath.WalkDir(sourceDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".mojo") && !strings.HasSuffix(p, ".proto") {
+			return nil
+		}
+		return hashFile(p)
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,63 @@
+package mpm
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const fakeCorePackage = `package mojo.core {
+    version: '0.1.0'
+    repository: 'https://github.com/mojo-lang/core'
+}
+`
+
+const fakeCoreSource = `type Int32 {}
+type String {}
+`
+
+func TestLoadOverriddenPackage(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("MOJO_HOME", home)
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(path.Join(dir, "mojo", "core"), 0o755))
+	assert.NoError(t, os.WriteFile(path.Join(dir, "package.mojo"), []byte(fakeCorePackage), 0o644))
+	assert.NoError(t, os.WriteFile(path.Join(dir, "mojo", "core", "core.mojo"), []byte(fakeCoreSource), 0o644))
+
+	pkg, err := loadOverriddenPackage("mojo.core", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "mojo.core", pkg.FullName)
+	assert.Equal(t, dir, pkg.GetExtraString("workingDir"))
+	assert.Equal(t, 1, len(pkg.SourceFiles))
+
+	key := pkg.GetExtraString(cacheKeyName)
+	assert.NotEmpty(t, key)
+	assert.FileExists(t, path.Join(home, "cache", "packages", key[:2], key+".binary"))
+
+	cached, err := loadOverriddenPackage("mojo.core", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, len(pkg.SourceFiles), len(cached.SourceFiles))
+}
+
+func TestHashPackageSource(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(path.Join(dir, "package.mojo"), []byte(fakeCorePackage), 0o644))
+
+	h1, err := hashPackageSource(dir)
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.MkdirAll(path.Join(dir, "mojo", "core"), 0o755))
+	assert.NoError(t, os.WriteFile(path.Join(dir, "mojo", "core", "core.mojo"), []byte(fakeCoreSource), 0o644))
+	h2, err := hashPackageSource(dir)
+	assert.NoError(t, err)
+	assert.NotEqual(t, h1, h2)
+
+	assert.NoError(t, os.WriteFile(path.Join(dir, "mojo", "README.md"), []byte("readme"), 0o644))
+	assert.NoError(t, os.WriteFile(path.Join(dir, "other.mojo"), []byte(fakeCoreSource), 0o644))
+	h3, err := hashPackageSource(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, h2, h3)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/mojo-lang/rpc/go/pkg/mojo/rpc"
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
 	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
@@ -35,69 +36,203 @@ var _ = geom.LngLat{}
 //go:embed mojo/*
 var packages embed.FS
 
-var mojoPackages map[string]*lang.Package
-var mojoPackagesOnce sync.Once
+// mojoPackageNames all the mojo standard packages, in the order of dependency
+var mojoPackageNames = []string{"core", "document", "lang", "openapi", "http", "db", "rpc", "geom"}
 
-func GetMojoPackages() map[string]*lang.Package {
-	mojoPackagesOnce.Do(func() {
-		pkgs := make(map[string]*lang.Package)
-		err := fs.WalkDir(packages, ".", func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
-			if d.IsDir() {
-				return nil
-			}
-			if strings.HasSuffix(path, ".pb.binary") {
-				return nil
-			}
+// mojoPackageTemplate the embedded (or overridden) mojo package, decoded when it's required first time.
+//
+// the template is immutable, it's never attached to the packages directly, but copied by the MojoPackages
+// of each build, so the compiling plugins with the options of one build never change it for the others.
+type mojoPackageTemplate struct {
+	once sync.Once
+	pkg  *lang.Package
+}
 
-			f, err := packages.Open(path)
-			if err != nil {
-				return err
-			}
-			b, err := io.ReadAll(f)
-			if err != nil {
-				return err
-			}
+var mojoPackageTemplates = func() map[string]*mojoPackageTemplate {
+	templates := make(map[string]*mojoPackageTemplate)
+	for _, name := range mojoPackageNames {
+		templates["mojo."+name] = &mojoPackageTemplate{}
+	}
+	return templates
+}()
 
-			pkg := &lang.Package{}
-			if err = proto.Unmarshal(b, pkg); err != nil {
-				return err
-			}
+func getMojoPackageTemplate(name string) *lang.Package {
+	template, ok := mojoPackageTemplates[name]
+	if !ok {
+		return nil
+	}
 
-			pkgs[pkg.FullName] = pkg
-			return nil
-		})
+	template.once.Do(func() {
+		template.pkg = loadMojoPackage(name)
+	})
+	return template.pkg
+}
 
-		if err != nil {
-			logs.Errorw("failed to get embed mojo packages", "error", err)
-			return
+func loadMojoPackage(name string) *lang.Package {
+	if source, ok := getMojoPackageOverride(name); ok {
+		pkg, err := loadOverriddenPackage(name, source)
+		if err == nil {
+			logs.Infow("override the embedded mojo package", "package", name, "source", source)
+			return pkg
 		}
+		logs.Errorw("failed to override the mojo package, use the embedded one", "package", name, "source", source, "error", err)
+	}
+
+	pkg, err := decodeMojoPackage(name)
+	if err != nil {
+		logs.Errorw("failed to decode the embedded mojo package", "package", name, "error", err)
+		return nil
+	}
+	return pkg
+}
+
+func decodeMojoPackage(name string) (*lang.Package, error) {
+	b, err := packages.ReadFile("mojo/" + lang.GetPackageName(name) + ".binary")
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &lang.Package{}
+	if err = proto.Unmarshal(b, pkg); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// MojoPackages the mojo standard packages used in one build.
+//
+// the package is copied from the immutable decoded one when it's required first time, and then shared by
+// all the packages in the build, so decoding only happens once and only for the packages really used.
+type MojoPackages struct {
+	mutex    sync.Mutex
+	packages map[string]*lang.Package
+}
+
+func NewMojoPackages() *MojoPackages {
+	return &MojoPackages{
+		packages: make(map[string]*lang.Package),
+	}
+}
 
-		for _, pkg := range pkgs {
-			if len(pkg.Dependencies) > 0 {
-				if pkg.ResolvedDependencies == nil {
-					pkg.ResolvedDependencies = make(map[string]*lang.Package)
-				}
-				for name := range pkg.Dependencies {
-					pkg.ResolvedDependencies[name] = pkgs[name]
-				}
+// Get the mojo package with its dependencies resolved, nil if it's not a mojo standard package
+func (m *MojoPackages) Get(name string) *lang.Package {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.get(name)
+}
+
+func (m *MojoPackages) get(name string) *lang.Package {
+	if pkg, ok := m.packages[name]; ok {
+		return pkg
+	}
+
+	template := getMojoPackageTemplate(name)
+	if template == nil {
+		return nil
+	}
+
+	// the mojo packages are in the order of dependency, so there is no circular dependency here
+	pkg := proto.Clone(template).(*lang.Package)
+	m.packages[name] = pkg
+	for dependency := range pkg.Dependencies {
+		if dep := m.get(dependency); dep != nil {
+			if pkg.ResolvedDependencies == nil {
+				pkg.ResolvedDependencies = make(map[string]*lang.Package)
 			}
+			pkg.ResolvedDependencies[dependency] = dep
 		}
-		mojoPackages = pkgs
-	})
+	}
+	return pkg
+}
+
+// All returns all the mojo standard packages, which decodes all of them
+func (m *MojoPackages) All() map[string]*lang.Package {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 
-	return mojoPackages
+	pkgs := make(map[string]*lang.Package)
+	for _, name := range mojoPackageNames {
+		if pkg := m.get("mojo." + name); pkg != nil {
+			pkgs[pkg.FullName] = pkg
+		}
+	}
+	return pkgs
+}
+
+var mojoPackages = NewMojoPackages()
+
+// GetMojoPackages all the mojo standard packages shared in the process, the compiling will change them,
+// so using NewMojoPackages for each build instead.
+func GetMojoPackages() map[string]*lang.Package {
+	return mojoPackages.All()
 }
 
 func GetMojoPackage(name string) *lang.Package {
-	for n, pkg := range GetMojoPackages() {
-		if n == name {
-			return pkg
+	return mojoPackages.Get(name)
+}
+
+// referencedMojoPackages the mojo packages referenced by the qualified types and attributes in the parsed package,
+// like `mojo.db.Foo`, `geom.LngLat` or `@http.get`. the qualifiers are sufficient, as only the identifiers of
+// the mojo.core, which is always attached, are declared unqualified by the namer, the others are only visible
+// by the package qualified names
+func referencedMojoPackages(pkg *lang.Package) []string {
+	qualifiers := make(map[string]bool)
+	collectQualifiers(pkg.ProtoReflect(), make(map[protoreflect.Message]bool), qualifiers)
+
+	var names []string
+	for qualifier := range qualifiers {
+		name := strings.Split(strings.TrimPrefix(qualifier, "mojo."), ".")[0]
+		if _, ok := mojoPackageTemplates["mojo."+name]; ok {
+			names = append(names, "mojo."+name)
 		}
 	}
-	return nil
+	sort.Strings(names)
+	return names
+}
+
+func collectQualifiers(m protoreflect.Message, visited map[protoreflect.Message]bool, qualifiers map[string]bool) {
+	if visited[m] {
+		return
+	}
+	visited[m] = true
+
+	switch v := m.Interface().(type) {
+	case *lang.NominalType:
+		if len(v.PackageName) > 0 {
+			qualifiers[v.PackageName] = true
+		}
+	case *lang.Attribute:
+		if len(v.PackageName) > 0 {
+			qualifiers[v.PackageName] = true
+		}
+	case *lang.Package:
+		// the dependencies are not parts of the package
+		for _, child := range v.Children {
+			collectQualifiers(child.ProtoReflect(), visited, qualifiers)
+		}
+		for _, file := range v.SourceFiles {
+			collectQualifiers(file.ProtoReflect(), visited, qualifiers)
+		}
+		return
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				collectQualifiers(list.Get(i).Message(), visited, qualifiers)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+				collectQualifiers(value.Message(), visited, qualifiers)
+				return true
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			collectQualifiers(v.Message(), visited, qualifiers)
+		}
+		return true
+	})
 }
 
 func GetMojoPbFile(name string) *BinaryFile {
@@ -144,7 +279,7 @@ func GetMojoPbFile(name string) *BinaryFile {
 // 4. then other package one by one
 func GenerateMojoPackages(projectPath string) error {
 	pkgs := parseGoMod(projectPath)
-	for _, name := range []string{"core", "document", "lang", "openapi", "http", "db", "rpc", "geom"} {
+	for _, name := range mojoPackageNames {
 		if err := generatePackage(name, projectPath, pkgs); err != nil {
 			return err
 		}
@@ -242,8 +377,7 @@ func compileMojoPackage(pkg *mojoPackage) (*lang.Package, *BinaryFile, error) {
 		return nil, nil, err
 	}
 
-	plugins := plugin.NewPlugins("mpm", "syntax")
-	p, err := plugins.ParsePath(plugin.WithWorkingDir(context.Empty(), dir), dir)
+	p, err := compileMojoPackageDir(context.Empty(), dir)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -283,6 +417,14 @@ func compileMojoPackage(pkg *mojoPackage) (*lang.Package, *BinaryFile, error) {
 	return p, pbFile, nil
 }
 
+func compileMojoPackageDir(ctx context.Context, dir string) (*lang.Package, error) {
+	plugins, err := plugin.ResolvePlugins("mpm", "syntax")
+	if err != nil {
+		return nil, err
+	}
+	return plugins.ParsePath(plugin.WithWorkingDir(ctx, dir), dir)
+}
+
 func shrinkPackage(pkg *lang.Package) {
 	pkg.ExtraInfo.Delete("workingDir").Delete("path").Delete(pluginName)
 	pkg.ResolvedDependencies = nil
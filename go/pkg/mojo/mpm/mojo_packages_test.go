@@ -3,7 +3,15 @@ package mpm
 import (
 	"testing"
 
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/parser/syntax"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/printer"
+	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
 func TestBinaryFile_Decode(t *testing.T) {
@@ -25,3 +33,110 @@ func TestGenerateMojoPackages(t *testing.T) {
 	// err := GenerateMojoPackages("../../../")
 	// assert.NoError(t, err)
 }
+
+// mojoPackageSources print the embedded mojo packages back to the mojo sources, which are the largest
+// sources parsed in the builds
+func mojoPackageSources() []string {
+	var sources []string
+	for _, name := range mojoPackageNames {
+		for _, pkg := range GetMojoPackage("mojo." + name).GetAllPackageArray() {
+			for _, file := range pkg.SourceFiles {
+				if source := printMojoSource(file); len(source) > 0 {
+					sources = append(sources, source)
+				}
+			}
+		}
+	}
+	return sources
+}
+
+func printMojoSource(file *lang.SourceFile) (source string) {
+	// the printer does not support all the declarations yet, ignore the unsupported files
+	defer func() {
+		if r := recover(); r != nil {
+			source = ""
+		}
+	}()
+	return printer.New(nil).PrintSourceFile(context.Empty(), file).Buffer.String()
+}
+
+func parseMojoSource(source string, twoStage bool) (*lang.SourceFile, error) {
+	stream := antlr.NewCommonTokenStream(syntax.NewMojoLexer(antlr.NewInputStream(source)), 0)
+	errorListener := util.NewErrorListener("", false)
+	parser := syntax.NewMojoParser(stream)
+	parser.BuildParseTrees = true
+
+	var tree syntax.IMojoFileContext
+	if twoStage {
+		tree = util.ParseTwoStage(parser, errorListener, parser.MojoFile)
+	} else {
+		parser.RemoveErrorListeners()
+		parser.AddErrorListener(errorListener)
+		tree = parser.MojoFile()
+	}
+	if errorListener.Errors != nil {
+		return nil, errorListener.Errors
+	}
+	return syntax.NewMojoFileVisitor().Visit(tree).(*lang.SourceFile), nil
+}
+
+func TestParseTwoStage_MojoPackageSources(t *testing.T) {
+	sources := mojoPackageSources()
+	assert.NotEmpty(t, sources)
+
+	for _, source := range sources {
+		expected, err := parseMojoSource(source, false)
+		actual, twoStageErr := parseMojoSource(source, true)
+		if err != nil {
+			assert.EqualError(t, twoStageErr, err.Error())
+			continue
+		}
+
+		assert.NoError(t, twoStageErr)
+		assert.True(t, proto.Equal(expected, actual))
+	}
+}
+
+// BenchmarkParseTwoStage_MojoPackageSources compares the full LL parsing with the SLL first one on the
+// sources of the embedded mojo packages
+func BenchmarkParseTwoStage_MojoPackageSources(b *testing.B) {
+	sources := mojoPackageSources()
+	for _, c := range []struct {
+		name     string
+		twoStage bool
+	}{{"ll", false}, {"sll-ll", true}} {
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, source := range sources {
+					_, _ = parseMojoSource(source, c.twoStage)
+				}
+			}
+		})
+	}
+}
+
+func TestReferencedMojoPackages(t *testing.T) {
+	file, err := syntax.New(nil).ParseString(context.Empty(), `
+type Foo {
+    bar: mojo.db.Bar @1
+    locations: [geom.LngLat] @2 @http.header("X-Locations")
+}
+`)
+	assert.NoError(t, err)
+
+	names := referencedMojoPackages(&lang.Package{SourceFiles: []*lang.SourceFile{file}})
+	assert.Equal(t, []string{"mojo.core", "mojo.db", "mojo.geom", "mojo.http"}, names)
+}
+
+func TestMojoPackages_Get(t *testing.T) {
+	pkgs := NewMojoPackages()
+	httpPkg := pkgs.Get("mojo.http")
+	assert.NotNil(t, httpPkg)
+	assert.Same(t, httpPkg, pkgs.Get("mojo.http"))
+	assert.Same(t, pkgs.Get("mojo.core"), httpPkg.ResolvedDependencies["mojo.core"])
+
+	// each build has its own copy, the template is never shared
+	assert.NotSame(t, httpPkg, NewMojoPackages().Get("mojo.http"))
+	assert.NotSame(t, getMojoPackageTemplate("mojo.http"), httpPkg)
+	assert.Nil(t, pkgs.Get("mojo.unknown"))
+}
@@ -0,0 +1,187 @@
+package mpm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"runtime/debug"
+	"sort"
+	"sync"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+const (
+	cacheKeyName = "cacheKey"
+
+	cacheDisabledKey = "@cacheDisabled"
+)
+
+// WithCacheDisabled disable the build cache, all the packages will be compiled from the source
+func WithCacheDisabled(ctx context.Context) context.Context {
+	return context.WithValues(ctx, cacheDisabledKey, true)
+}
+
+func isCacheDisabled(ctx context.Context) bool {
+	return context.GetBool(ctx, cacheDisabledKey)
+}
+
+// PackageCache the content-addressed cache of the compiled packages, located in `$MOJO_HOME/cache/packages`.
+//
+// the key of the package is the hash of its source files, the keys of its dependencies,
+// the plugins which compiled it and the version of mojo.
+type PackageCache struct {
+	Dir string
+}
+
+func NewPackageCache() *PackageCache {
+	return &PackageCache{
+		Dir: path.Join(config.MojoHome(), "cache", "packages"),
+	}
+}
+
+func (c *PackageCache) Get(key string) *lang.Package {
+	fileName := c.fileName(key)
+	if !core.IsExist(fileName) {
+		return nil
+	}
+
+	pkg, err := readPackageFile(fileName)
+	if err != nil {
+		logs.Warnw("failed to read the cached package, ignore it", "file", fileName, "error", err)
+		return nil
+	}
+
+	return pkg
+}
+
+// ErrCircularReference the compiled package has the circular references, which is not cached
+var ErrCircularReference = errors.New("the compiled package has circular references")
+
+// Put saves the compiled package without its dependencies, the package whose compiled types refer each
+// other can't be serialized, so it is refused with the ErrCircularReference and compiled in every build
+func (c *PackageCache) Put(key string, pkg *lang.Package) error {
+	// the dependencies are cached by themselves, do not save them into this package
+	all := pkg.GetAllPackageArray()
+	dependencies := make([]map[string]*lang.Package, len(all))
+	for i, p := range all {
+		dependencies[i] = p.ResolvedDependencies
+		p.ResolvedDependencies = nil
+	}
+	defer func() {
+		for i, p := range all {
+			p.ResolvedDependencies = dependencies[i]
+		}
+	}()
+
+	// the compiled types may refer each other (circular types, entity relations), which can't be serialized
+	if util.HasCircularReference(pkg) {
+		return ErrCircularReference
+	}
+
+	return writePackageFile(c.fileName(key), pkg)
+}
+
+func (c *PackageCache) fileName(key string) string {
+	return path.Join(c.Dir, key[:2], key+".binary")
+}
+
+// PackageCacheKey calculate the cache key of the package in the dir,
+// all the dependencies of the package should be resolved before
+func PackageCacheKey(dir string, pkg *lang.Package, plugins []string) (string, error) {
+	sourceHash, err := hashPackageSource(dir)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(mojoVersion()))
+	h.Write([]byte(pkg.FullName))
+	h.Write([]byte(sourceHash))
+	for _, name := range plugins {
+		h.Write([]byte(name))
+	}
+
+	var names []string
+	for name := range pkg.ResolvedDependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dep := pkg.ResolvedDependencies[name]
+		key := dep.GetExtraString(cacheKeyName)
+		if len(key) == 0 {
+			if !isEmbeddedMojoPackage(dep) {
+				return "", fmt.Errorf("the dependency %s of package %s has no cache key", name, pkg.FullName)
+			}
+			// the embedded package is identified by the mojo version
+			key = "embedded"
+		}
+		h.Write([]byte(name))
+		h.Write([]byte(key))
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isEmbeddedMojoPackage(pkg *lang.Package) bool {
+	return len(pkg.GetExtraString("workingDir")) == 0 && len(pkg.GetExtraString("path")) == 0
+}
+
+func readPackageFile(fileName string) (*lang.Package, error) {
+	bytes, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &lang.Package{}
+	if err = proto.Unmarshal(bytes, pkg); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+func writePackageFile(fileName string, pkg *lang.Package) error {
+	bytes, err := proto.Marshal(pkg)
+	if err != nil {
+		return err
+	}
+	if err = core.CreateDir(path.Dir(fileName)); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, bytes, 0o644)
+}
+
+var version string
+var versionOnce sync.Once
+
+// mojoVersion the build info of the running mojo binary, plus the size and the modification time
+// of the executable, so that a rebuilt binary never uses the stale cache.
+func mojoVersion() string {
+	versionOnce.Do(func() {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			version = info.Main.Version
+			for _, setting := range info.Settings {
+				if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+					version += "-" + setting.Value
+				}
+			}
+		}
+		if exe, err := os.Executable(); err == nil {
+			if stat, err := os.Stat(exe); err == nil {
+				version += fmt.Sprintf("-%d-%d", stat.Size(), stat.ModTime().UnixNano())
+			}
+		}
+	})
+	return version
+}
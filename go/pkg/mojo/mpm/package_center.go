@@ -1,7 +1,6 @@
 package mpm
 
 import (
-	"os"
 	"os/exec"
 	"path"
 	"strings"
@@ -10,6 +9,8 @@ import (
 	"github.com/mojo-lang/core/go/pkg/mojo/core"
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 	"google.golang.org/protobuf/proto"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
 )
 
 var packageCenter *PackageCenter
@@ -19,11 +20,7 @@ func GetPackageCenter() *PackageCenter {
 		Cache: make(map[string]*lang.Package),
 	}
 
-	center.MojoHome = os.Getenv("MOJO_HOME")
-	if len(center.MojoHome) == 0 {
-		home, _ := os.UserHomeDir()
-		center.MojoHome = path.Join(home, "mojo")
-	}
+	center.MojoHome = config.MojoHome()
 	center.MojoPkgRoot = path.Join(center.MojoHome, "pkg")
 
 	return center
@@ -138,10 +135,46 @@ func (p *PackageCenter) Update(name string, requirement *lang.Package_Requiremen
 	return repoPath, nil
 }
 
+// Checkout install or update the package, then check out the revision to its own worktree, so the
+// builds using the different revisions of the same package would not clobber each other in the shared repository
+func (p *PackageCenter) Checkout(name string, requirement *lang.Package_Requirement, revision string) (string, error) {
+	repoPath, err := p.Get(name, requirement)
+	if err != nil {
+		return "", err
+	}
+
+	worktree := p.getWorktreePath(requirement, revision)
+
+	var cmd *exec.Cmd
+	if core.IsExist(worktree) {
+		// move the worktree to the revision again, which may be a branch updated since the last checkout
+		cmd = exec.Command("git", "checkout", "--detach", revision)
+		cmd.Dir = worktree
+	} else {
+		cmd = exec.Command("git", "worktree", "add", "--detach", worktree, revision)
+		cmd.Dir = repoPath
+	}
+
+	logs.Debugw("begin to checkout mojo package", "package", name, "cmd", cmd.String())
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		logs.Errorw("failed to run git cmd", "error", string(out), "cmd", cmd.String())
+		return "", err
+	}
+	logs.Debugw("finish to checkout mojo package", "package", name, "cmd", cmd.String())
+
+	return worktree, nil
+}
+
 func (p *PackageCenter) getPkgPath(requirement *lang.Package_Requirement) string {
 	return path.Join(p.MojoPkgRoot, requirement.Repository.FormatWithoutSchema())
 }
 
+// getWorktreePath the worktree of the revision, `$MOJO_HOME/worktree/<repository>/<revision>`
+func (p *PackageCenter) getWorktreePath(requirement *lang.Package_Requirement, revision string) string {
+	return path.Join(p.MojoHome, "worktree", requirement.Repository.FormatWithoutSchema(), strings.ReplaceAll(revision, "/", "-"))
+}
+
 func pathObject(path string) *core.Object {
 	object := &core.Object{}
 	object.SetString("path", path)
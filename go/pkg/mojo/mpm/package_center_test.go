@@ -1,8 +1,14 @@
 package mpm
 
 import (
+	"os"
+	"os/exec"
+	"path"
+	"strings"
 	"testing"
 
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -10,3 +16,63 @@ func TestGetGitLastCommit(t *testing.T) {
 	commit := GetGitLatestCommit(".")
 	assert.NotNil(t, commit)
 }
+
+func gitCommit(t *testing.T, dir string, content string) string {
+	assert.NoError(t, os.WriteFile(path.Join(dir, "package.mojo"), []byte(content), 0o644))
+	for _, args := range [][]string{
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@mojo", "commit", "-q", "-m", content},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if !assert.NoError(t, err, string(out)) {
+			t.FailNow()
+		}
+	}
+	return GetGitLatestCommit(dir).Hash
+}
+
+func TestPackageCenter_Checkout(t *testing.T) {
+	t.Setenv("MOJO_HOME", t.TempDir())
+
+	repo := t.TempDir()
+	cmd := exec.Command("git", "init", "-q")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); !assert.NoError(t, err, string(out)) {
+		t.FailNow()
+	}
+	first := gitCommit(t, repo, "first")
+	second := gitCommit(t, repo, "second")
+
+	url, err := core.NewUrl("github.com/mojo-lang/core")
+	assert.NoError(t, err)
+	requirement := &lang.Package_Requirement{Repository: url}
+
+	// the repository is installed already
+	center := GetPackageCenter()
+	center.Cache["mojo.core"] = &lang.Package{ExtraInfo: pathObject(repo)}
+
+	firstDir, err := center.Checkout("mojo.core", requirement, first)
+	assert.NoError(t, err)
+	secondDir, err := center.Checkout("mojo.core", requirement, second)
+	assert.NoError(t, err)
+	assert.NotEqual(t, firstDir, secondDir)
+	assert.True(t, strings.HasPrefix(firstDir, path.Join(center.MojoHome, "worktree")))
+
+	// the revisions are checked out side by side, and the shared repository is untouched
+	read := func(dir string) string {
+		content, err := os.ReadFile(path.Join(dir, "package.mojo"))
+		assert.NoError(t, err)
+		return string(content)
+	}
+	assert.Equal(t, "first", read(firstDir))
+	assert.Equal(t, "second", read(secondDir))
+	assert.Equal(t, "second", read(repo))
+
+	// check out the same revision again reuses the worktree
+	dir, err := center.Checkout("mojo.core", requirement, first)
+	assert.NoError(t, err)
+	assert.Equal(t, firstDir, dir)
+	assert.Equal(t, "first", read(dir))
+}
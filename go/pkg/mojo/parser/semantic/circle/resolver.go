@@ -30,6 +30,8 @@ func NewResolver(options core.Options) *Resolver {
 		BasicPlugin: plugin.BasicPlugin{
 			Name:          pluginName,
 			Group:         "semantic",
+			Provides:      []string{plugin.CircleDependencies},
+			Requires:      []string{plugin.ResolvedIdentifiers},
 			GroupPriority: 3,
 			Priority:      5,
 			Creator: func(options core.Options) plugin.Plugin {
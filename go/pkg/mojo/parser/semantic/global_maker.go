@@ -27,6 +27,8 @@ func NewGlobalMaker(options core.Options) *GlobalMaker {
 		BasicPlugin: plugin.BasicPlugin{
 			Name:          "semantic.global-maker",
 			Group:         "semantic",
+			Provides:      []string{plugin.GlobalPackage},
+			Requires:      []string{plugin.SyntaxTree},
 			GroupPriority: 3,
 			Priority:      0,
 			Creator: func(options core.Options) plugin.Plugin {
@@ -25,10 +25,13 @@ type GlobalRemover struct {
 func NewGlobalRemover(options core.Options) *GlobalRemover {
 	return &GlobalRemover{
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          "semantic.global-remover",
-			Group:         "semantic",
-			GroupPriority: 3,
-			Priority:      100,
+			Name:             "semantic.global-remover",
+			Group:            "semantic",
+			Provides:         []string{plugin.SemanticTree},
+			Requires:         []string{plugin.GlobalPackage, plugin.ResolvedIdentifiers},
+			OptionalRequires: []string{plugin.CircleDependencies},
+			GroupPriority:    3,
+			Priority:         100,
 			Creator: func(options core.Options) plugin.Plugin {
 				return NewGlobalRemover(options)
 			},
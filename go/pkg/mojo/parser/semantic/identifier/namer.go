@@ -30,6 +30,8 @@ func NewNamer(options core.Options) *Namer {
 		BasicPlugin: plugin.BasicPlugin{
 			Name:          namerName,
 			Group:         "semantic",
+			Provides:      []string{plugin.DeclaredIdentifiers},
+			Requires:      []string{plugin.GlobalPackage},
 			GroupPriority: 3,
 			Priority:      1,
 			Creator: func(options core.Options) plugin.Plugin {
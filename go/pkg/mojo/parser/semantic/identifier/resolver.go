@@ -29,6 +29,8 @@ func NewResolver(options core.Options) *Resolver {
 		BasicPlugin: plugin.BasicPlugin{
 			Name:          resolverName,
 			Group:         "semantic",
+			Provides:      []string{plugin.ResolvedIdentifiers},
+			Requires:      []string{plugin.DeclaredIdentifiers},
 			GroupPriority: 3,
 			Priority:      3,
 			Creator: func(options core.Options) plugin.Plugin {
@@ -48,6 +48,8 @@ func (a *AttributeVisitor) VisitAttribute(ctx *AttributeContext) interface{} {
 	attributeIdentifier := ctx.AttributeIdentifier()
 	if attributeIdentifier != nil {
 		if attribute, ok := attributeIdentifier.Accept(a).(*lang.Attribute); ok {
+			// the end of the arguments, which the union type compares to attach the attributes to its members
+			attribute.EndPosition = GetPosition(ctx.GetStop())
 			attribute.GenericArguments = GetGenericArguments(ctx.GenericArgumentClause())
 
 			argumentClause := ctx.AttributeArgumentClause()
@@ -2,6 +2,7 @@ package syntax
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
 	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/parser/semantic"
+	protobuf "github.com/mojo-lang/mojo/go/pkg/protobuf/parser/syntax"
 	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
@@ -23,6 +26,14 @@ func init() {
 
 const pluginName = "syntax.parser"
 
+const (
+	mojoFileExt  = ".mojo"
+	protoFileExt = ".proto"
+)
+
+// ConcurrencyOption the option name of the worker pool size to parse the source files, 1 to parse one by one
+const ConcurrencyOption = "concurrency"
+
 type Parser struct {
 	plugin.BasicPlugin
 
@@ -32,10 +43,13 @@ type Parser struct {
 func New(options core.Options) *Parser {
 	return &Parser{
 		BasicPlugin: plugin.BasicPlugin{
-			Name:          pluginName,
-			Group:         "syntax",
-			GroupPriority: 2,
-			Priority:      1,
+			Name:             pluginName,
+			Group:            "syntax",
+			Provides:         []string{plugin.SyntaxTree},
+			OptionalRequires: []string{plugin.ResolvedDependencies},
+			GroupPriority:    2,
+			Priority:         1,
+			SupportedOptions: []string{ConcurrencyOption},
 			Creator: func(options core.Options) plugin.Plugin {
 				return New(options)
 			},
@@ -78,10 +92,9 @@ func (p *Parser) ParseStream(fileName string, input *antlr.InputStream) (*lang.S
 	errorListener.FileName = fileName
 
 	parser := NewMojoParser(stream)
-	parser.AddErrorListener(errorListener)
 	parser.BuildParseTrees = true
 
-	tree := parser.MojoFile()
+	tree := util.ParseTwoStage(parser, errorListener, parser.MojoFile)
 	if sourceFile, ok := NewMojoFileVisitor().Visit(tree).(*lang.SourceFile); ok {
 		if errorListener.Errors == nil {
 			comments := CommentParser{}.Parse(stream)
@@ -120,14 +133,12 @@ func (p *Parser) ParsePackage(ctx context.Context, pkg *lang.Package) (err error
 
 func (p *Parser) ParsePath(ctx context.Context, pkgPath string) (*lang.Package, error) {
 	currentPkg := plugin.ContextDeclaredPackage(ctx)
-	currentPkgName := ""
 	if currentPkg != nil {
 		if util.IsPackageProcessed(currentPkg, pluginName) {
 			return currentPkg, nil
 		}
-		currentPkgName = currentPkg.FullName
 	} else {
-		currentPkgName = plugin.ContextPackageName(ctx)
+		currentPkgName := plugin.ContextPackageName(ctx)
 		currentPkg = &lang.Package{
 			Name:     lang.GetPackageName(currentPkgName),
 			FullName: currentPkgName,
@@ -141,11 +152,53 @@ func (p *Parser) ParsePath(ctx context.Context, pkgPath string) (*lang.Package,
 		pkgPath = ""
 	}
 
+	// walk the package tree first, then parse all the source files in the worker pool
+	var tasks []*parseTask
+	var packages []*lang.Package
+	if err := p.walkPath(ctx, fileSys, pkgPath, currentPkg, &packages, &tasks); err != nil {
+		return nil, err
+	}
+
+	err := util.Parallel(len(tasks), p.concurrency(), func(i int) (err error) {
+		task := tasks[i]
+		if path.Ext(task.fileName) == protoFileExt {
+			task.sourceFile, err = p.parseProtoFile(task.ctx, task.fileName)
+		} else {
+			task.sourceFile, err = p.ParseFile(task.ctx, task.fileName)
+		}
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		task.sourceFile.PackageName = task.pkg.FullName
+		task.sourceFile.FullName = path.Join(lang.PackageNameToPath(task.pkg.FullName), task.sourceFile.Name)
+		task.pkg.SourceFiles = append(task.pkg.SourceFiles, task.sourceFile)
+	}
+
+	for _, pkg := range packages {
+		util.SetPackageProcessed(pkg, pluginName)
+	}
+	return currentPkg, nil
+}
+
+type parseTask struct {
+	ctx        context.Context
+	pkg        *lang.Package
+	fileName   string
+	sourceFile *lang.SourceFile
+}
+
+// walkPath build the package tree from the directories, and collect the source files to parse in the directory order
+func (p *Parser) walkPath(ctx context.Context, fileSys fs.FS, pkgPath string, currentPkg *lang.Package, packages *[]*lang.Package, tasks *[]*parseTask) error {
+	currentPkgName := currentPkg.FullName
 	currentPath := path.Join(pkgPath, lang.PackageNameToPath(currentPkgName))
 	files, err := fs.ReadDir(fileSys, currentPath)
 	if err != nil {
 		logs.Errorw("failed to read source directory", "path", currentPath, "error", err.Error())
-		return nil, err
+		return err
 	}
 
 	parentPkg := context.Package(ctx)
@@ -156,8 +209,10 @@ func (p *Parser) ParsePath(ctx context.Context, pkgPath string) (*lang.Package,
 		currentPkg.Repository = parentPkg.Repository
 		currentPkg.Version = parentPkg.Version
 	}
+	*packages = append(*packages, currentPkg)
 
 	thisCtx := context.WithType(ctx, currentPkg)
+	names := make(map[string]string)
 	for _, f := range files {
 		if f.IsDir() {
 			pkgName := ""
@@ -167,28 +222,53 @@ func (p *Parser) ParsePath(ctx context.Context, pkgPath string) (*lang.Package,
 				pkgName = currentPkgName + "." + f.Name()
 			}
 
-			newCtx := plugin.WithDeclaredPackage(context.WithType(thisCtx, currentPkg), &lang.Package{
+			childPkg := &lang.Package{
 				Name:     lang.GetPackageName(pkgName),
 				FullName: pkgName,
-			})
-			if _, err = p.ParsePath(newCtx, pkgPath); err != nil {
-				return nil, err
+			}
+			if err = p.walkPath(thisCtx, fileSys, pkgPath, childPkg, packages, tasks); err != nil {
+				return err
 			}
 		} else {
-			if !strings.HasSuffix(f.Name(), ".mojo") {
+			ext := path.Ext(f.Name())
+			if ext != mojoFileExt && ext != protoFileExt {
 				continue
 			}
 
-			if sourceFile, err := p.ParseFile(thisCtx, path.Join(currentPath, f.Name())); err != nil {
-				return nil, err
-			} else {
-				sourceFile.PackageName = currentPkgName
-				sourceFile.FullName = path.Join(lang.PackageNameToPath(currentPkgName), sourceFile.Name)
-				currentPkg.SourceFiles = append(currentPkg.SourceFiles, sourceFile)
+			// the proto file is converted to the mojo source file with the same name
+			name := strings.TrimSuffix(f.Name(), ext)
+			if other, ok := names[name]; ok {
+				return fmt.Errorf("the source files %s and %s in %s conflict", other, f.Name(), currentPath)
 			}
+			names[name] = f.Name()
+
+			*tasks = append(*tasks, &parseTask{
+				ctx:      thisCtx,
+				pkg:      currentPkg,
+				fileName: path.Join(currentPath, f.Name()),
+			})
 		}
 	}
+	return nil
+}
 
-	util.SetPackageProcessed(currentPkg, pluginName)
-	return currentPkg, nil
+// parseProtoFile parse the proto file in the package, and convert it to the mojo source file
+func (p *Parser) parseProtoFile(ctx context.Context, fileName string) (*lang.SourceFile, error) {
+	source, err := protobuf.New(p.Options).ParseFile(ctx, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceFile, err := semantic.ConvertSource(source)
+	if err != nil {
+		return nil, err
+	}
+	sourceFile.Name = strings.TrimSuffix(source.Name, protoFileExt) + mojoFileExt
+	return sourceFile, nil
+}
+
+// concurrency the size of the worker pool parsing the source files, using the `concurrency` option,
+// default is the number of the CPUs
+func (p *Parser) concurrency() int {
+	return int(p.Options.GetInteger(ConcurrencyOption))
 }
@@ -2,11 +2,21 @@ package syntax
 
 import (
 	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
 func TestParser_ParseString(t *testing.T) {
@@ -26,3 +36,106 @@ func TestParser_ParseExpression2(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, expr)
 }
+
+const ncraftPackagePath = "../../../ncraft/testdata/mojo-ncraft/mojo"
+
+func TestParser_ParsePath(t *testing.T) {
+	sequential, err := New(core.Options{ConcurrencyOption: 1}).ParsePath(plugin.WithPackageName(context.Background(), "ncraft"), ncraftPackagePath)
+	assert.NoError(t, err)
+
+	parallel, err := New(core.Options{ConcurrencyOption: 4}).ParsePath(plugin.WithPackageName(context.Background(), "ncraft"), ncraftPackagePath)
+	assert.NoError(t, err)
+
+	files := 0
+	for _, pkg := range parallel.GetAllPackageArray() {
+		files += len(pkg.SourceFiles)
+	}
+	assert.Equal(t, 2, files)
+	assert.True(t, proto.Equal(sequential, parallel))
+}
+
+func BenchmarkParser_ParsePath(b *testing.B) {
+	for _, c := range []struct {
+		name        string
+		concurrency int
+	}{{"sequential", 1}, {"parallel", 0}} {
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := New(core.Options{ConcurrencyOption: c.concurrency}).ParsePath(plugin.WithPackageName(context.Background(), "ncraft"), ncraftPackagePath); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_ParseString_Error(t *testing.T) {
+	file, err := plugin.NewPlugins("syntax").ParseString(context.Background(), "type A {\n  a: Int @1\n  b: = @2\n}")
+	assert.Error(t, err)
+	assert.Nil(t, file)
+
+	errors, ok := err.(util.ParseErrors)
+	assert.True(t, ok)
+	assert.NotEmpty(t, errors)
+	assert.Equal(t, int64(3), errors[0].Line)
+}
+
+// mojoSources the mojo source files in the testdata of the packages
+func mojoSources(t testing.TB) []string {
+	var sources []string
+	err := filepath.WalkDir("../../..", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".mojo") || !strings.Contains(filepath.ToSlash(p), "/testdata/") {
+			return nil
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, string(content))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sources
+}
+
+func parseMojoSource(source string, twoStage bool) (*lang.SourceFile, error) {
+	stream := antlr.NewCommonTokenStream(NewMojoLexer(antlr.NewInputStream(source)), 0)
+	errorListener := util.NewErrorListener("", false)
+	parser := NewMojoParser(stream)
+	parser.BuildParseTrees = true
+
+	var tree IMojoFileContext
+	if twoStage {
+		tree = util.ParseTwoStage(parser, errorListener, parser.MojoFile)
+	} else {
+		parser.RemoveErrorListeners()
+		parser.AddErrorListener(errorListener)
+		tree = parser.MojoFile()
+	}
+	if errorListener.Errors != nil {
+		return nil, errorListener.Errors
+	}
+	return NewMojoFileVisitor().Visit(tree).(*lang.SourceFile), nil
+}
+
+func TestParseTwoStage_MojoSources(t *testing.T) {
+	sources := mojoSources(t)
+	assert.NotEmpty(t, sources)
+
+	for _, source := range sources {
+		expected, err := parseMojoSource(source, false)
+		actual, twoStageErr := parseMojoSource(source, true)
+		if err != nil {
+			assert.EqualError(t, twoStageErr, err.Error())
+			continue
+		}
+
+		assert.NoError(t, twoStageErr)
+		assert.True(t, proto.Equal(expected, actual))
+	}
+}
@@ -70,7 +70,7 @@ func (p *Printer) PrintAttributes(ctx context.Context, attributes lang.Attribute
 	}
 
 	for _, attribute := range attributes {
-		if attribute.IsNumber() || attribute.IsRequired() && attribute.IsOptional() {
+		if attribute.IsNumber() || attribute.IsRequired() || attribute.IsOptional() {
 			continue
 		}
 
@@ -42,11 +42,17 @@ func (p *Printer) PrintInterfaceDecl(ctx context.Context, decl *lang.InterfaceDe
 				p.PrintTypeAliasDecl(newCtx, d)
 			}
 
-			for _, method := range decl.Type.Methods {
+			for i, method := range decl.Type.Methods {
+				if i > 0 && !p.IsNewLine() {
+					p.BreakLine()
+				}
 				p.PrintFunctionDecl(newCtx, method)
 			}
 
 			p.Outdent()
+			if !p.IsNewLine() {
+				p.BreakLine()
+			}
 			p.PrintTerm(ctx, lang.NewSymbolTerm(decl.Type.EndPosition, lang.TermTypeEnd, "}"))
 		} else {
 			if lastInheritDocument != nil {
@@ -42,7 +42,9 @@ func (p *Printer) PrintStructDecl(ctx context.Context, decl *lang.StructDecl) *P
 			hasDecl := false
 			for _, d := range decl.TypeAliasDecls {
 				if hasDecl {
-					p.BreakLine()
+					if !p.IsNewLine() {
+						p.BreakLine()
+					}
 				} else {
 					hasDecl = true
 				}
@@ -52,7 +54,9 @@ func (p *Printer) PrintStructDecl(ctx context.Context, decl *lang.StructDecl) *P
 
 			for _, d := range decl.EnumDecls {
 				if hasDecl {
-					p.BreakLine()
+					if !p.IsNewLine() {
+						p.BreakLine()
+					}
 				} else {
 					hasDecl = true
 				}
@@ -62,7 +66,9 @@ func (p *Printer) PrintStructDecl(ctx context.Context, decl *lang.StructDecl) *P
 
 			for _, d := range decl.StructDecls {
 				if hasDecl {
-					p.BreakLine()
+					if !p.IsNewLine() {
+						p.BreakLine()
+					}
 				} else {
 					hasDecl = true
 				}
@@ -72,7 +78,9 @@ func (p *Printer) PrintStructDecl(ctx context.Context, decl *lang.StructDecl) *P
 
 			for _, field := range decl.Type.Fields {
 				if hasDecl {
-					p.BreakLine()
+					if !p.IsNewLine() {
+						p.BreakLine()
+					}
 				} else {
 					hasDecl = true
 				}
@@ -21,6 +21,10 @@ func generateFiles(files []*util.GeneratedFile, output string) error {
 	guard := &util.PathGuard{
 		OnlyClearGenerated: true,
 		Suffixes:           []string{".java"},
+		Target:             "ncraft.service",
+	}
+	if err := guard.Record(output); err != nil {
+		return err
 	}
 	for _, file := range files {
 		file.SkipIfUserCodeMixed = true
@@ -14,6 +14,7 @@ import (
 	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf"
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
+	langcompiler "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
 	"github.com/mojo-lang/mojo/go/pkg/mojo/compiler/transformer"
 	"github.com/mojo-lang/mojo/go/pkg/ncraft/data"
 	"github.com/mojo-lang/mojo/go/pkg/openapi/generator/compiler"
@@ -167,9 +168,11 @@ func MethodActionName(name string) (action string, standard bool) {
 func (s *Services) CompileMethod(ctx context.Context, decl *lang.FunctionDecl, service *data.Service) error {
 	thisCtx := context.WithType(ctx, decl)
 	m := &data.Method{
-		Decl:       decl,
-		Name:       decl.Name,
-		Extensions: make(map[string]interface{}),
+		Decl:            decl,
+		Name:            decl.Name,
+		ClientStreaming: langcompiler.IsClientStreamingMethod(decl),
+		ServerStreaming: langcompiler.IsServerStreamingMethod(decl),
+		Extensions:      make(map[string]interface{}),
 	}
 	registerType := func(t *lang.NominalType) {
 		if !t.IsScalar() && !t.IsMapType() && !t.IsArrayType() && !t.IsUnionType() && (len(t.PackageName) > 0 && t.PackageName != service.PackageFullName) {
@@ -381,7 +384,11 @@ func (s *Services) CompileMethod(ctx context.Context, decl *lang.FunctionDecl, s
 		return err
 	}
 
-	service.Interface.Methods = append(service.Interface.Methods, m)
+	if m.IsStreaming() {
+		service.Interface.StreamingMethods = append(service.Interface.StreamingMethods, m)
+	} else {
+		service.Interface.Methods = append(service.Interface.Methods, m)
+	}
 	return nil
 }
 
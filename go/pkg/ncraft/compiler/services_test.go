@@ -33,3 +33,37 @@ func TestServices_CompileInterface(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, services)
 }
+
+func TestServices_CompileInterface_Stream(t *testing.T) {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "../../mojo/testdata/mojo-stream")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	options := make(core.Options)
+	for _, p := range pkg.GetAllPackages() {
+		options[p.FullName] = p.GetGoPackageImport()
+	}
+	for _, p := range pkg.GetAllDependentPackages() {
+		options[p.FullName] = p.GetGoPackageImport()
+	}
+	services, err := CompilePackage(WithGoPackageImports(context.Empty(), options), pkg)
+	if !assert.NoError(t, err) || !assert.Len(t, services, 1) {
+		t.FailNow()
+	}
+
+	iface := services[0].Interface
+	if assert.Len(t, iface.Methods, 1) {
+		assert.Equal(t, "get_message", iface.Methods[0].Name)
+	}
+	if assert.Len(t, iface.StreamingMethods, 3) {
+		streaming := make(map[string][2]bool)
+		for _, m := range iface.StreamingMethods {
+			streaming[m.Name] = [2]bool{m.ClientStreaming, m.ServerStreaming}
+		}
+		assert.Equal(t, [2]bool{true, false}, streaming["upload"])
+		assert.Equal(t, [2]bool{false, true}, streaming["watch"])
+		assert.Equal(t, [2]bool{true, true}, streaming["chat"])
+	}
+}
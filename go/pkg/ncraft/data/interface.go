@@ -15,6 +15,10 @@ type Interface struct {
 	HasSubscription bool
 	Methods         []*Method
 
+	// StreamingMethods the client, server or bidirectional streaming methods, which are not in the Methods
+	// because the go-kit endpoints are unary only
+	StreamingMethods []*Method
+
 	Extensions map[string]interface{}
 }
 
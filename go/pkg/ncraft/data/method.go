@@ -19,6 +19,11 @@ type Method struct {
 	IsStandard  bool
 	IsBatch     bool
 
+	// ClientStreaming the method receives a stream of the request messages
+	ClientStreaming bool
+	// ServerStreaming the method replies a stream of the response messages
+	ServerStreaming bool
+
 	// Bindings contains information for mapping http paths and parameters onto
 	// the fields of the Methods.
 	Bindings []*HTTPBinding
@@ -35,6 +40,12 @@ func (m *Method) GetFirstBinding() *HTTPBinding {
 	return nil
 }
 
+// IsStreaming the method is client, server or bidirectional streaming, which is served by the grpc
+// stream directly instead of the go-kit endpoint
+func (m *Method) IsStreaming() bool {
+	return m != nil && (m.ClientStreaming || m.ServerStreaming)
+}
+
 func (m *Method) GetRequest() *Message {
 	if m != nil {
 		return m.Request
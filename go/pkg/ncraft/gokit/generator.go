@@ -14,7 +14,7 @@ func GenerateClient(ds *data.Service, options Options) error {
 		return err
 	}
 
-	return generateFiles(files, options.Output)
+	return generateFiles(files, options.Output, "ncraft.client")
 }
 
 func GenerateService(ds *data.Service, options Options) error {
@@ -23,13 +23,26 @@ func GenerateService(ds *data.Service, options Options) error {
 		return err
 	}
 
-	return generateFiles(files, options.Output)
+	return generateFiles(files, options.Output, "ncraft.service")
 }
 
-func generateFiles(files []*util.GeneratedFile, output string) error {
+func GenerateSidecar(ds *data.Service, options Options) error {
+	files, err := options.GenerateSidecar(ds)
+	if err != nil {
+		return err
+	}
+
+	return generateFiles(files, options.Output, "ncraft.sidecar")
+}
+
+func generateFiles(files []*util.GeneratedFile, output string, target string) error {
 	guard := &util.PathGuard{
 		OnlyClearGenerated: true,
 		Suffixes:           []string{".go", ".mod", ".md", ".sh", ".yaml"},
+		Target:             target,
+	}
+	if err := guard.Record(output); err != nil {
+		return err
 	}
 	for _, file := range files {
 		if err := file.WriteTo(output, guard); err != nil {
@@ -1,86 +1,49 @@
 package client
 
-/*
-var (
-	spw = spew.ConfigState{
-		Indent: "   ",
-	}
-)
+import (
+	"testing"
 
-var gopath []string
-
-func init() {
-	gopath = filepath.SplitList(os.Getenv("GOPATH"))
-}
+	"github.com/stretchr/testify/assert"
 
-func TestNewClientInterfaceArgs(t *testing.T) {
-	defStr := `
-		syntax = "proto3";
-
-		// General package
-		package general;
+	"github.com/mojo-lang/mojo/go/pkg/ncraft/data"
+)
 
-		import "github.com/frankee/ncraft/deftree/googlethirdparty/annotations.proto";
+func TestNewClientArguments(t *testing.T) {
+	svc := &data.Interface{
+		Methods: []*data.Method{{
+			Name: "reverse_geocode",
+			Request: &data.Message{
+				Fields: []*data.Field{
+					{Name: "nearby", Type: &data.FieldType{Name: "Bool"}},
+					{Name: "nearby_radius", Type: &data.FieldType{Name: "Int32"}},
+					{Name: "location", Type: &data.FieldType{Name: "LngLat"}},
+					{Name: "tags", Type: &data.FieldType{Name: "String", IsArray: true}},
+					{Name: "level", Type: &data.FieldType{Name: "Level", IsEnum: true}},
+				},
+			},
+		}},
+	}
 
-		message SumRequest {
-			repeated int64 a = 1;
-			int64 b = 2;
-		}
+	args := NewClientArguments(svc).MethArgs["reverse_geocode"]
+	assert.Equal(t, 5, len(args.Args))
 
-		message SumReply {
-			int64 v = 1;
-			string err = 2;
-		}
+	nearbyRadius := args.Args[1]
+	assert.Equal(t, "nearbyRadius", nearbyRadius.JsonName)
+	assert.Equal(t, "nearby-radius", nearbyRadius.FlagName)
+	assert.Equal(t, "int32", nearbyRadius.FlagType)
+	assert.True(t, nearbyRadius.IsBaseType)
 
-		service SumSvc {
-			rpc Sum(SumRequest) returns (SumReply) {
-				option (google.api.http) = {
-					get: "/sum/{a}"
-				};
-			}
-		}
-	`
-	sd, err := svcdef.NewFromString(defStr, gopath)
-	if err != nil {
-		t.Fatal(err, "Failed to create a service from the definition string")
-	}
-	csa := New(sd.Interface)
+	assert.Equal(t, `fsReverseGeocode.Bool("nearby", false, "the nearby of the request")`, args.Args[0].FlagConvertFunc)
+	assert.Equal(t, `fsReverseGeocode.Int("nearby-radius", 0, "the nearbyRadius of the request")`, nearbyRadius.FlagConvertFunc)
+	assert.Equal(t, `fsReverseGeocode.String("location", "", "the location of the request in json")`, args.Args[2].FlagConvertFunc)
 
-	expected := &Arguments{
-		MethArgs: map[string]*MethodArguments{
-			"Sum": &MethodArguments{
-				Args: []*Argument{
-					&Argument{
-						Name:            "a",
-						FlagName:        "a",
-						FlagArg:         "flagASum",
-						FlagType:        "string",
-						FlagConvertFunc: "flagASum = fsSum.String(\"a\", \"\", \"\")",
-						GoArg:           "ASum",
-						GoType:          "[]int64",
-						GoConvertInvoc:  "\nvar ASum []int64\nif flagASum != nil && len(*flagASum) > 0 {\n\terr = json.Unmarshal([]byte(*flagASum), &ASum)\n\tif err != nil {\n\t\tpanic(errors.Wrapf(err, \"unmarshalling ASum from %v:\", flagASum))\n\t}\n}\n",
-						IsBaseType:      true,
-						Repeated:        true,
-					},
-					&Argument{
+	tags := args.Args[3]
+	assert.True(t, tags.Repeated)
+	assert.False(t, tags.IsBaseType)
+	assert.Equal(t, "string", tags.FlagType)
 
-						Name:            "b",
-						FlagName:        "b",
-						FlagArg:         "flagBSum",
-						FlagType:        "int64",
-						FlagConvertFunc: "flagBSum = fsSum.Int64(\"b\", 0, \"\")",
-						GoArg:           "BSum",
-						GoType:          "int64",
-						GoConvertInvoc:  "BSum := *flagBSum",
-						IsBaseType:      true,
-						Repeated:        false,
-					},
-				},
-			},
-		},
-	}
-	if got, want := csa, expected; !reflect.DeepEqual(got, want) {
-		t.Errorf(testdata.DiffStrings(spw.Sdump(got), spw.Sdump(want)))
-	}
+	level := args.Args[4]
+	assert.True(t, level.Enum)
+	assert.True(t, level.IsBaseType)
+	assert.Equal(t, "string", level.FlagType)
 }
-*/
@@ -49,12 +49,43 @@ func GetHandlersTemplate() string {
 	return templates.Handlers + handlerInterface + handlerMethods + handlerExtension
 }
 
+// Template is the templates of the handler's interface, methods and extension,
+// the empty ones will use the reset templates
+type Template struct {
+	Interface string
+	Methods   string
+	Extension string
+}
+
+// SidecarTemplate is the handler templates forwarding the requests to the upstream service
+var SidecarTemplate = &Template{
+	Interface: templates.SidecarHandlerInterface,
+	Methods:   templates.SidecarHandlerMethods,
+}
+
 // New returns a render.Renderer capable of updating server handlers.
 // New should be passed the previous version of the server handler to parse.
 func New(svc *data.Interface, prev io.Reader) (render.Renderer, error) {
+	return NewWithTemplate(svc, prev, nil)
+}
+
+// NewWithTemplate returns a render.Renderer rendering the handlers with the template
+func NewWithTemplate(svc *data.Interface, prev io.Reader, tmpl *Template) (render.Renderer, error) {
 	var h handler
+	h.template = Template{Interface: handlerInterface, Methods: handlerMethods, Extension: handlerExtension}
+	if tmpl != nil {
+		if len(tmpl.Interface) > 0 {
+			h.template.Interface = tmpl.Interface
+		}
+		if len(tmpl.Methods) > 0 {
+			h.template.Methods = tmpl.Methods
+		}
+		if len(tmpl.Extension) > 0 {
+			h.template.Extension = tmpl.Extension
+		}
+	}
 	// logs.WithField("Interface Methods", len(svc.Methods)).Debug("Handler being created")
-	h.methodMap = newMethodMap(svc.Methods)
+	h.methodMap = newMethodMap(svc.Methods, svc.StreamingMethods)
 	h.service = svc
 
 	if prev == nil {
@@ -74,15 +105,18 @@ func New(svc *data.Interface, prev io.Reader) (render.Renderer, error) {
 // remove service methods already in the handler file.
 type methodMap map[string]*data.Method
 
-func newMethodMap(meths []*data.Method) methodMap {
-	mMap := make(methodMap, len(meths))
-	for _, m := range meths {
-		mMap[m.Name] = m
+func newMethodMap(meths ...[]*data.Method) methodMap {
+	mMap := make(methodMap)
+	for _, ms := range meths {
+		for _, m := range ms {
+			mMap[m.Name] = m
+		}
 	}
 	return mMap
 }
 
 type handler struct {
+	template  Template
 	fileSet   *token.FileSet
 	service   *data.Interface
 	methodMap methodMap
@@ -96,7 +130,7 @@ func (h *handler) Render(alias string, service *data.Service) (io.Reader, error)
 		return nil, errors.Errorf("cannot render unknown file: %q", alias)
 	}
 	if h.ast == nil {
-		return applyServerTmpl(service)
+		return h.applyServerTmpl(service)
 	}
 
 	// Remove exported methods not defined in service definition
@@ -139,11 +173,15 @@ func (h *handler) Render(alias string, service *data.Service) (io.Reader, error)
 	}
 	for k, v := range h.methodMap {
 		logs.Infow("Generating handler from rpc definition", "Method", k)
-		ex.Interface.Methods = append(ex.Interface.Methods, v)
+		if v.IsStreaming() {
+			ex.Interface.StreamingMethods = append(ex.Interface.StreamingMethods, v)
+		} else {
+			ex.Interface.Methods = append(ex.Interface.Methods, v)
+		}
 	}
 
 	// render the server for all methods not already defined
-	newCode, err := applyServerMethsTmpl(ex)
+	newCode, err := h.applyServerMethsTmpl(ex)
 	if err != nil {
 		return nil, err
 	}
@@ -192,8 +230,11 @@ func (m methodMap) pruneDecls(decls []ast.Decl, svcName string) ([]ast.Decl, []a
 			}
 			if ok := isValidFunc(x, m, svcName); ok {
 				indexName := strcase.ToSnake(name)
-				updateParams(x, m[indexName])
-				updateResults(x, m[indexName])
+				// the streaming methods have the grpc stream in the signature, which are kept as is
+				if !m[indexName].IsStreaming() {
+					updateParams(x, m[indexName])
+					updateResults(x, m[indexName])
+				}
 				newDecls = append(newDecls, x)
 				delete(m, indexName)
 			} else {
@@ -331,11 +372,12 @@ func commentOfDecl(file *token.FileSet, comment *ast.CommentGroup, decl ast.Decl
 	return false
 }
 
-func applyServerTmpl(service *data.Service) (io.Reader, error) {
+func (h *handler) applyServerTmpl(service *data.Service) (io.Reader, error) {
 	logs.Debug("Rendering handler for the first time")
-	return util.ApplyTemplate("ServerTmpl", GetHandlersTemplate(), service, service.FuncMap)
+	tmpl := templates.Handlers + h.template.Interface + h.template.Methods + h.template.Extension
+	return util.ApplyTemplate("ServerTmpl", tmpl, service, service.FuncMap)
 }
 
-func applyServerMethsTmpl(service *data.Service) (io.Reader, error) {
-	return util.ApplyTemplate("ServerMethsTmpl", handlerMethods, service, service.FuncMap)
+func (h *handler) applyServerMethsTmpl(service *data.Service) (io.Reader, error) {
+	return util.ApplyTemplate("ServerMethsTmpl", h.template.Methods, service, service.FuncMap)
 }
@@ -64,5 +64,29 @@ const HandlerMethods = `
 			return resp, nil
 		}
 	{{end}}
+	{{range $i := $te.Interface.StreamingMethods}}
+		// {{GoName $i.Name}} implements Interface.
+		{{- if and $i.ClientStreaming $i.ServerStreaming}}
+		func (s {{ToLowerCamel $te.Interface.ServerName}}) {{GoName $i.Name}}(stream pb.{{GoName $te.Interface.Name}}_{{GoName $i.Name}}Server) error {
+			// receive the requests by stream.Recv until io.EOF, and send the responses by stream.Send
+			return nil
+		}
+		{{- else if $i.ClientStreaming}}
+		func (s {{ToLowerCamel $te.Interface.ServerName}}) {{GoName $i.Name}}(stream pb.{{GoName $te.Interface.Name}}_{{GoName $i.Name}}Server) error {
+			// receive the requests by stream.Recv until io.EOF
+			resp := &{{GoPackageName $i.Response.Name}}.{{GoName $i.Response.Name}}{
+				{{range $j := $i.Response.Fields -}}
+					// {{GoName $j.Name}}:
+				{{end -}}
+			}
+			return stream.SendAndClose(resp)
+		}
+		{{- else}}
+		func (s {{ToLowerCamel $te.Interface.ServerName}}) {{GoName $i.Name}}(in *{{GoPackageName $i.Request.Name}}.{{GoName $i.Request.Name}}, stream pb.{{GoName $te.Interface.Name}}_{{GoName $i.Name}}Server) error {
+			// send the responses by stream.Send
+			return nil
+		}
+		{{- end}}
+	{{end}}
 {{- end}}
 `
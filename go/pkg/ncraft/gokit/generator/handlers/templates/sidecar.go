@@ -0,0 +1,28 @@
+package templates
+
+const SidecarHandlerInterface = `
+type {{ToLowerCamel .Interface.ServerName}} struct{
+	pb.Unimplemented{{GoName .Interface.ServerName}}
+	client pb.{{GoName .Interface.Name}}Client
+}
+
+// NewService returns the sidecar service which forwards the requests to the upstream service.
+// The upstream is configured in configs/upstream.yaml, see upstream.go
+func NewService() pb.{{GoName .Interface.ServerName}} {
+	return {{ToLowerCamel .Interface.ServerName}}{
+		client: UpstreamClient(),
+	}
+}
+
+`
+
+const SidecarHandlerMethods = `
+{{with $te := . }}
+	{{range $i := $te.Interface.Methods}}
+		// {{GoName $i.Name}} implements Interface, forwards the request to the upstream service.
+		func (s {{ToLowerCamel $te.Interface.ServerName}}) {{GoName $i.Name}}(ctx context.Context, in *{{GoPackageName $i.Request.Name}}.{{GoName $i.Request.Name}}) (*{{GoPackageName $i.Response.Name}}.{{GoName $i.Response.Name}}, error){
+			return s.client.{{GoName $i.Name}}(ctx, in)
+		}
+	{{end}}
+{{- end}}
+`
@@ -3,23 +3,39 @@ package httptransport
 import (
 	"bytes"
 	"io"
+	"strings"
 	"text/template"
 
+	"github.com/mojo-lang/mojo/go/pkg/ncraft/compiler"
 	"github.com/mojo-lang/mojo/go/pkg/ncraft/data"
 	_go "github.com/mojo-lang/mojo/go/pkg/ncraft/go"
 	"github.com/mojo-lang/mojo/go/pkg/ncraft/gokit/generator/httptransport/templates"
 	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
+const ClientHttpTransportPath = "pkg/NAME-client/transport_http.go.tmpl"
+
 type ClientHttpTransport struct {
 }
 
-func NewClientHttpTransport(ds data.Service) (*ClientHttpTransport, error) {
+func NewClientHttpTransport(ds *data.Service) (*ClientHttpTransport, error) {
 	for _, method := range ds.Interface.Methods {
 		for _, binding := range method.Bindings {
+			for _, param := range binding.Parameters {
+				if param.Extensions == nil {
+					param.Extensions = make(map[string]interface{})
+				}
+				param.Extensions["ClientGetter"] = clientGetter(param)
+				// the exploded struct is sent by its fields, and the body is not in the query
+				param.Extensions["ClientQuery"] = param.Location == "query" && !param.GetField().Exploded && param != binding.Body
+			}
+
 			if encoder, err := createClientEncode(binding, ds.FuncMap); err != nil {
 				return nil, err
 			} else {
+				if binding.Extensions == nil {
+					binding.Extensions = make(map[string]interface{})
+				}
 				binding.Extensions["ClientEncoder"] = encoder
 			}
 		}
@@ -40,6 +56,15 @@ func (h *ClientHttpTransport) Render(tmpl string, ds *data.Service) (io.Reader,
 	}
 }
 
+// clientGetter returns the nil safe getter chain of the parameter from the request, like `req.GetAddress().GetCity()`
+func clientGetter(param *data.HTTPParameter) string {
+	var getters []string
+	for field := param.GetField(); field != nil; field = field.GetEnclosingField() {
+		getters = append([]string{"Get" + compiler.GoName(field.Name) + "()"}, getters...)
+	}
+	return "req." + strings.Join(getters, ".")
+}
+
 // createClientEncode returns the generated code for the client-side encoding of
 // that clients request struct into the correctly formatted http request.
 func createClientEncode(binding *data.HTTPBinding, funcMap template.FuncMap) (string, error) {
@@ -43,7 +43,7 @@ if req.{{GoName .Field.FullName}} == nil {
     {{if .GetGoType.IsPointer}}
      if err != nil {
         if core.IsNotFoundError(err) {
-            {{ToLowerCamel .Field.FullName}}Initialized {
+            if {{ToLowerCamel .Field.FullName}}Initialized {
                 req.{{GoName .GetField.Name}} = nil
             }
         } else {
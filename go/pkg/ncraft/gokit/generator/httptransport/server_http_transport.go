@@ -20,19 +20,15 @@ type ServerHttpTransport struct {
 
 func NewServerHttpTransport(ds *data.Service) (*ServerHttpTransport, error) {
 	for _, method := range ds.Interface.Methods {
-		for _, binding := range method.Bindings {
-			for _, param := range binding.Parameters {
-				str, err := createParamUnmarshaler(param, ds.FuncMap)
-				if err != nil {
-					return nil, err
-				}
-				param.Go.ParamUnmarshaler = str
-			}
-
-			if serverDecode, err := createServerDecode(binding, ds.FuncMap); err != nil {
+		if err := compileServerBindings(method, ds.FuncMap); err != nil {
+			return nil, err
+		}
+	}
+	// the requests of the client streaming methods are decoded from the body stream, not the http binding
+	for _, method := range ds.Interface.StreamingMethods {
+		if !method.ClientStreaming {
+			if err := compileServerBindings(method, ds.FuncMap); err != nil {
 				return nil, err
-			} else {
-				binding.Extensions["ServerDecode"] = string(serverDecode)
 			}
 		}
 	}
@@ -40,6 +36,26 @@ func NewServerHttpTransport(ds *data.Service) (*ServerHttpTransport, error) {
 	return &ServerHttpTransport{}, nil
 }
 
+// compileServerBindings generates the param unmarshalers and the server decode of the method bindings
+func compileServerBindings(method *data.Method, funcMap template.FuncMap) error {
+	for _, binding := range method.Bindings {
+		for _, param := range binding.Parameters {
+			str, err := createParamUnmarshaler(param, funcMap)
+			if err != nil {
+				return err
+			}
+			param.Go.ParamUnmarshaler = str
+		}
+
+		if serverDecode, err := createServerDecode(binding, funcMap); err != nil {
+			return err
+		} else {
+			binding.Extensions["ServerDecode"] = string(serverDecode)
+		}
+	}
+	return nil
+}
+
 func (h *ServerHttpTransport) Render(tmpl string, ds *data.Service) (io.Reader, error) {
 	code, err := util.ApplyTemplate("ServerTemplate", templates.ServerTemplate, ds, ds.FuncMap)
 	if err != nil {
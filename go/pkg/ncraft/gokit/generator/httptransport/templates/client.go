@@ -8,127 +8,99 @@ var ClientEncodeTemplate = `
 	// that encodes a {{ToLower $binding.Parent.Name}} request into the various portions of
 	// the http request (path, query, and body).
 	func EncodeHTTP{{$binding.Label}}Request(_ context.Context, r *http.Request, request interface{}) error {
-		strval := ""
-		_ = strval
-		req := request.(*{{GoPackageName $binding.Parent.RequestType}}.{{GoName $binding.Parent.RequestType}})
+		req := request.(*{{GoPackageName $binding.Parent.Request.Name}}.{{GoName $binding.Parent.Request.Name}})
 		_ = req
 
 		r.Header.Set("transport", "HTTPJSON")
-		r.Header.Set("request-url", r.URL.Path)
 
 		// Set the path parameters
-		path := strings.Join([]string{
-		{{- range $section := $binding.PathSections}}
-			{{$section}},
+		path := "{{$binding.Path}}"
+		{{- range $param := $binding.Parameters}}
+			{{- if eq $param.Location "path"}}
+				if value, ok := formatParam({{$param.Extensions.ClientGetter}}); ok {
+					path = replacePathParam(path, url.PathEscape(value), "{{$param.Name}}", "{{$param.FullName}}")
+				}
+			{{- end}}
 		{{- end}}
-		}, "/")
-		u, err := url.Parse(path)
-		if err != nil {
-			return errors.Wrapf(err, "couldn't unmarshal path %q", path)
-		}
-		r.URL.RawPath = u.RawPath
-		r.URL.Path = u.Path
+		r.URL.Path = unresolvedPathParams.ReplaceAllString(path, "")
 
 		// Set the query parameters
 		values := r.URL.Query()
-		var tmp []byte
-		_ = tmp
-		{{- range $field := $binding.Fields }}
-			{{- if eq $field.Location "query"}}
-				{{if or (not $field.IsBaseType) $field.Repeated}}
-					tmp, err = json.Marshal(req.{{$field.CamelName}})
-					if err != nil {
-						return errors.Wrap(err, "failed to marshal req.{{$field.CamelName}}")
-					}
-					strval = string(tmp)
-					values.Add("{{$field.ParamName}}", strval)
-				{{else}}
-					values.Add("{{$field.ParamName}}", fmt.Sprint(req.{{$field.CamelName}}))
-				{{- end }}
-			{{- end }}
+		{{- range $param := $binding.Parameters}}
+			{{- if $param.Extensions.ClientQuery}}
+				addQueryParam(values, "{{$param.FullName}}", {{$param.Extensions.ClientGetter}})
+			{{- end}}
 		{{- end}}
-
 		r.URL.RawQuery = values.Encode()
 
 		// Set the body parameters
-		var buf bytes.Buffer
-		toRet := request.(*{{GoPackageName $binding.Parent.RequestType}}.{{GoName $binding.Parent.RequestType}})
-		{{- range $field := $binding.Fields -}}
-			{{if eq $field.Location "body"}}
-				{{/* Only set the fields which should be in the body, so all
-				others will be omitted due to emptiness */}}
-				toRet.{{$field.CamelName}} = req.{{$field.CamelName}}
-			{{end}}
-		{{- end }}
-		encoder := json.NewEncoder(&buf)
-		encoder.SetEscapeHTML(false)
-		if err := encoder.Encode(toRet); err != nil {
-			return errors.Wrapf(err, "couldn't encode body as json %v", toRet)
-		}
-		r.Body = ioutil.NopCloser(&buf)
-		return nil
+		{{- if $binding.Body}}
+			return encodeBody(r, {{$binding.Body.Extensions.ClientGetter}})
+		{{- else if $binding.IsGet}}
+			return nil
+		{{- else}}
+			return encodeBody(r, req)
+		{{- end}}
 	}
 {{- end -}}
 `
 
-var ClientTemplate = `
-// Code generated by ncraft. DO NOT EDIT.
+var ClientTemplate = `// Code generated by ncraft. DO NOT EDIT.
 // Rerunning ncraft will overwrite this file.
 // Version: {{.Version}}
 // Version Date: {{.VersionDate}}
 
-// Package http provides an HTTP client for the {{.Interface.Name}} service.
-package {{ToSnake .Interface.Name}}_client
+package {{ToSnake .Interface.BaredName}}_client
+
+// This file provides client-side bindings for the HTTP transport.
+// It utilizes the transport/http.Client.
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
+	"reflect"
+	"regexp"
 	"strings"
-	"context"
 
-	"github.com/go-kit/kit/endpoint"
-	"github.com/go-kit/kit/transport/http"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/json-iterator/go"
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
 	"github.com/pkg/errors"
 
-	{{range $i := .ExternalMessageImports}}
-	"{{$i}}"
+	{{$corePackage := "github.com/mojo-lang/core/go/pkg/mojo/core"}}
+	{{- range $i := .Go.ImportedTypePaths}}
+	{{if ne $i $corePackage}}"{{$i}}"{{end}}
 	{{- end}}
 
-	// This Interface
-	"{{.RepositoryPath -}}/pkg/{{ToKebab .Interface.Name}}-service/svc"
-	pb "{{.ApiImportPath -}}"
+	// this service api
+	pb "{{.Go.ApiImportPath -}}"
 )
 
-var (
-	_ = endpoint.Chain
-	_ = http.NewClient
-	_ = fmt.Sprint
-	_ = bytes.Compare
-	_ = ioutil.NopCloser
-)
+{{if .HasImported}}
+var ({{range $msg := .ImportedMessages}}
+	_ = {{$msg.Go.PackageName}}.{{$msg.Name}}{}
+{{- end}}{{range $enum := .ImportedEnums}}
+	_ = {{$enum.Go.PackageName}}.{{$enum.Name}}(0)
+{{- end}}){{end}}
 
-// New returns a service backed by an HTTP server living at the remote
+// NewHttp returns a service backed by an HTTP server living at the remote
 // instance. We expect instance to come from a service discovery system, so
 // likely of the form "host:port".
-func NewHttpClient(instance string, options ...ClientOption) (pb.{{.Interface.ServerName}}, error) {
+func NewHttp(instance string, options ...ClientOption) (pb.{{.Interface.ServerName}}, error) {
 	var cc clientConfig
-
 	for _, f := range options {
-		err := f(&cc)
-		if err != nil {
-			return nil, errors.Wrap(err, "cannot apply option") }
+		if err := f(&cc); err != nil {
+			return nil, errors.Wrap(err, "cannot apply option")
+		}
 	}
 
-	{{ if .HTTPHelper.Methods }}
-		clientOptions := []http.ClientOption{
-			http.ClientBefore(
-				contextValuesToHttpHeaders(cc.headers)),
-		}
-	{{ end }}
+	clientOptions := []httptransport.ClientOption{
+		httptransport.ClientBefore(contextValuesToHttpHeaders(cc.headers)),
+	}
 
 	if !strings.HasPrefix(instance, "http") {
 		instance = "http://" + instance
@@ -137,127 +109,146 @@ func NewHttpClient(instance string, options ...ClientOption) (pb.{{.Interface.Se
 	if err != nil {
 		return nil, err
 	}
-	_ = u
 
-	{{if not .HTTPHelper.Methods -}}
-		panic("No HTTP Endpoints, this client will not work, define bindings in your proto definition")
-	{{- end}}
-
-	{{range $method := .HTTPHelper.Methods}}
-		{{ if $method.Bindings -}}
-			{{ with $binding := index $method.Bindings 0 -}}
-				var {{$binding.Label}}Endpoint endpoint.Endpoint
-				{
-					{{$binding.Label}}Endpoint = http.NewClient(
-						"{{$binding.Verb | ToUpper}}",
-						copyURL(u, "{{$binding.BasePath}}"),
-						EncodeHTTP{{$binding.Label}}Request,
-						DecodeHTTP{{$method.Name}}Response,
-						clientOptions...,
-					).Endpoint()
-				}
-			{{- end}}
-		{{- end}}
-	{{- end}}
-
-	return svc.Endpoints{
-	{{range $method := .HTTPHelper.Methods -}}
-		{{ if $method.Bindings -}}
-			{{ with $binding := index $method.Bindings 0 -}}
-				{{ToCamel $method.Name}}Endpoint:    {{$binding.Label}}Endpoint,
-			{{end}}
+	endpoints := Endpoints{}
+	{{- range $method := .Interface.Methods}}
+		{{- with $binding := $method.GetFirstBinding}}
+			endpoints.{{ToCamel $method.Name}}Endpoint = httptransport.NewClient(
+				"{{$binding.Verb | ToUpper}}",
+				copyURL(u),
+				EncodeHTTP{{$binding.Label}}Request,
+				DecodeHTTP{{ToCamel $method.Name}}Response,
+				clientOptions...,
+			).Endpoint()
+		{{- else}}
+			endpoints.{{ToCamel $method.Name}}Endpoint = func(context.Context, interface{}) (interface{}, error) {
+				return nil, errors.New("the method {{$method.Name}} has no http binding")
+			}
 		{{- end}}
 	{{- end}}
-	}, nil
+	return endpoints, nil
 }
 
-func copyURL(base *url.URL, path string) *url.URL {
+func copyURL(base *url.URL) *url.URL {
 	next := *base
-	next.Path = path
 	return &next
 }
 
-type clientConfig struct {
-	headers []string
-}
-
-// ClientOption is a function that modifies the client config
-type ClientOption func(*clientConfig) error
-
-// CtxValuesToSend configures the http client to pull the specified keys out of
-// the context and add them to the http request as headers.  Note that keys
-// will have net/http.CanonicalHeaderKey called on them before being send over
-// the wire and that is the form they will be available in the server context.
-func CtxValuesToSend(keys ...string) ClientOption {
-	return func(o *clientConfig) error {
-		o.headers = keys
-		return nil
-	}
-}
-
-func contextValuesToHttpHeaders(keys []string) http.RequestFunc {
+func contextValuesToHttpHeaders(keys []string) httptransport.RequestFunc {
 	return func(ctx context.Context, r *http.Request) context.Context {
 		for _, k := range keys {
 			if v, ok := ctx.Value(k).(string); ok {
 				r.Header.Set(k, v)
 			}
 		}
-
 		return ctx
 	}
 }
 
 // HTTP Client Decode
-{{range $method := .HTTPHelper.Methods}}
-	// DecodeHTTP{{$method.Name}}Response is a transport/http.DecodeResponseFunc that decodes
-	// a JSON-encoded {{GoName $method.Response}} response from the HTTP response body.
-	// If the response has a non-200 status code, we will interpret that as an
-	// error and attempt to decode the specific error message from the response
-	// body. Primarily useful in a client.
+{{range $method := .Interface.Methods}}
+	// DecodeHTTP{{ToCamel $method.Name}}Response is a transport/http.DecodeResponseFunc that decodes
+	// a JSON-encoded {{GoName $method.Response.Name}} response from the HTTP response body.
+	// If the response has a non-2xx status code, the error in the response body will be returned.
 	func DecodeHTTP{{ToCamel $method.Name}}Response(_ context.Context, r *http.Response) (interface{}, error) {
-		buf, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			return nil, errors.Wrap(err, "cannot read http body")
+		var resp {{GoPackageName $method.Response.Name}}.{{GoName $method.Response.Name}}
+		if err := decodeResponse(r, &resp); err != nil {
+			return nil, err
 		}
-
-		if len(buf) == 0 {
-			return nil, errors.New("response http body empty")
-		}
-
-		if r.StatusCode != http.StatusOK {
-			return nil, errors.Wrapf(errorDecoder(buf), "status code: '%d'", r.StatusCode)
-		}
-
-		var resp {{GoPackageName $method.Response}}.{{GoName $method.Response}}
-		if err = json.Unmarshal(buf, &resp); err != nil {
-			return nil, errorDecoder(buf)
-		}
-
 		return &resp, nil
 	}
 {{end}}
 
 // HTTP Client Encode
-{{range $method := .HTTPHelper.Methods}}
+{{range $method := .Interface.Methods}}
 	{{range $binding := $method.Bindings}}
-		{{$binding.GenClientEncode}}
+		{{$binding.Extensions.ClientEncoder}}
 	{{end}}
 {{end}}
 
-func errorDecoder(buf []byte) error {
-	var w errorWrapper
-	if err := json.Unmarshal(buf, &w); err != nil {
-		const size = 8196
-		if len(buf) > size {
-			buf = buf[:size]
+// the optional path parameters like "{.format}" which are not set
+var unresolvedPathParams = regexp.MustCompile(` + "`" + `\{[^}]*\}` + "`" + `)
+
+func replacePathParam(path string, value string, names ...string) string {
+	for _, name := range names {
+		path = strings.ReplaceAll(path, "{"+name+"}", value)
+	}
+	return path
+}
+
+// formatParam formats the path or query parameter in the same style as the server parsing it,
+// the zero value will be omitted
+func formatParam(value interface{}) (string, bool) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || v.IsZero() {
+		return "", false
+	}
+
+	if formatter, ok := value.(interface{ Format() string }); ok {
+		return formatter.Format(), true
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Struct, reflect.Slice, reflect.Map:
+		bs, err := jsoniter.ConfigFastest.Marshal(value)
+		if err != nil {
+			return "", false
+		}
+		return string(bs), true
+	default:
+		return fmt.Sprint(value), true
+	}
+}
+
+func addQueryParam(values url.Values, name string, value interface{}) {
+	v := reflect.ValueOf(value)
+	if v.IsValid() && v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Ptr {
+		for i := 0; i < v.Len(); i++ {
+			if s, ok := formatParam(v.Index(i).Interface()); ok {
+				values.Add(name, s)
+			}
 		}
-		return fmt.Errorf("response body '%s': cannot parse non-json request body", buf)
+		return
 	}
+	if s, ok := formatParam(value); ok {
+		values.Add(name, s)
+	}
+}
 
-	return errors.New(w.Error)
+func encodeBody(r *http.Request, body interface{}) error {
+	buf, err := jsoniter.ConfigFastest.Marshal(body)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't encode body as json %v", body)
+	}
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	r.Body = io.NopCloser(bytes.NewReader(buf))
+	r.ContentLength = int64(len(buf))
+	return nil
 }
 
-type errorWrapper struct {
-	Error string ` + "`" + `json:"error"` + "`" + `
+func decodeResponse(r *http.Response, resp interface{}) error {
+	buf, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(err, "cannot read http body")
+	}
+
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		e := &core.Error{}
+		if err = jsoniter.ConfigFastest.Unmarshal(buf, e); err != nil || e.Code == nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return core.NewErrorFrom(int32(r.StatusCode), fmt.Sprintf("response body '%s'", buf))
+		}
+		return e
+	}
+
+	if len(buf) == 0 {
+		return nil
+	}
+	if err = jsoniter.ConfigFastest.Unmarshal(buf, resp); err != nil {
+		return errors.Wrap(err, "cannot parse the json response body")
+	}
+	return nil
 }
 `
@@ -107,6 +107,9 @@ import (
 	"github.com/pkg/errors"
 
 	httptransport "github.com/go-kit/kit/transport/http"
+	{{- if .Interface.StreamingMethods}}
+	"google.golang.org/grpc/metadata"
+	{{- end}}
 	pagination "github.com/ncraft-io/ncraft-gokit/pkg/pagination"
 	nhttp "github.com/ncraft-io/ncraft-gokit/pkg/transport/http"
 	stdopentracing "github.com/opentracing/opentracing-go"
@@ -179,8 +182,163 @@ func RegisterHttpHandler(router *mux.Router, endpoints Endpoints, tracer stdopen
 			))
 		{{- end}}
 	{{- end}}
+
+	{{- range $method := .Interface.StreamingMethods}}
+		{{range $binding := $method.Bindings}}
+			router.Methods("{{$binding.Verb | ToUpper}}").Path("{{$binding.Path}}").HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					ctx := queryToContext(headersToContext(r.Context(), r), r)
+					{{- if $method.ClientStreaming}}
+					stream := newHttpServerStream(ctx, w, r, {{if $method.ServerStreaming}}ndjsonContentType{{else}}contentType{{end}})
+					stream.finish(endpoints.Service.{{ToCamel $method.Name}}(http{{ToCamel $method.Name}}Server{stream}), logger)
+					{{- else}}
+					req, err := DecodeHTTP{{$binding.Label}}Request(ctx, r)
+					if err != nil {
+						errorEncoder(ctx, err, w)
+						return
+					}
+					stream := newHttpServerStream(ctx, w, r, eventStreamContentType)
+					stream.finish(endpoints.Service.{{ToCamel $method.Name}}(req.(*{{GoPackageName $method.Request.Name}}.{{GoName $method.Request.Name}}), http{{ToCamel $method.Name}}Server{stream}), logger)
+					{{- end}}
+				})
+		{{- end}}
+	{{- end}}
+}
+{{- if .Interface.StreamingMethods}}
+
+// The streaming methods are mapped to HTTP as:
+//
+//   - server streaming: the request is decoded from the path, query and body as the unary method,
+//     the responses are written as the server-sent events (text/event-stream), one "data:" event per message.
+//   - client streaming: the request body is a chunked stream of newline delimited JSON messages
+//     (application/x-ndjson), the single response is written as the unary method.
+//   - bidirectional streaming: both the request and the response bodies are newline delimited JSON messages,
+//     which needs HTTP/2 to interleave the requests and the responses.
+const (
+	eventStreamContentType = "text/event-stream"
+	ndjsonContentType      = "application/x-ndjson"
+)
+
+// httpServerStream adapts the HTTP request and response to the grpc.ServerStream, so the streaming
+// methods are served by the same service implementation as the gRPC transport
+type httpServerStream struct {
+	ctx         context.Context
+	w           http.ResponseWriter
+	decoder     *jsoniter.Decoder
+	contentType string
+	sent        bool
 }
 
+func newHttpServerStream(ctx context.Context, w http.ResponseWriter, r *http.Request, contentType string) *httpServerStream {
+	return &httpServerStream{
+		ctx:         ctx,
+		w:           w,
+		decoder:     jsoniter.ConfigFastest.NewDecoder(r.Body),
+		contentType: contentType,
+	}
+}
+
+func (s *httpServerStream) SetHeader(md metadata.MD) error {
+	for k, values := range md {
+		for _, v := range values {
+			s.w.Header().Add(k, v)
+		}
+	}
+	return nil
+}
+
+func (s *httpServerStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *httpServerStream) SetTrailer(md metadata.MD) {
+}
+
+func (s *httpServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg writes the message as a server-sent event or a line of the newline delimited JSON, and flushes it
+func (s *httpServerStream) SendMsg(m interface{}) error {
+	if s.contentType == contentType {
+		s.sent = true
+		return EncodeHTTPGenericResponse(s.ctx, s.w, m)
+	}
+
+	buf, err := jsoniter.ConfigFastest.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if !s.sent {
+		s.w.Header().Set("Content-Type", s.contentType)
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.sent = true
+	}
+
+	if s.contentType == eventStreamContentType {
+		buf = append(append([]byte("data: "), buf...), '\n', '\n')
+	} else {
+		buf = append(buf, '\n')
+	}
+	if _, err = s.w.Write(buf); err != nil {
+		return err
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// RecvMsg reads the next newline delimited JSON message from the request body, returns io.EOF at the end
+func (s *httpServerStream) RecvMsg(m interface{}) error {
+	return s.decoder.Decode(m)
+}
+
+// finish writes the error returned by the streaming method, as the error response if nothing was sent,
+// otherwise as the last "error" event of the server-sent events
+func (s *httpServerStream) finish(err error, logger log.Logger) {
+	if err == nil {
+		return
+	}
+	if !s.sent {
+		errorEncoder(s.ctx, err, s.w)
+		return
+	}
+
+	logger.Log("error", err)
+	if s.contentType == eventStreamContentType {
+		if buf, e := jsoniter.ConfigFastest.Marshal(core.NewErrorFrom(500, err.Error())); e == nil {
+			s.w.Write(append(append([]byte("event: error\ndata: "), buf...), '\n', '\n'))
+		}
+	}
+}
+{{range $method := .Interface.StreamingMethods}}
+// http{{ToCamel $method.Name}}Server implements the pb.{{GoName $.Interface.Name}}_{{ToCamel $method.Name}}Server over HTTP
+type http{{ToCamel $method.Name}}Server struct {
+	*httpServerStream
+}
+{{if $method.ServerStreaming}}
+func (s http{{ToCamel $method.Name}}Server) Send(m *{{GoPackageName $method.Response.Name}}.{{GoName $method.Response.Name}}) error {
+	return s.SendMsg(m)
+}
+{{- else}}
+func (s http{{ToCamel $method.Name}}Server) SendAndClose(m *{{GoPackageName $method.Response.Name}}.{{GoName $method.Response.Name}}) error {
+	return s.SendMsg(m)
+}
+{{- end}}
+{{if $method.ClientStreaming}}
+func (s http{{ToCamel $method.Name}}Server) Recv() (*{{GoPackageName $method.Request.Name}}.{{GoName $method.Request.Name}}, error) {
+	m := new({{GoPackageName $method.Request.Name}}.{{GoName $method.Request.Name}})
+	if err := s.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+{{- end}}
+{{end}}
+{{- end}}
+
 // ErrorEncoder writes the error to the ResponseWriter, by default a content
 // type of application/json, a body of json with key "error" and the value
 // error.Error(), and a status code of 500. If the error implements Headerer,
@@ -261,6 +419,13 @@ func errorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
 		{{$binding.Extensions.ServerDecode}}
 	{{end}}
 {{end}}
+{{range $method := .Interface.StreamingMethods}}
+	{{- if not $method.ClientStreaming}}
+	{{range $binding := $method.Bindings}}
+		{{$binding.Extensions.ServerDecode}}
+	{{end}}
+	{{- end}}
+{{end}}
 
 {{/* {{range $method := .Interface.Methods}}
     {{range $binding := $method.Bindings}}
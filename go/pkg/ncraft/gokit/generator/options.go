@@ -2,6 +2,7 @@ package generator
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"strings"
 
@@ -11,17 +12,14 @@ import (
 
 	"github.com/mojo-lang/mojo/go/pkg/ncraft/data"
 	_go "github.com/mojo-lang/mojo/go/pkg/ncraft/go"
+	"github.com/mojo-lang/mojo/go/pkg/ncraft/gokit/generator/client"
 	"github.com/mojo-lang/mojo/go/pkg/ncraft/gokit/generator/handlers"
 	"github.com/mojo-lang/mojo/go/pkg/ncraft/gokit/generator/httptransport"
 	"github.com/mojo-lang/mojo/go/pkg/ncraft/gokit/generator/templates"
 	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
-type HandlerTemplate struct {
-	Interface string
-	Methods   string
-	Extension string
-}
+type HandlerTemplate = handlers.Template
 
 type Options struct {
 	Repository    string // the repository for the generated gokit service
@@ -43,8 +41,30 @@ func (o *Options) SyncTo(ds *data.Service) {
 	ds.Go.ApiRepositoryPath = o.ApiRepository
 }
 
+// checkStreamingMethods the client and the sidecar only support the unary methods for now,
+// so fail the generation instead of leaving the streaming methods out silently
+func checkStreamingMethods(ds *data.Service, kind string) error {
+	if ds.Interface == nil || len(ds.Interface.StreamingMethods) == 0 {
+		return nil
+	}
+
+	var names []string
+	for _, method := range ds.Interface.StreamingMethods {
+		names = append(names, method.Name)
+	}
+	return fmt.Errorf("the ncraft %s of the service %s does not support the streaming methods: %s",
+		kind, ds.Interface.Name, strings.Join(names, ", "))
+}
+
 func (o *Options) GenerateClient(ds *data.Service) ([]*util.GeneratedFile, error) {
+	if err := checkStreamingMethods(ds, "client"); err != nil {
+		return nil, err
+	}
 	o.SyncTo(ds)
+	if ds.Extensions == nil {
+		ds.Extensions = make(map[string]interface{})
+	}
+	ds.Extensions["ClientArguments"] = client.NewClientArguments(ds.Interface)
 	return o.generateTemplatedFiles(ds, templates.ClientNames(), templates.Client)
 }
 
@@ -53,20 +73,49 @@ func (o *Options) GenerateService(ds *data.Service) ([]*util.GeneratedFile, erro
 	return o.generateTemplatedFiles(ds, templates.ServiceNames(), templates.Service)
 }
 
-// GenerateTemplatedFiles generate the service or client files
-func (o *Options) generateTemplatedFiles(ds *data.Service, tmplPaths []string, getter templates.FileGetter) ([]*util.GeneratedFile, error) {
-	if tmpl := o.HandlerTemplate; tmpl != nil {
-		if len(tmpl.Interface) > 0 {
-			handlers.ResetHandlerInterface(tmpl.Interface)
-		}
-		if len(tmpl.Methods) > 0 {
-			handlers.ResetHandlerMethods(tmpl.Methods)
+// GenerateSidecar generate the service files with the handlers forwarding to the upstream service,
+// and the sidecar files which will override the service ones with the same name
+func (o *Options) GenerateSidecar(ds *data.Service) ([]*util.GeneratedFile, error) {
+	if err := checkStreamingMethods(ds, "sidecar"); err != nil {
+		return nil, err
+	}
+	o.SyncTo(ds)
+
+	sidecar := *o
+	if sidecar.HandlerTemplate == nil {
+		sidecar.HandlerTemplate = handlers.SidecarTemplate
+	}
+	// the sidecar has no storage, so the models are not needed
+	var serviceNames []string
+	for _, name := range templates.ServiceNames() {
+		if !strings.HasPrefix(name, "internal/model/") {
+			serviceNames = append(serviceNames, name)
 		}
-		if len(tmpl.Extension) > 0 {
-			handlers.ResetHandlerExtension(tmpl.Extension)
+	}
+	files, err := sidecar.generateTemplatedFiles(ds, serviceNames, templates.Service)
+	if err != nil {
+		return nil, err
+	}
+	sidecarFiles, err := sidecar.generateTemplatedFiles(ds, templates.SidecarNames(), templates.Sidecar)
+	if err != nil {
+		return nil, err
+	}
+
+	overridden := make(map[string]bool)
+	for _, file := range sidecarFiles {
+		overridden[file.Name] = true
+	}
+	var generated []*util.GeneratedFile
+	for _, file := range files {
+		if !overridden[file.Name] {
+			generated = append(generated, file)
 		}
 	}
+	return append(generated, sidecarFiles...), nil
+}
 
+// GenerateTemplatedFiles generate the service or client files
+func (o *Options) generateTemplatedFiles(ds *data.Service, tmplPaths []string, getter templates.FileGetter) ([]*util.GeneratedFile, error) {
 	var codeGenFiles util.GeneratedFiles
 
 	// Remove the suffix "-service" since it's added back in by templatePathToActual
@@ -88,7 +137,7 @@ func (o *Options) generateTemplatedFiles(ds *data.Service, tmplPaths []string, g
 		// Re-derive the actual path for this file based on the service output
 		// path provided by the ncraft main.go
 		actualPath := templatePathToActual(tmplPath, ds.Go.PackageName, svcName)
-		file, err := generateTemplateFile(tmplPath, actualPath, ds, o.PreviousFiles[actualPath], getter)
+		file, err := o.generateTemplateFile(tmplPath, actualPath, ds, o.PreviousFiles[actualPath], getter)
 		if err != nil {
 			return nil, logs.NewErrorw("cannot render templates", "error", err.Error())
 		}
@@ -105,13 +154,13 @@ func (o *Options) generateTemplatedFiles(ds *data.Service, tmplPaths []string, g
 }
 
 // generateTemplateFile
-func generateTemplateFile(tmplPath string, actualPath string, ds *data.Service, prevFile io.Reader, getter templates.FileGetter) (io.Reader, error) {
+func (o *Options) generateTemplateFile(tmplPath string, actualPath string, ds *data.Service, prevFile io.Reader, getter templates.FileGetter) (io.Reader, error) {
 	var genCode io.Reader
 	var err error
 
 	switch tmplPath {
 	case handlers.ServerHandlerPath:
-		h, err := handlers.New(ds.Interface, prevFile)
+		h, err := handlers.NewWithTemplate(ds.Interface, prevFile, o.HandlerTemplate)
 		if err != nil {
 			return nil, errors.Wrapf(err, "cannot parse previous handler: %q", actualPath)
 		}
@@ -138,6 +187,14 @@ func generateTemplateFile(tmplPath string, actualPath string, ds *data.Service,
 		if genCode, err = transport.Render(tmplPath, ds); err != nil {
 			return nil, errors.Wrapf(err, "cannot render templates: %s", tmplPath)
 		}
+	case httptransport.ClientHttpTransportPath:
+		transport, err := httptransport.NewClientHttpTransport(ds)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create the client http transport")
+		}
+		if genCode, err = transport.Render(tmplPath, ds); err != nil {
+			return nil, errors.Wrapf(err, "cannot render templates: %s", tmplPath)
+		}
 	default:
 		if genCode, err = applyTemplateFromPath(tmplPath, ds, getter); err != nil {
 			return nil, errors.Wrapf(err, "cannot render templates: %s", tmplPath)
@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
+	"github.com/mojo-lang/mojo/go/pkg/ncraft/compiler"
+	"github.com/mojo-lang/mojo/go/pkg/ncraft/data"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+func compileServices(t *testing.T) []*data.Service {
+	return compileServicesFrom(t, "../../testdata/mojo-ncraft")
+}
+
+func compileServicesFrom(t *testing.T, path string) []*data.Service {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), path)
+	assert.NoError(t, err)
+	if pkg == nil {
+		t.FailNow()
+	}
+
+	options := make(core.Options)
+	for _, p := range pkg.GetAllPackages() {
+		options[p.FullName] = p.GetGoPackageImport()
+	}
+	for _, p := range pkg.GetAllDependentPackages() {
+		options[p.FullName] = p.GetGoPackageImport()
+	}
+	services, err := compiler.CompilePackage(compiler.WithGoPackageImports(context.Empty(), options), pkg)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, services)
+	return services
+}
+
+func newTestOptions(repository string) *Options {
+	return &Options{
+		Repository:    repository,
+		ApiRepository: "github.com/mojo-lang/test/go",
+		MixedInAPI:    true,
+	}
+}
+
+// readGeneratedFiles read the contents of the generated files by the names,
+// and check no file is generated twice, and all the non-empty go files are valid
+func readGeneratedFiles(t *testing.T, files []*util.GeneratedFile) map[string]string {
+	contents := make(map[string]string)
+	for _, file := range files {
+		_, ok := contents[file.Name]
+		assert.False(t, ok, "duplicated file %s", file.Name)
+
+		bs, err := io.ReadAll(file.Reader)
+		assert.NoError(t, err)
+		contents[file.Name] = string(bs)
+
+		// the entity model files are empty if no entity in the package
+		if strings.HasSuffix(file.Name, ".go") && len(strings.TrimSpace(string(bs))) > 0 {
+			_, err = parser.ParseFile(token.NewFileSet(), file.Name, bs, parser.AllErrors)
+			assert.NoError(t, err, file.Name)
+		}
+	}
+	return contents
+}
+
+func TestOptions_GenerateClient(t *testing.T) {
+	services := compileServices(t)
+	if len(services) == 0 {
+		return
+	}
+
+	files, err := newTestOptions("github.com/mojo-lang/test/service-go").GenerateClient(services[0])
+	assert.NoError(t, err)
+	contents := readGeneratedFiles(t, files)
+
+	assert.Contains(t, contents["go.mod"], "module github.com/mojo-lang/test/service-go")
+	assert.Contains(t, contents["go.mod"], "github.com/mojo-lang/test/go => ../go")
+
+	http := contents["pkg/geocoding-client/transport_http.go"]
+	assert.Contains(t, http, "package geocoding_client")
+	assert.Contains(t, http, `path := "/address/{address}{_format}"`)
+	assert.Contains(t, http, "formatParam(req.GetAddress())")
+	assert.Contains(t, http, "func NewHttp(instance string, options ...ClientOption) (pb.GeocodingServer, error)")
+
+	grpc := contents["pkg/geocoding-client/transport_grpc.go"]
+	assert.Contains(t, grpc, `"ncraft.v1.Geocoding"`)
+	assert.Contains(t, grpc, `"ReverseGeocode"`)
+
+	cli := contents["cmd/geocoding-cli/main.go"]
+	assert.Contains(t, cli, `cmds["reverse-geocode"]`)
+	assert.Contains(t, cli, `fsReverseGeocode.Bool("nearby", false,`)
+	assert.Contains(t, cli, `fsReverseGeocode.Int64("nearby-radius", 0,`)
+	assert.Contains(t, cli, `{name: "nearbyRadius", json: false}`)
+	assert.Contains(t, cli, `{name: "location", json: true}`)
+}
+
+func TestOptions_GenerateSidecar(t *testing.T) {
+	services := compileServices(t)
+	if len(services) == 0 {
+		return
+	}
+
+	files, err := newTestOptions("github.com/mojo-lang/test/sidecar-go").GenerateSidecar(services[0])
+	assert.NoError(t, err)
+	contents := readGeneratedFiles(t, files)
+
+	assert.Contains(t, contents["configs/upstream.yaml"], "address: localhost:20172")
+	assert.Contains(t, contents["go.mod"], "module github.com/mojo-lang/test/sidecar-go")
+
+	h := contents["pkg/geocoding-service/handlers/handlers.go"]
+	assert.Contains(t, h, "client pb.GeocodingClient")
+	assert.Contains(t, h, "return s.client.ReverseGeocode(ctx, in)")
+	assert.NotContains(t, h, "NewUserClient")
+
+	assert.Contains(t, contents["pkg/geocoding-service/handlers/upstream.go"], "pb.NewGeocodingClient(conn)")
+
+	test := contents["pkg/geocoding-service/handlers/handlers_test.go"]
+	assert.Contains(t, test, "pb.RegisterGeocodingServer(server, fake)")
+	assert.Contains(t, test, "calls != 2")
+}
+
+func TestOptions_GenerateService_Stream(t *testing.T) {
+	services := compileServicesFrom(t, "../../../mojo/testdata/mojo-stream")
+	if len(services) == 0 {
+		return
+	}
+
+	files, err := newTestOptions("github.com/mojo-lang/test/service-go").GenerateService(services[0])
+	assert.NoError(t, err)
+	contents := readGeneratedFiles(t, files)
+
+	endpoints := contents["pkg/chat-service/svc/endpoints.go"]
+	assert.Contains(t, endpoints, "GetMessageEndpoint")
+	assert.NotContains(t, endpoints, "WatchEndpoint")
+	assert.Contains(t, endpoints, "Service pb.ChatServer")
+
+	grpc := contents["pkg/chat-service/svc/transport_grpc.go"]
+	assert.Contains(t, grpc, "func (s *grpcServer) Upload(stream pb.Chat_UploadServer) error")
+	assert.Contains(t, grpc, "func (s *grpcServer) Watch(req *pb.WatchRequest, stream pb.Chat_WatchServer) error")
+	assert.Contains(t, grpc, "func (s *grpcServer) Chat(stream pb.Chat_ChatServer) error")
+
+	http := contents["pkg/chat-service/svc/transport_http.go"]
+	assert.Contains(t, http, "stream := newHttpServerStream(ctx, w, r, eventStreamContentType)")
+	assert.Contains(t, http, "func DecodeHTTPWatchZeroRequest(")
+	assert.NotContains(t, http, "func DecodeHTTPUploadZeroRequest(")
+	assert.Contains(t, http, "func (s httpUploadServer) SendAndClose(m *pb.UploadSummary) error")
+	assert.Contains(t, http, "func (s httpChatServer) Recv() (*pb.ChatRequest, error)")
+
+	h := contents["pkg/chat-service/handlers/handlers.go"]
+	assert.Contains(t, h, "func (s chatServer) Watch(in *pb.WatchRequest, stream pb.Chat_WatchServer) error")
+	assert.Contains(t, h, "return stream.SendAndClose(resp)")
+}
+
+func TestOptions_GenerateClient_Stream(t *testing.T) {
+	services := compileServicesFrom(t, "../../../mojo/testdata/mojo-stream")
+	if len(services) == 0 {
+		return
+	}
+
+	_, err := newTestOptions("github.com/mojo-lang/test/service-go").GenerateClient(services[0])
+	assert.ErrorContains(t, err, "does not support the streaming methods: upload, watch, chat")
+
+	_, err = newTestOptions("github.com/mojo-lang/test/sidecar-go").GenerateSidecar(services[0])
+	assert.ErrorContains(t, err, "ncraft sidecar")
+}
@@ -11,7 +11,7 @@ import (
 //go:embed service-go/*
 var services embed.FS
 
-//go:embed NAME-client/*
+//go:embed client-go/*
 var clients embed.FS
 
 //go:embed sidecar/*
@@ -24,11 +24,11 @@ func Service(path string) ([]byte, error) {
 }
 
 func Client(path string) ([]byte, error) {
-	return FileContent(clients, path, "")
+	return FileContent(clients, path, "client-go/")
 }
 
 func Sidecar(path string) ([]byte, error) {
-	return FileContent(clients, path, "")
+	return FileContent(sidecars, path, "sidecar/")
 }
 
 func ServiceNames() []string {
@@ -36,11 +36,11 @@ func ServiceNames() []string {
 }
 
 func ClientNames() []string {
-	return FileNames(clients, "")
+	return FileNames(clients, "client-go/")
 }
 
 func SidecarNames() []string {
-	return FileNames(sidecars, "")
+	return FileNames(sidecars, "sidecar/")
 }
 
 func FileContent(fs embed.FS, path string, prefix string) ([]byte, error) {
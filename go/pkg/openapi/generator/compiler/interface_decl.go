@@ -243,7 +243,7 @@ func CompileParameters(ctx context.Context, method *lang.FunctionDecl, httpMetho
 
 	params := method.Signature.GetParameters()
 	if pagination, _ := lang.GetBoolAttribute(method.Attributes, core.PaginationAttributeName); pagination {
-		params = append(params, langcompiler.PaginationRequestFields()...)
+		params = append(params, langcompiler.PaginationRequestFieldsOf(method)...)
 	}
 	if query, _ := lang.GetBoolAttribute(method.Attributes, core.QueryAttributeName); query {
 		params = append(params, langcompiler.QueryRequestFields()...)
@@ -85,6 +85,12 @@ func compileNominalType(ctx context.Context, nominalType *lang.NominalType) (*op
 		if nominalType.Name != "Int" && nominalType.Name != "UInt" {
 			schema.Format = nominalType.Name
 		}
+		if val, err := lang.GetIntegerAttribute(nominalType.Attributes, core.MinimumAttributeName); err == nil {
+			schema.Minimum = core.NewInt64Value(val)
+		}
+		if val, err := lang.GetIntegerAttribute(nominalType.Attributes, core.MaximumLengthAttributeName); err == nil {
+			schema.Maximum = core.NewInt64Value(val)
+		}
 	case core.PositiveTypeFullName:
 		schema.Type = openapi.Schema_TYPE_INTEGER
 		schema.Format = core.PositiveTypeName
@@ -96,6 +96,10 @@ func (g *Generator) cleanFiles() error {
 func (g *Generator) writeFiles(dir string) error {
 	guard := &util2.PathGuard{
 		Suffixes: []string{".yaml", ".schema.json"},
+		Target:   "openapi",
+	}
+	if err := guard.Record(dir); err != nil {
+		return err
 	}
 
 	for _, file := range g.Files {
@@ -0,0 +1,61 @@
+package plugin
+
+// the artifacts provided by the builtin plugins, which could be required by other plugins
+const (
+	// ResolvedDependencies all the dependencies of the package are resolved (mpm)
+	ResolvedDependencies = "resolved-dependencies"
+
+	// SyntaxTree the sources are parsed to the syntax tree (syntax)
+	SyntaxTree = "syntax-tree"
+
+	// GlobalPackage all the packages are linked in a global package tree (semantic.global-maker)
+	GlobalPackage = "global-package"
+
+	// DeclaredIdentifiers all the declarations are declared in the scopes (semantic.identifier-namer)
+	DeclaredIdentifiers = "declared-identifiers"
+
+	// ResolvedIdentifiers all the identifiers are resolved to the declarations (semantic.identifier-resolver)
+	ResolvedIdentifiers = "resolved-identifiers"
+
+	// CircleDependencies the circular dependent source files are grouped (semantic.circle-resolver)
+	CircleDependencies = "circle-dependencies"
+
+	// SemanticTree the semantic analysis is finished, and the global package tree is removed (semantic.global-remover)
+	SemanticTree = "semantic-tree"
+
+	// ResolvedTypeAliases the nominal type aliases are resolved, except the generic ones (compiler.type-alias)
+	ResolvedTypeAliases = "resolved-type-aliases"
+
+	// IrregularCaseRules the irregular case rules are applied to the case conversions (compiler.irregular-case-rule)
+	IrregularCaseRules = "irregular-case-rules"
+
+	// InstantiatedGenerics the generic types are instantiated to the nominal types (compiler.generic)
+	InstantiatedGenerics = "instantiated-generics"
+
+	// RenamedGenerics the instantiated generic types are renamed (compiler.generic-renaming)
+	RenamedGenerics = "renamed-generics"
+
+	// ResolvedGenericAliases the type aliases of the instantiated generic types are resolved (compiler.type-alias-2)
+	ResolvedGenericAliases = "resolved-generic-aliases"
+
+	// CircleSourceFiles the circular dependent source files are merged (compiler.circle)
+	CircleSourceFiles = "circle-source-files"
+
+	// GoPackageNames the go package names are set to the structs (convert.go-package-name)
+	GoPackageNames = "go-package-names"
+
+	// LabelFormats the labels of the union and tuple are formatted (compiler.label-format)
+	LabelFormats = "label-formats"
+
+	// PaginationMethods the list methods are marked with `@pagination` (compiler.pagination)
+	PaginationMethods = "pagination-methods"
+
+	// QueryMethods the list methods are marked with `@query` (compiler.query)
+	QueryMethods = "query-methods"
+
+	// EntityGraph the entity nodes and edges are compiled (compiler.entity)
+	EntityGraph = "entity-graph"
+
+	// MethodRequestTypes the request types of the methods are generated (compiler.method-request-type)
+	MethodRequestTypes = "method-request-types"
+)
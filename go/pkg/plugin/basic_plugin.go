@@ -1,16 +1,38 @@
 package plugin
 
-import "github.com/mojo-lang/core/go/pkg/mojo/core"
+import (
+	"sync"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+)
 
 type BasicPlugin struct {
-	Name          string
-	Group         string
+	Name  string
+	Group string
+
+	// Provides the artifacts provided by the plugin, like ResolvedIdentifiers
+	Provides []string
+	// Requires the artifacts must be provided by the plugins before it, it's an error if missing
+	Requires []string
+	// OptionalRequires the artifacts should be provided before it, only if there is any plugin providing them
+	OptionalRequires []string
+
+	// GroupPriority and Priority only order the plugins without dependency between them
 	GroupPriority int
 	Priority      int
 
+	// SupportedOptions the keys of the options the plugin accepts, besides the `enabled`,
+	// the others set in the mojo.yaml or the command line are rejected
+	SupportedOptions []string
+
+	// Concurrent the plugin has no shared state between the packages and the source files,
+	// so they will be processed concurrently when using the default ParsePackage and CompilePackage.
+	Concurrent bool
+
 	Creator func(options core.Options) Plugin
 
 	MarkedPackages map[string]bool
+	markedMutex    sync.Mutex
 }
 
 func (p *BasicPlugin) GetName() string {
@@ -29,6 +51,41 @@ func (p *BasicPlugin) GetGroupPriority() int {
 	return p.GroupPriority
 }
 
+func (p *BasicPlugin) GetProvides() []string {
+	return p.Provides
+}
+
+func (p *BasicPlugin) GetRequires() []string {
+	return p.Requires
+}
+
+func (p *BasicPlugin) GetOptionalRequires() []string {
+	return p.OptionalRequires
+}
+
+func (p *BasicPlugin) GetSupportedOptions() []string {
+	return p.SupportedOptions
+}
+
+func (p *BasicPlugin) IsConcurrent() bool {
+	return p.Concurrent
+}
+
+// MarkPackage mark the package in the MarkedPackages, returns true if the package is marked first time
+func (p *BasicPlugin) MarkPackage(pkgName string) bool {
+	p.markedMutex.Lock()
+	defer p.markedMutex.Unlock()
+
+	if p.MarkedPackages[pkgName] {
+		return false
+	}
+	if p.MarkedPackages == nil {
+		p.MarkedPackages = make(map[string]bool)
+	}
+	p.MarkedPackages[pkgName] = true
+	return true
+}
+
 func (p *BasicPlugin) Create(options core.Options) Plugin {
 	if p.Creator != nil {
 		return p.Creator(options)
@@ -55,18 +55,22 @@ func CompilePackage(p interface{}, ctx context.Context, pkg *lang.Package) error
 	}
 
 	thisCtx := context.WithType(ctx, pkg)
-	for _, file := range pkg.SourceFiles {
+	err := eachSourceFile(p, pkg, func(file *lang.SourceFile) error {
 		if err := CompileSourceFile(p, thisCtx, file); err != nil && !core.IsSkipError(err) {
 			return err
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	for _, child := range pkg.Children {
+	return eachChild(p, pkg, func(child *lang.Package) error {
 		if err := CompilePackage(p, thisCtx, child); err != nil && !core.IsSkipError(err) {
 			return err
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 func CompileSourceFile(p interface{}, ctx context.Context, file *lang.SourceFile) error {
@@ -1 +1,43 @@
 package plugin
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+type sourceFileCompiler struct {
+	BasicPlugin
+
+	mutex sync.Mutex
+	files []string
+}
+
+func (c *sourceFileCompiler) CompileSourceFile(ctx context.Context, file *lang.SourceFile) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.files = append(c.files, file.Name)
+	return nil
+}
+
+func TestCompilePackage_Concurrent(t *testing.T) {
+	pkg := &lang.Package{FullName: "foo"}
+	var names []string
+	for i := 0; i < 4; i++ {
+		child := &lang.Package{FullName: "foo.bar" + string(rune('a'+i))}
+		for j := 0; j < 8; j++ {
+			name := child.FullName + string(rune('a'+j)) + ".mojo"
+			child.SourceFiles = append(child.SourceFiles, &lang.SourceFile{Name: name})
+			names = append(names, name)
+		}
+		pkg.Children = append(pkg.Children, child)
+	}
+
+	compiler := &sourceFileCompiler{BasicPlugin: BasicPlugin{Concurrent: true}}
+	assert.NoError(t, CompilePackage(compiler, context.Empty(), pkg))
+	assert.ElementsMatch(t, names, compiler.files)
+}
@@ -0,0 +1,108 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+// GeneratorPrefix the executable name prefix of the generator plugins, `mojo-gen-<target>`
+const GeneratorPrefix = "mojo-gen-"
+
+// Generator the out-of-process generator plugin like the protoc plugins,
+// which is an executable reading the Request from stdin and writing the Response to stdout.
+type Generator struct {
+	Target string
+
+	// the executable path of the plugin
+	Path string
+}
+
+// LookupGenerator find the `mojo-gen-<target>` executable in the PATH
+func LookupGenerator(target string) (*Generator, error) {
+	p, err := exec.LookPath(GeneratorPrefix + target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the generator plugin of the target %s: %w", target, err)
+	}
+	return &Generator{Target: target, Path: p}, nil
+}
+
+func (g *Generator) Generate(ctx context.Context, req *Request) (util.GeneratedFiles, error) {
+	if len(req.Target) == 0 {
+		req.Target = g.Target
+	}
+	input, err := req.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	logs.Infow("begin to run the generator plugin", "target", g.Target, "path", g.Path)
+
+	output := &bytes.Buffer{}
+	cmd := exec.Command(g.Path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = output
+	cmd.Stderr = os.Stderr
+	span, _ := util.BeginSpan(ctx, util.ProcessSpan, filepath.Base(g.Path))
+	err = cmd.Run()
+	span.End()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run the generator plugin %s: %w", g.Path, err)
+	}
+
+	resp, err := DecodeResponse(output.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the response of the generator plugin %s: %w", g.Path, err)
+	}
+	if err = resp.GetError(); err != nil {
+		return nil, fmt.Errorf("the generator plugin %s failed: %w", g.Path, err)
+	}
+	return resp.Files, nil
+}
+
+// Run the main function of the generator plugin, reads the Request from stdin,
+// then writes the files generated by the fn (or the error) to stdout
+func Run(fn func(req *Request) (util.GeneratedFiles, error)) {
+	if err := run(os.Stdin, os.Stdout, fn); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[0], err)
+		os.Exit(1)
+	}
+}
+
+func run(in io.Reader, out io.Writer, fn func(req *Request) (util.GeneratedFiles, error)) error {
+	input, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	req, err := DecodeRequest(input)
+	if err != nil {
+		return err
+	}
+
+	resp := &Response{}
+	if resp.Files, err = fn(req); err != nil {
+		resp.Error = err.Error()
+		resp.Files = nil
+	}
+
+	for _, f := range resp.Files {
+		if f.Reader != nil && len(f.Content) == 0 {
+			content, err := io.ReadAll(f.Reader)
+			if err != nil {
+				return err
+			}
+			f.Content = string(content)
+		}
+	}
+
+	_, err = out.Write(resp.Encode())
+	return err
+}
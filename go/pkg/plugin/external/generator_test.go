@@ -0,0 +1,188 @@
+package external
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf3/parser/syntax"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+const generatorEnv = "MOJO_TEST_GENERATOR"
+
+// TestMain the test binary acts as the generator plugin when the env is set
+func TestMain(m *testing.M) {
+	if os.Getenv(generatorEnv) == "1" {
+		Run(generateEcho)
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func generateEcho(req *Request) (util.GeneratedFiles, error) {
+	if req.Options["fail"] == "true" {
+		return nil, errors.New("failed on purpose")
+	}
+	return util.GeneratedFiles{{
+		Name:    req.Target + "/" + req.Package.Name + ".txt",
+		Content: req.Package.FullName + ":" + req.Options["suffix"],
+	}}, nil
+}
+
+func TestRequest_Encode(t *testing.T) {
+	req := &Request{
+		Target:  "echo",
+		Package: &lang.Package{Name: "foo", FullName: "mojo.foo"},
+		Options: map[string]string{"a": "1", "b": ""},
+	}
+	b, err := req.Encode()
+	assert.NoError(t, err)
+
+	decoded, err := DecodeRequest(b)
+	assert.NoError(t, err)
+	assert.Equal(t, req.Target, decoded.Target)
+	assert.True(t, proto.Equal(req.Package, decoded.Package))
+	assert.Equal(t, req.Options, decoded.Options)
+}
+
+func TestRequest_EncodeCircularReference(t *testing.T) {
+	node := &lang.StructDecl{Name: "Node", Type: &lang.StructType{}}
+	node.Type.Fields = append(node.Type.Fields, &lang.ValueDecl{
+		Name: "next",
+		Type: &lang.NominalType{Name: "Node", TypeDeclaration: lang.NewStructTypeDeclaration(node)},
+	})
+	pkg := &lang.Package{Name: "foo", FullName: "mojo.foo", SourceFiles: []*lang.SourceFile{{
+		Name:       "node.mojo",
+		Statements: []*lang.Statement{lang.NewStructDeclStatement(node)},
+	}}}
+	assert.True(t, util.HasCircularReference(pkg))
+
+	b, err := (&Request{Target: "echo", Package: pkg}).Encode()
+	assert.NoError(t, err)
+	decoded, err := DecodeRequest(b)
+	assert.NoError(t, err)
+
+	// the back reference is dropped, and the package itself is untouched
+	next := decoded.Package.SourceFiles[0].Statements[0].GetDeclaration().GetStructDecl().Type.Fields[0].Type
+	assert.Equal(t, "Node", next.Name)
+	assert.Nil(t, next.TypeDeclaration.GetStructDecl())
+	assert.NotNil(t, node.Type.Fields[0].Type.TypeDeclaration.GetStructDecl())
+}
+
+func TestResponse_DecodeInvalidName(t *testing.T) {
+	for _, name := range []string{"../x.txt", "a/../../x.txt", "/etc/x.txt", ""} {
+		resp := &Response{Files: util.GeneratedFiles{{Name: name, Content: "x"}}}
+		_, err := DecodeResponse(resp.Encode())
+		assert.Error(t, err, name)
+	}
+
+	decoded, err := DecodeResponse((&Response{Files: util.GeneratedFiles{{Name: "a/./b/../c.txt", Content: "c"}}}).Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(decoded.Files))
+}
+
+func TestResponse_Encode(t *testing.T) {
+	resp := &Response{Files: util.GeneratedFiles{
+		{Name: "a.txt", Content: "a", SkipIfExist: true},
+		{Name: "b.txt", Content: "", SkipIfUserCodeMixed: true},
+	}}
+
+	decoded, err := DecodeResponse(resp.Encode())
+	assert.NoError(t, err)
+	assert.NoError(t, decoded.GetError())
+	assert.Equal(t, resp.Files, decoded.Files)
+
+	decoded, err = DecodeResponse((&Response{Error: "failed"}).Encode())
+	assert.NoError(t, err)
+	assert.EqualError(t, decoded.GetError(), "failed")
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the generator is linked by a shell script")
+	}
+
+	exe, err := os.Executable()
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" + generatorEnv + "=1 exec " + exe + "\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, GeneratorPrefix+"echo"), []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	generator, err := LookupGenerator("echo")
+	assert.NoError(t, err)
+
+	files, err := generator.Generate(context.Empty(), &Request{
+		Package: &lang.Package{Name: "foo", FullName: "mojo.foo"},
+		Options: map[string]string{"suffix": "bar"},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		assert.Equal(t, "echo/foo.txt", files[0].Name)
+		assert.Equal(t, "mojo.foo:bar", files[0].Content)
+	}
+
+	_, err = generator.Generate(context.Empty(), &Request{
+		Package: &lang.Package{Name: "foo"},
+		Options: map[string]string{"fail": "true"},
+	})
+	assert.ErrorContains(t, err, "failed on purpose")
+
+	_, err = LookupGenerator("not-exist")
+	assert.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	req, err := (&Request{Target: "echo", Package: &lang.Package{Name: "foo", FullName: "mojo.foo"}}).Encode()
+	assert.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, run(bytes.NewReader(req), out, generateEcho))
+
+	resp, err := DecodeResponse(out.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, resp.Files, 1)
+}
+
+// protoFieldNumbers the field numbers of the messages in the plugin.proto
+func protoFieldNumbers(t *testing.T) map[string]map[string]int64 {
+	file, err := syntax.New(nil).ParseString(context.Empty(), Proto)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	numbers := make(map[string]map[string]int64)
+	for _, statement := range file.Statements {
+		decl := statement.GetDeclaration().GetStructDecl()
+		if decl == nil {
+			continue
+		}
+		numbers[decl.Name] = make(map[string]int64)
+		for _, field := range decl.GetType().GetFields() {
+			number, err := lang.GetIntegerAttribute(field.GetType().GetAttributes(), core.NumberAttributeName)
+			assert.NoError(t, err)
+			numbers[decl.Name][field.Name] = number
+		}
+	}
+	return numbers
+}
+
+func TestProto(t *testing.T) {
+	assert.Equal(t, map[string]map[string]int64{
+		"Request":  {"target": 1, "package": 2, "options": 3},
+		"Option":   {"key": 1, "value": 2},
+		"Response": {"error": 1, "files": 2},
+		"File":     {"name": 1, "content": 2, "skip_if_exist": 3, "skip_if_user_code_mixed": 4},
+	}, protoFieldNumbers(t))
+}
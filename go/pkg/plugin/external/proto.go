@@ -0,0 +1,10 @@
+package external
+
+import (
+	_ "embed"
+)
+
+// Proto the `plugin.proto` of the Request and Response, which the third-party generator plugins compile against
+//
+//go:embed plugin.proto
+var Proto string
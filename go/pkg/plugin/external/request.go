@@ -0,0 +1,137 @@
+package external
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+// Request the request sent to the generator plugin through its stdin, encoded in the protobuf wire format
+// as the `mojo.plugin.Request` in the plugin.proto
+type Request struct {
+	Target string
+
+	// the compiled package, with all its dependencies resolved. the back references of the circular ones, like
+	// the type declaration of a recursive type, are dropped in the encoding, which are resolved by the full names
+	Package *lang.Package
+
+	Options map[string]string
+}
+
+func (r *Request) Encode() ([]byte, error) {
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, r.Target)
+
+	if r.Package != nil {
+		var message proto.Message = r.Package
+		if util.HasCircularReference(r.Package) {
+			message = util.CopyWithoutCircularReference(r.Package)
+		}
+		pkg, err := proto.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, pkg)
+	}
+
+	var keys []string
+	for key := range r.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var option []byte
+		option = protowire.AppendTag(option, 1, protowire.BytesType)
+		option = protowire.AppendString(option, key)
+		option = protowire.AppendTag(option, 2, protowire.BytesType)
+		option = protowire.AppendString(option, r.Options[key])
+
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, option)
+	}
+	return b, nil
+}
+
+func DecodeRequest(b []byte) (*Request, error) {
+	r := &Request{Options: make(map[string]string)}
+	err := rangeFields(b, func(num protowire.Number, value []byte) error {
+		switch num {
+		case 1:
+			r.Target = string(value)
+		case 2:
+			r.Package = &lang.Package{}
+			return proto.Unmarshal(value, r.Package)
+		case 3:
+			var key, val string
+			err := rangeFields(value, func(num protowire.Number, value []byte) error {
+				switch num {
+				case 1:
+					key = string(value)
+				case 2:
+					val = string(value)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			r.Options[key] = val
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// rangeFields call the fn with the length-delimited fields of the message, the varint ones are passed
+// in the protowire encoded form, and others are skipped
+func rangeFields(b []byte, fn func(num protowire.Number, value []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var value []byte
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			_, n = protowire.ConsumeVarint(b)
+			if n >= 0 {
+				value = b[:n]
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if value != nil || typ == protowire.BytesType {
+			if err := fn(num, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeBool(value []byte) (bool, error) {
+	v, n := protowire.ConsumeVarint(value)
+	if n < 0 {
+		return false, errors.New("invalid bool value")
+	}
+	return v != 0, nil
+}
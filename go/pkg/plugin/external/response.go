@@ -0,0 +1,91 @@
+package external
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+// Response the response sent back by the generator plugin through its stdout, encoded in the protobuf wire format
+// as the `mojo.plugin.Response` in the plugin.proto
+type Response struct {
+	// the error message if failed to generate, the files will be ignored
+	Error string
+
+	Files util.GeneratedFiles
+}
+
+func (r *Response) Encode() []byte {
+	var b []byte
+	if len(r.Error) > 0 {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, r.Error)
+	}
+
+	for _, f := range r.Files {
+		var file []byte
+		file = protowire.AppendTag(file, 1, protowire.BytesType)
+		file = protowire.AppendString(file, f.Name)
+		file = protowire.AppendTag(file, 2, protowire.BytesType)
+		file = protowire.AppendString(file, f.Content)
+		if f.SkipIfExist {
+			file = protowire.AppendTag(file, 3, protowire.VarintType)
+			file = protowire.AppendVarint(file, 1)
+		}
+		if f.SkipIfUserCodeMixed {
+			file = protowire.AppendTag(file, 4, protowire.VarintType)
+			file = protowire.AppendVarint(file, 1)
+		}
+
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, file)
+	}
+	return b
+}
+
+func DecodeResponse(b []byte) (*Response, error) {
+	r := &Response{}
+	err := rangeFields(b, func(num protowire.Number, value []byte) error {
+		switch num {
+		case 1:
+			r.Error = string(value)
+		case 2:
+			file := &util.GeneratedFile{}
+			err := rangeFields(value, func(num protowire.Number, value []byte) (err error) {
+				switch num {
+				case 1:
+					file.Name = string(value)
+				case 2:
+					file.Content = string(value)
+				case 3:
+					file.SkipIfExist, err = decodeBool(value)
+				case 4:
+					file.SkipIfUserCodeMixed, err = decodeBool(value)
+				}
+				return
+			})
+			if err != nil {
+				return err
+			}
+			// the files are written to the output directory by the name, which is untrusted from the plugin
+			if err = file.CheckName(); err != nil {
+				return err
+			}
+			r.Files = append(r.Files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Response) GetError() error {
+	if len(r.Error) > 0 {
+		return errors.New(r.Error)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package plugin
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+)
+
+// EnabledOption the option to disable the plugin or all the plugins in the group, like `compiler.query.enabled=false`
+const EnabledOption = "enabled"
+
+// ParseOption parse the plugin option like `compiler.pagination.page_size_max=500`,
+// the scope is the longest registered plugin name or group name in the key,
+// or all the segments except the last one if there is no registered one (for the external generator plugins)
+func ParseOption(option string) (scope string, key string, value interface{}, err error) {
+	kv := strings.SplitN(option, "=", 2)
+	if len(kv) != 2 {
+		return "", "", nil, fmt.Errorf("invalid plugin option %s, should be like scope.key=value", option)
+	}
+
+	name := strings.TrimSpace(kv[0])
+	segments := strings.Split(name, ".")
+	if len(segments) < 2 {
+		return "", "", nil, fmt.Errorf("invalid plugin option %s, has no plugin scope", option)
+	}
+
+	scope = strings.Join(segments[:len(segments)-1], ".")
+	for i := len(segments) - 1; i > 0; i-- {
+		prefix := strings.Join(segments[:i], ".")
+		if GetPlugin(prefix) != nil || len(GetPluginGroup(prefix)) > 0 {
+			scope = prefix
+			break
+		}
+	}
+
+	return scope, strings.TrimPrefix(name, scope+"."), NormalizeOptionValue(strings.TrimSpace(kv[1])), nil
+}
+
+// SetOption set the plugin option, like `compiler.pagination.page_size_max=500`, into the configuration,
+// which has a higher priority than the `mojo.yaml`
+func SetOption(option string) error {
+	scope, key, value, err := ParseOption(option)
+	if err != nil {
+		return err
+	}
+	config.Get().SetPluginOption(scope, key, value)
+	return nil
+}
+
+// GetOptions the options of the plugin in the configuration, the options of its name override the ones of its group
+func GetOptions(p Plugin) core.Options {
+	var options core.Options
+	scopes := config.Get().Plugins
+	for _, scope := range []string{p.GetGroup(), p.GetName()} {
+		if values, ok := scopes[scope]; ok && len(scope) > 0 {
+			if options == nil {
+				options = make(core.Options)
+			}
+			for k, v := range values {
+				options[k] = NormalizeOptionValue(v)
+			}
+		}
+	}
+	return options
+}
+
+// ValidateOptions reject the options not supported by the plugin, or by any plugin in the group,
+// the options of the unregistered scopes are left to the external generator plugins
+func ValidateOptions(scopes map[string]core.Options) error {
+	var names []string
+	for scope := range scopes {
+		names = append(names, scope)
+	}
+	sort.Strings(names)
+
+	for _, scope := range names {
+		var plugins []Plugin
+		if p := GetPlugin(scope); p != nil {
+			plugins = append(plugins, p)
+		} else {
+			plugins = GetPluginGroup(scope)
+		}
+		if len(plugins) == 0 {
+			continue
+		}
+
+		supported := map[string]bool{EnabledOption: true}
+		for _, p := range plugins {
+			for _, option := range SupportedOptions(p) {
+				supported[option] = true
+			}
+		}
+
+		var keys []string
+		for key := range scopes[scope] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if !supported[key] {
+				return fmt.Errorf("unknown option %s.%s, the plugin %s does not support it", scope, key, scope)
+			}
+		}
+	}
+	return nil
+}
+
+func IsEnabled(options core.Options) bool {
+	return !options.HasValue(EnabledOption) || options.GetBool(EnabledOption)
+}
+
+// NormalizeOptionValue convert the option value from the command line or the yaml to bool, int, float64 or string,
+// which could be got by the core.Options directly
+func NormalizeOptionValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		switch v {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+		return v
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < math.MaxInt32 {
+			return int(v)
+		}
+		return v
+	case float32:
+		return NormalizeOptionValue(float64(v))
+	case int64:
+		return int(v)
+	case int32:
+		return int(v)
+	case uint64:
+		return int(v)
+	case uint32:
+		return int(v)
+	}
+	return value
+}
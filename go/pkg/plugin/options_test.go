@@ -0,0 +1,125 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+)
+
+type optionsPlugin struct {
+	BasicPlugin
+	Options core.Options
+}
+
+func newOptionsPlugin(name string, options core.Options, supported ...string) *optionsPlugin {
+	return &optionsPlugin{
+		BasicPlugin: BasicPlugin{
+			Name:             name,
+			Group:            "test.options",
+			SupportedOptions: supported,
+			Creator: func(options core.Options) Plugin {
+				return newOptionsPlugin(name, options, supported...)
+			},
+		},
+		Options: options,
+	}
+}
+
+func init() {
+	RegisterPlugin(newOptionsPlugin("test.options.foo", nil, "limit"))
+	RegisterPlugin(newOptionsPlugin("test.options.bar", nil))
+}
+
+// restorePluginOptions restores the plugin options of the process-wide config after the test
+func restorePluginOptions(t *testing.T) {
+	c := config.Get()
+	plugins := c.Plugins
+	c.Plugins = (&config.Config{}).Merge(c).Plugins
+	t.Cleanup(func() { c.Plugins = plugins })
+}
+
+func TestParseOption(t *testing.T) {
+	scope, key, value, err := ParseOption("test.options.foo.page_size_max=500")
+	assert.NoError(t, err)
+	assert.Equal(t, "test.options.foo", scope)
+	assert.Equal(t, "page_size_max", key)
+	assert.Equal(t, 500, value)
+
+	scope, key, value, err = ParseOption("test.options.enabled=false")
+	assert.NoError(t, err)
+	assert.Equal(t, "test.options", scope)
+	assert.Equal(t, "enabled", key)
+	assert.Equal(t, false, value)
+
+	scope, key, value, err = ParseOption("my-target.style.name=camel")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-target.style", scope)
+	assert.Equal(t, "name", key)
+	assert.Equal(t, "camel", value)
+
+	_, _, _, err = ParseOption("page_size_max=500")
+	assert.Error(t, err)
+	_, _, _, err = ParseOption("test.options.foo")
+	assert.Error(t, err)
+}
+
+func TestNormalizeOptionValue(t *testing.T) {
+	assert.Equal(t, true, NormalizeOptionValue("true"))
+	assert.Equal(t, 10, NormalizeOptionValue("10"))
+	assert.Equal(t, 0.5, NormalizeOptionValue("0.5"))
+	assert.Equal(t, "foo", NormalizeOptionValue("foo"))
+	assert.Equal(t, 500, NormalizeOptionValue(float64(500)))
+	assert.Equal(t, 500, NormalizeOptionValue(int64(500)))
+}
+
+func TestNewPlugins_Options(t *testing.T) {
+	restorePluginOptions(t)
+
+	assert.NoError(t, SetOption("test.options.limit=10"))
+	assert.NoError(t, SetOption("test.options.foo.limit=20"))
+
+	plugins := NewPlugins("test.options")
+	assert.Equal(t, 2, len(plugins.plugins))
+	for _, p := range plugins.plugins {
+		options := p.(*optionsPlugin).Options
+		if p.GetName() == "test.options.foo" {
+			assert.Equal(t, int64(20), options.GetInteger("limit"))
+		} else {
+			assert.Equal(t, int64(10), options.GetInteger("limit"))
+		}
+	}
+	assert.Contains(t, plugins.Options(), "test.options.foo.limit=20")
+
+	assert.NoError(t, SetOption("test.options.bar.enabled=false"))
+	assert.Equal(t, []string{"test.options.foo"}, NewPlugins("test.options").Names())
+}
+
+func TestNewPlugins_OptionsRestored(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		restorePluginOptions(t)
+		assert.NoError(t, SetOption("test.options.bar.enabled=false"))
+		assert.Equal(t, []string{"test.options.foo"}, NewPlugins("test.options").Names())
+	})
+
+	assert.Nil(t, config.Get().Plugins["test.options.bar"])
+	assert.Equal(t, 2, len(NewPlugins("test.options").Names()))
+}
+
+func TestValidateOptions(t *testing.T) {
+	assert.NoError(t, ValidateOptions(map[string]core.Options{
+		"test.options":     {"limit": 10, EnabledOption: true},
+		"test.options.foo": {"limit": 20},
+		"test.options.bar": {EnabledOption: false},
+		"my-target.style":  {"name": "camel"},
+	}))
+
+	assert.EqualError(t, ValidateOptions(map[string]core.Options{
+		"test.options.bar": {"limit": 10},
+	}), "unknown option test.options.bar.limit, the plugin test.options.bar does not support it")
+	assert.Error(t, ValidateOptions(map[string]core.Options{
+		"test.options": {"page_size": 10},
+	}))
+}
@@ -90,18 +90,16 @@ func ParsePackage(p interface{}, ctx context.Context, pkg *lang.Package) error {
 	}
 
 	thisCtx := context.WithScopeType(ctx, pkg)
-	for _, file := range pkg.SourceFiles {
-		if err := ParseSourceFile(p, thisCtx, file); err != nil {
-			return err
-		}
+	err := eachSourceFile(p, pkg, func(file *lang.SourceFile) error {
+		return ParseSourceFile(p, thisCtx, file)
+	})
+	if err != nil {
+		return err
 	}
 
-	for _, child := range pkg.Children {
-		if err := ParsePackage(p, thisCtx, child); err != nil {
-			return err
-		}
-	}
-	return nil
+	return eachChild(p, pkg, func(child *lang.Package) error {
+		return ParsePackage(p, thisCtx, child)
+	})
 }
 
 func ParseSourceFile(p interface{}, ctx context.Context, file *lang.SourceFile) error {
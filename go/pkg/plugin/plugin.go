@@ -4,6 +4,9 @@ import (
 	"reflect"
 
 	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
 type Plugin interface {
@@ -16,6 +19,93 @@ type Plugin interface {
 	Create(options core.Options) Plugin
 }
 
+// DependentPlugin the plugin declares the artifacts it provides and requires, which decide the order in the pipeline
+type DependentPlugin interface {
+	// GetProvides the artifacts provided by the plugin, like ResolvedIdentifiers
+	GetProvides() []string
+
+	// GetRequires the artifacts must be provided by the plugins before it
+	GetRequires() []string
+
+	// GetOptionalRequires the artifacts should be provided before it if there is any plugin providing them
+	GetOptionalRequires() []string
+}
+
+func Provides(p interface{}) []string {
+	if d, ok := p.(DependentPlugin); ok {
+		return d.GetProvides()
+	}
+	return nil
+}
+
+// IsProviding the plugin provides the artifact
+func IsProviding(p interface{}, artifact string) bool {
+	for _, provided := range Provides(p) {
+		if provided == artifact {
+			return true
+		}
+	}
+	return false
+}
+
+func Requires(p interface{}) []string {
+	if d, ok := p.(DependentPlugin); ok {
+		return d.GetRequires()
+	}
+	return nil
+}
+
+func OptionalRequires(p interface{}) []string {
+	if d, ok := p.(DependentPlugin); ok {
+		return d.GetOptionalRequires()
+	}
+	return nil
+}
+
+// ConfigurablePlugin the plugin declares the options it accepts
+type ConfigurablePlugin interface {
+	GetSupportedOptions() []string
+}
+
+func SupportedOptions(p interface{}) []string {
+	if c, ok := p.(ConfigurablePlugin); ok {
+		return c.GetSupportedOptions()
+	}
+	return nil
+}
+
+// ConcurrentPlugin the plugin could process the source files and the child packages concurrently
+type ConcurrentPlugin interface {
+	IsConcurrent() bool
+}
+
+func IsConcurrent(p interface{}) bool {
+	if c, ok := p.(ConcurrentPlugin); ok {
+		return c.IsConcurrent()
+	}
+	return false
+}
+
+// concurrency the worker pool size to process the source files and the child packages for the plugin
+func concurrency(p interface{}) int {
+	if IsConcurrent(p) {
+		return util.DefaultConcurrency()
+	}
+	return 1
+}
+
+func eachSourceFile(p interface{}, pkg *lang.Package, fn func(file *lang.SourceFile) error) error {
+	return util.Parallel(len(pkg.SourceFiles), concurrency(p), func(i int) error {
+		return fn(pkg.SourceFiles[i])
+	})
+}
+
+func eachChild(p interface{}, pkg *lang.Package, fn func(child *lang.Package) error) error {
+	return util.Parallel(len(pkg.Children), concurrency(p), func(i int) error {
+		return fn(pkg.Children[i])
+	})
+}
+
 func ContainsAnyMethod(p interface{}, methods ...string) bool {
 	v := reflect.ValueOf(p)
 	for _, method := range methods {
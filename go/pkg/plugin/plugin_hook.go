@@ -0,0 +1,40 @@
+package plugin
+
+import (
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+// PluginHook observe the plugins pipeline, called before and after each plugin processing the package
+type PluginHook interface {
+	BeforePlugin(ctx context.Context, plugin Plugin, pkg *lang.Package)
+	AfterPlugin(ctx context.Context, plugin Plugin, pkg *lang.Package)
+}
+
+const pluginHooksKey = "@pluginHooks"
+
+// WithPluginHook add the hook to the hooks already in the context
+func WithPluginHook(ctx context.Context, hook PluginHook) context.Context {
+	hooks := append([]PluginHook{}, ContextPluginHooks(ctx)...)
+	return context.WithValues(ctx, pluginHooksKey, append(hooks, hook))
+}
+
+func ContextPluginHooks(ctx context.Context) []PluginHook {
+	if hooks, ok := ctx.Value(pluginHooksKey).([]PluginHook); ok {
+		return hooks
+	}
+	return nil
+}
+
+func beforePlugin(ctx context.Context, plugin Plugin, pkg *lang.Package) {
+	for _, hook := range ContextPluginHooks(ctx) {
+		hook.BeforePlugin(ctx, plugin, pkg)
+	}
+}
+
+func afterPlugin(ctx context.Context, plugin Plugin, pkg *lang.Package) {
+	for _, hook := range ContextPluginHooks(ctx) {
+		hook.AfterPlugin(ctx, plugin, pkg)
+	}
+}
@@ -0,0 +1,79 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sortPlugins sort the plugins topologically by the artifacts they require and provide,
+// the plugins without dependency between them are ordered by the group priority and the priority.
+//
+// returns error if any required artifact is not provided, or the plugins require each other in a cycle.
+func sortPlugins(ps plugins) (plugins, error) {
+	providers := make(map[string][]int)
+	for i, p := range ps {
+		for _, artifact := range Provides(p) {
+			providers[artifact] = append(providers[artifact], i)
+		}
+	}
+
+	var missing []string
+	dependents := make([][]int, len(ps))
+	degrees := make([]int, len(ps))
+	addEdges := func(i int, artifacts []string, required bool) {
+		for _, artifact := range artifacts {
+			if _, ok := providers[artifact]; !ok && required {
+				missing = append(missing, fmt.Sprintf("%s requires %s", ps[i].GetName(), artifact))
+			}
+			for _, provider := range providers[artifact] {
+				if provider != i {
+					dependents[provider] = append(dependents[provider], i)
+					degrees[i]++
+				}
+			}
+		}
+	}
+	for i, p := range ps {
+		addEdges(i, Requires(p), true)
+		addEdges(i, OptionalRequires(p), false)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the required artifacts are not provided by any plugin: %s", strings.Join(missing, ", "))
+	}
+
+	var ready []int
+	for i, degree := range degrees {
+		if degree == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	sorted := make(plugins, 0, len(ps))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool {
+			return ps.Less(ready[i], ready[j])
+		})
+
+		next := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, ps[next])
+		for _, dependent := range dependents[next] {
+			degrees[dependent]--
+			if degrees[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(sorted) < len(ps) {
+		var cycle []string
+		for i, degree := range degrees {
+			if degree > 0 {
+				cycle = append(cycle, ps[i].GetName())
+			}
+		}
+		return nil, fmt.Errorf("the plugins are blocked by the cyclic requirements: %s", strings.Join(cycle, ", "))
+	}
+	return sorted, nil
+}
@@ -0,0 +1,53 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSortPlugin(name string, priority int, provides []string, requires []string, optional ...string) Plugin {
+	return &BasicPlugin{
+		Name:             name,
+		Priority:         priority,
+		Provides:         provides,
+		Requires:         requires,
+		OptionalRequires: optional,
+	}
+}
+
+func sortedNames(ps plugins) []string {
+	var names []string
+	for _, p := range ps {
+		names = append(names, p.GetName())
+	}
+	return names
+}
+
+func TestSortPlugins(t *testing.T) {
+	sorted, err := sortPlugins(plugins{
+		newSortPlugin("c", 1, []string{"c"}, []string{"b"}),
+		newSortPlugin("b", 2, []string{"b"}, []string{"a"}),
+		newSortPlugin("a", 3, []string{"a"}, nil),
+		newSortPlugin("d", 0, nil, nil, "c", "not-provided"),
+		newSortPlugin("e", 0, nil, nil),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"e", "a", "b", "c", "d"}, sortedNames(sorted))
+}
+
+func TestSortPlugins_Missing(t *testing.T) {
+	_, err := sortPlugins(plugins{
+		newSortPlugin("a", 0, nil, []string{"resolved-identifiers"}),
+	})
+	assert.ErrorContains(t, err, "a requires resolved-identifiers")
+}
+
+func TestSortPlugins_Cycle(t *testing.T) {
+	_, err := sortPlugins(plugins{
+		newSortPlugin("a", 0, []string{"a"}, []string{"b"}),
+		newSortPlugin("b", 0, []string{"b"}, []string{"a"}),
+		newSortPlugin("c", 0, nil, []string{"a"}),
+	})
+	assert.ErrorContains(t, err, "cyclic requirements: a, b, c")
+}
@@ -4,40 +4,95 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/mojo-lang/core/go/pkg/logs"
 	"github.com/mojo-lang/core/go/pkg/mojo/core"
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/util"
 )
 
+// Plugins the plugin pipeline with a cursor to the current plugin.
+//
+// the cursor is guarded, but it is still a sequential state, use Copy to get a new pipeline
+// for each goroutine, the parsed packages are shared and synchronized between the copies.
 type Plugins struct {
 	plugins
 	cursor         int
-	parsedPackages map[string]bool
+	mutex          sync.Mutex
+	parsedPackages *packageSet
 }
 
+type packageSet struct {
+	mutex    sync.Mutex
+	packages map[string]bool
+}
+
+func (s *packageSet) contains(name string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.packages[name]
+}
+
+func (s *packageSet) add(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.packages[name] = true
+}
+
+// NewPlugins is like ResolvePlugins but panics if the pipeline can not be resolved,
+// it is only for the fixed pipelines whose plugins are all builtin.
 func NewPlugins(plugins ...string) *Plugins {
+	ps, err := ResolvePlugins(plugins...)
+	if err != nil {
+		panic(err)
+	}
+	return ps
+}
+
+// ResolvePlugins create the pipeline of the plugins or the plugin groups,
+// which is sorted by the artifacts the plugins require and provide.
+func ResolvePlugins(plugins ...string) (*Plugins, error) {
 	ps := &Plugins{
-		parsedPackages: make(map[string]bool),
+		parsedPackages: &packageSet{packages: make(map[string]bool)},
 	}
 
 	for _, name := range plugins {
 		if p := GetPlugin(name); p != nil {
-			ps.plugins = append(ps.plugins, p.Create(nil))
+			ps.add(p)
 		} else if plugs := GetPluginGroup(name); len(plugs) > 0 {
 			for _, p = range plugs {
-				ps.plugins = append(ps.plugins, p.Create(nil))
+				ps.add(p)
 			}
 		} else {
-			logs.Warnw("the plugin has not been register", "plugin", name)
+			return nil, fmt.Errorf("the plugin or plugin group %s has not been registered", name)
 		}
 	}
-	return ps.sort()
+
+	sorted, err := sortPlugins(ps.plugins)
+	if err != nil {
+		return nil, err
+	}
+	ps.plugins = sorted
+	return ps, nil
+}
+
+// add create the plugin with the options in the configuration, skip it if disabled
+func (p *Plugins) add(plugin Plugin) {
+	options := GetOptions(plugin)
+	if !IsEnabled(options) {
+		logs.Infow("skip the disabled plugin", "plugin", plugin.GetName())
+		return
+	}
+	p.plugins = append(p.plugins, plugin.Create(options))
 }
 
 func (p *Plugins) Next() *Plugins {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
 	p.cursor++
 	if p.cursor == len(p.plugins) {
 		return nil
@@ -46,15 +101,44 @@ func (p *Plugins) Next() *Plugins {
 }
 
 func (p *Plugins) plugin() Plugin {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
 	if p.cursor >= len(p.plugins) {
 		return nil
 	}
 	return p.plugins[p.cursor]
 }
 
-func (p *Plugins) sort() *Plugins {
-	sort.Sort(p.plugins)
-	return p
+// Plugins returns all the plugins, in the order of execution
+func (p *Plugins) Plugins() []Plugin {
+	return p.plugins
+}
+
+// Names returns the names of all the plugins, in the order of execution
+func (p *Plugins) Names() []string {
+	var names []string
+	for _, plug := range p.plugins {
+		names = append(names, plug.GetName())
+	}
+	return names
+}
+
+// Options returns the options of all the plugins, like `compiler.pagination.auto=false`, in the order of execution
+func (p *Plugins) Options() []string {
+	var options []string
+	for _, plug := range p.plugins {
+		values := GetOptions(plug)
+		var keys []string
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			options = append(options, fmt.Sprintf("%s.%s=%v", plug.GetName(), key, values[key]))
+		}
+	}
+	return options
 }
 
 func (p *Plugins) Copy() *Plugins {
@@ -118,40 +202,85 @@ func (p *Plugins) ParseString(ctx context.Context, content string) (*lang.Source
 }
 
 func (p *Plugins) ParsePackage(ctx context.Context, pkg *lang.Package) error {
-	if p.parsedPackages[pkg.FullName] {
+	if p.parsedPackages.contains(pkg.FullName) {
 		logs.Infow("skip when the package has been parsed", "pkg", pkg.FullName)
 		return nil
 	}
+	if util.IsPackageCached(pkg) {
+		logs.Infow("skip the plugins except resolving the dependencies when the package is loaded from the build cache", "pkg", pkg.FullName)
+		return p.parseCachedPackage(ctx, pkg)
+	}
 
-	for p.plugin() != nil {
-		if err := ParsePackage(p.plugin(), ctx, pkg); err != nil && !core.IsSkipError(err) {
+	for plug := p.plugin(); plug != nil; plug = p.plugin() {
+		beforePlugin(ctx, plug, pkg)
+		if err := tracePlugin(ctx, plug, "ParsePackage", pkg, func(ctx context.Context) error {
+			return ParsePackage(plug, ctx, pkg)
+		}); err != nil && !core.IsSkipError(err) {
 			return err
 		}
 
-		if err := CompilePackage(p.plugin(), ctx, pkg); err != nil && !core.IsSkipError(err) {
+		if err := tracePlugin(ctx, plug, "CompilePackage", pkg, func(ctx context.Context) error {
+			return CompilePackage(plug, ctx, pkg)
+		}); err != nil && !core.IsSkipError(err) {
 			return err
 		}
+		afterPlugin(ctx, plug, pkg)
 
 		p.Next()
 	}
 
 	if !pkg.IsPadding() {
-		p.parsedPackages[pkg.FullName] = true
+		p.parsedPackages.add(pkg.FullName)
+	}
+	return nil
+}
+
+// parseCachedPackage runs only the plugins resolving the dependencies on the package loaded from the build cache,
+// the package has been compiled, but its dependencies are not cached with it, which still need to be compiled
+func (p *Plugins) parseCachedPackage(ctx context.Context, pkg *lang.Package) error {
+	for plug := p.plugin(); plug != nil; plug = p.plugin() {
+		if !IsProviding(plug, ResolvedDependencies) {
+			p.Next()
+			continue
+		}
+
+		if err := tracePlugin(ctx, plug, "ParsePackage", pkg, func(ctx context.Context) error {
+			return ParsePackage(plug, ctx, pkg)
+		}); err != nil && !core.IsSkipError(err) {
+			return err
+		}
+		p.Next()
 	}
 	return nil
 }
 
 func (p *Plugins) CompilePackage(ctx context.Context, pkg *lang.Package) error {
-	for p.plugin() != nil {
-		if err := CompilePackage(p.plugin(), ctx, pkg); err != nil && !core.IsSkipError(err) {
+	for plug := p.plugin(); plug != nil; plug = p.plugin() {
+		beforePlugin(ctx, plug, pkg)
+		if err := tracePlugin(ctx, plug, "CompilePackage", pkg, func(ctx context.Context) error {
+			return CompilePackage(plug, ctx, pkg)
+		}); err != nil && !core.IsSkipError(err) {
 			return err
 		}
+		afterPlugin(ctx, plug, pkg)
 
 		p.Next()
 	}
 	return nil
 }
 
+// tracePlugin record the span of the plugin processing the package, the skipped ones are discarded
+func tracePlugin(ctx context.Context, plug Plugin, method string, pkg *lang.Package, fn func(ctx context.Context) error) error {
+	span, spanCtx := util.BeginSpan(ctx, util.PluginSpan, plug.GetName()+"."+method, "pkg", pkg.FullName)
+	err := fn(spanCtx)
+	if core.IsSkipError(err) {
+		span.Discard()
+	} else {
+		span.End()
+	}
+	return err
+}
+
 type plugins []Plugin
 
 func (p plugins) Len() int      { return len(p) }
@@ -1 +1,29 @@
 package plugin
+
+import (
+	"testing"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	RegisterPlugin(&BasicPlugin{
+		Name:     "test.resolve.missing",
+		Requires: []string{"test-not-provided"},
+		Creator: func(options core.Options) Plugin {
+			return &BasicPlugin{Name: "test.resolve.missing", Requires: []string{"test-not-provided"}}
+		},
+	})
+}
+
+func TestResolvePlugins_NotRegistered(t *testing.T) {
+	_, err := ResolvePlugins("test.options", "test.not-registered")
+	assert.ErrorContains(t, err, "test.not-registered has not been registered")
+}
+
+func TestResolvePlugins_Missing(t *testing.T) {
+	_, err := ResolvePlugins("test.resolve.missing")
+	assert.ErrorContains(t, err, "test.resolve.missing requires test-not-provided")
+	assert.Panics(t, func() { NewPlugins("test.resolve.missing") })
+}
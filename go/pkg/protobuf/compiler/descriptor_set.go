@@ -0,0 +1,66 @@
+package compiler
+
+import (
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/converter"
+)
+
+// DescriptorSet builds the FileDescriptorSet of the files compiled from the package, including all the files
+// imported by them, like the `protoc --include_imports --descriptor_set_out` does.
+// the source code info is only included if sourceInfo is true, which is built from the mojo source files
+func DescriptorSet(pkg *lang.Package, files []*descriptor.File, sourceInfo bool) (*descriptorpb.FileDescriptorSet, error) {
+	var names []string
+	for _, file := range files {
+		if !file.IsEmpty() {
+			names = append(names, file.GetName())
+		}
+	}
+
+	resolver := NewResolver(files, pkg.ResolvedDependencies)
+	protoFiles, err := resolver.Resolve(names...)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources map[string]*lang.SourceFile
+	if sourceInfo {
+		sources = protoSourceFiles(pkg)
+	}
+	for i, file := range protoFiles {
+		if file.SourceCodeInfo == nil && sources[file.GetName()] == nil {
+			continue
+		}
+
+		// the files not compiled from the mojo are shared with the registry, so should not be changed
+		file = proto.Clone(file).(*descriptorpb.FileDescriptorProto)
+		file.SourceCodeInfo = nil
+		if source := sources[file.GetName()]; source != nil && resolver.compiled[file.GetName()] {
+			file.SourceCodeInfo = SourceInfo(file, source)
+		}
+		protoFiles[i] = file
+	}
+
+	return &descriptorpb.FileDescriptorSet{File: protoFiles}, nil
+}
+
+// protoSourceFiles the mojo source files of the package and its dependencies, indexed by the compiled proto file names
+func protoSourceFiles(pkg *lang.Package) map[string]*lang.SourceFile {
+	sources := make(map[string]*lang.SourceFile)
+	add := func(p *lang.Package) {
+		for _, child := range p.GetAllPackages() {
+			for _, source := range child.SourceFiles {
+				sources[converter.GetProtoFile(source.FullName)] = source
+			}
+		}
+	}
+
+	add(pkg)
+	for _, dependency := range pkg.ResolvedDependencies {
+		add(dependency)
+	}
+	return sources
+}
@@ -0,0 +1,111 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/converter"
+)
+
+func TestDescriptorSet(t *testing.T) {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "../../ncraft/testdata/mojo-ncraft")
+	if !assert.NoError(t, err) || pkg == nil {
+		t.FailNow()
+	}
+
+	c := converter.New()
+	assert.NoError(t, c.CompilePackage(context.Empty(), pkg))
+	files := c.Descriptors.Filter(pkg.FullName, false)
+
+	set, err := DescriptorSet(pkg, files, false)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	index := make(map[string]int)
+	for i, file := range set.File {
+		index[file.GetName()] = i
+		assert.Nil(t, file.SourceCodeInfo, file.GetName())
+	}
+	assert.Contains(t, index, "ncraft/v1/geocoding.proto")
+	assert.Contains(t, index, "mojo/geom/lng_lat.proto")
+	assert.Less(t, index["ncraft/address.proto"], index["ncraft/v1/geocoding.proto"])
+
+	// all the imported files are included, so the set can be loaded alone
+	_, err = protodesc.NewFiles(set)
+	assert.NoError(t, err)
+
+	set, err = DescriptorSet(pkg, files, true)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	registry, err := protodesc.NewFiles(set)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	geocoding, err := registry.FindFileByPath("ncraft/v1/geocoding.proto")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	service := geocoding.Services().ByName("Geocoding")
+	locations := geocoding.SourceLocations()
+	assert.Contains(t, locations.ByDescriptor(service).LeadingComments, "Geocoding API")
+	assert.Contains(t, locations.ByDescriptor(service.Methods().ByName("geocode")).LeadingComments, "geocode for coding the address")
+
+	// the files registered by the linked go packages have no source info
+	lngLat, err := registry.FindFileByPath("mojo/geom/lng_lat.proto")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, lngLat.SourceLocations().Len())
+	}
+}
+
+func TestDescriptorSet_CustomOptions(t *testing.T) {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "../../mojo/testdata/mojo-option")
+	if !assert.NoError(t, err) || pkg == nil {
+		t.FailNow()
+	}
+
+	c := converter.New()
+	if !assert.NoError(t, c.CompilePackage(context.Empty(), pkg)) {
+		t.FailNow()
+	}
+
+	set, err := DescriptorSet(pkg, c.Descriptors.Filter(pkg.FullName, false), false)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	registry, err := protodesc.NewFiles(set)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	sensitive, err := registry.FindDescriptorByName("test.sensitive")
+	if assert.NoError(t, err) {
+		extension := sensitive.(protoreflect.ExtensionDescriptor)
+		assert.Equal(t, protoreflect.FieldNumber(50001), extension.Number())
+		assert.Equal(t, protoreflect.FullName("google.protobuf.FieldOptions"), extension.ContainingMessage().FullName())
+	}
+
+	account, err := registry.FindDescriptorByName("test.Account")
+	if assert.NoError(t, err) {
+		options := account.(protoreflect.MessageDescriptor).Fields().ByName("password").Options()
+		values := make(map[protoreflect.FieldNumber]interface{})
+		options.ProtoReflect().Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			values[field.Number()] = value.Interface()
+			return true
+		})
+		assert.Equal(t, true, values[50001])
+		assert.Equal(t, int32(64), values[50002])
+	}
+}
@@ -0,0 +1,142 @@
+package compiler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/converter"
+)
+
+// Resolver resolves the proto files imported by the compiled files without the protoc,
+// the imported files are looked up in order from:
+//  1. the compiled files
+//  2. the proto files registered by the linked go packages, like the mojo.core and the well-known types
+//  3. the dependent mojo packages, which will be compiled to the descriptors on demand
+type Resolver struct {
+	Files        map[string]*descriptorpb.FileDescriptorProto
+	Dependencies map[string]*lang.Package
+
+	// the files compiled from the mojo, which need to be normalized
+	compiled             map[string]bool
+	dependenciesCompiled bool
+}
+
+func NewResolver(files []*descriptor.File, dependencies map[string]*lang.Package) *Resolver {
+	r := &Resolver{
+		Files:        make(map[string]*descriptorpb.FileDescriptorProto),
+		Dependencies: dependencies,
+		compiled:     make(map[string]bool),
+	}
+	for _, file := range files {
+		if file != nil && file.Proto != nil {
+			r.Files[file.GetName()] = file.Proto
+			r.compiled[file.GetName()] = true
+		}
+	}
+	return r
+}
+
+// Resolve return the files and all the files imported by them transitively, the imported files are
+// placed before the ones importing them, as the CodeGeneratorRequest and FileDescriptorSet require.
+// the files compiled from the mojo are normalized like the protoc parsed, see Normalize.
+func (r *Resolver) Resolve(names ...string) ([]*descriptorpb.FileDescriptorProto, error) {
+	var err error
+	var files []*descriptorpb.FileDescriptorProto
+	visited := make(map[string]bool)
+
+	var resolve func(name string, importer string) error
+	resolve = func(name string, importer string) error {
+		if visited[name] {
+			return nil
+		}
+		visited[name] = true
+
+		file, err := r.lookup(name)
+		if err != nil {
+			return err
+		}
+		if file == nil {
+			if len(importer) > 0 {
+				return fmt.Errorf("failed to resolve the proto file %s imported by %s", name, importer)
+			}
+			return fmt.Errorf("failed to resolve the proto file %s", name)
+		}
+
+		for _, dependency := range file.Dependency {
+			if err = resolve(dependency, name); err != nil {
+				return err
+			}
+		}
+		files = append(files, file)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := resolve(name, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	types := NewTypes(files...)
+	for i, file := range files {
+		if r.compiled[file.GetName()] {
+			if files[i], err = types.Normalize(file); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return files, nil
+}
+
+func (r *Resolver) lookup(name string) (*descriptorpb.FileDescriptorProto, error) {
+	if file, ok := r.Files[name]; ok {
+		return file, nil
+	}
+
+	if file, err := protoregistry.GlobalFiles.FindFileByPath(name); err == nil {
+		proto := protodesc.ToFileDescriptorProto(file)
+		r.Files[name] = proto
+		return proto, nil
+	}
+
+	if !r.dependenciesCompiled {
+		r.dependenciesCompiled = true
+		if err := r.compileDependencies(); err != nil {
+			return nil, err
+		}
+		return r.Files[name], nil
+	}
+	return nil, nil
+}
+
+func (r *Resolver) compileDependencies() error {
+	var names []string
+	for name := range r.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dependency := r.Dependencies[name]
+		c := converter.New()
+		if err := c.CompilePackage(context.Empty(), dependency); err != nil {
+			logs.Errorw("failed to compile the dependency to protobuf", "package", dependency.FullName, "error", err.Error())
+			return err
+		}
+		for name, file := range c.Descriptors.FilesByPath {
+			if _, ok := r.Files[name]; !ok {
+				r.Files[name] = file.Proto
+				r.compiled[name] = true
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package compiler
+
+import (
+	"strings"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// the field numbers of the descriptors composing the paths of the source locations, see the descriptor.proto
+const (
+	fileMessageTypeTag    = 4
+	fileEnumTypeTag       = 5
+	fileServiceTag        = 6
+	messageFieldTag       = 2
+	messageNestedTypeTag  = 3
+	messageEnumTypeTag    = 4
+	enumValueTag          = 2
+	serviceMethodTag      = 2
+	sourceLocationMaxPath = 6
+)
+
+// SourceInfo builds the source code info of the file compiled from the mojo source file, the locations point to
+// the declarations in the mojo file, and the comments are the documents of the declarations
+func SourceInfo(file *descriptorpb.FileDescriptorProto, source *lang.SourceFile) *descriptorpb.SourceCodeInfo {
+	if file == nil || source == nil {
+		return nil
+	}
+
+	b := &sourceInfoBuilder{info: &descriptorpb.SourceCodeInfo{}}
+
+	structs := make(map[string]*lang.StructDecl)
+	enums := make(map[string]*lang.EnumDecl)
+	interfaces := make(map[string]*lang.InterfaceDecl)
+	for _, statement := range source.Statements {
+		decl := statement.GetDeclaration()
+		if d := decl.GetStructDecl(); d != nil {
+			structs[d.Name] = d
+		} else if d := decl.GetEnumDecl(); d != nil {
+			enums[d.Name] = d
+		} else if d := decl.GetInterfaceDecl(); d != nil {
+			interfaces[d.Name] = d
+		}
+	}
+
+	for i, message := range file.MessageType {
+		if decl, ok := structs[message.GetName()]; ok {
+			b.addMessage(sourcePath(nil, fileMessageTypeTag, i), message, decl)
+		}
+	}
+	for i, enum := range file.EnumType {
+		if decl, ok := enums[enum.GetName()]; ok {
+			b.addEnum(sourcePath(nil, fileEnumTypeTag, i), enum, decl)
+		}
+	}
+	for i, service := range file.Service {
+		if decl, ok := interfaces[service.GetName()]; ok {
+			b.addService(sourcePath(nil, fileServiceTag, i), service, decl)
+		}
+	}
+	return b.info
+}
+
+type sourceInfoBuilder struct {
+	info *descriptorpb.SourceCodeInfo
+}
+
+func (b *sourceInfoBuilder) addMessage(p []int32, message *descriptorpb.DescriptorProto, decl *lang.StructDecl) {
+	b.add(p, decl.StartPosition, decl.EndPosition, decl.Document)
+
+	fields := make(map[string]*lang.ValueDecl)
+	for _, field := range decl.GetType().GetFields() {
+		fields[field.Name] = field
+	}
+	for i, field := range message.Field {
+		if d, ok := fields[field.GetName()]; ok {
+			b.add(sourcePath(p, messageFieldTag, i), d.StartPosition, d.EndPosition, d.Document)
+		}
+	}
+
+	structs := make(map[string]*lang.StructDecl)
+	for _, d := range decl.StructDecls {
+		structs[d.Name] = d
+	}
+	for i, nested := range message.NestedType {
+		if d, ok := structs[nested.GetName()]; ok {
+			b.addMessage(sourcePath(p, messageNestedTypeTag, i), nested, d)
+		}
+	}
+
+	enums := make(map[string]*lang.EnumDecl)
+	for _, d := range decl.EnumDecls {
+		enums[d.Name] = d
+	}
+	for i, enum := range message.EnumType {
+		if d, ok := enums[enum.GetName()]; ok {
+			b.addEnum(sourcePath(p, messageEnumTypeTag, i), enum, d)
+		}
+	}
+}
+
+func (b *sourceInfoBuilder) addEnum(p []int32, enum *descriptorpb.EnumDescriptorProto, decl *lang.EnumDecl) {
+	b.add(p, decl.StartPosition, decl.EndPosition, decl.Document)
+
+	// the enum values are compiled in the order of the enumerators
+	enumerators := decl.GetType().GetEnumerators()
+	for i := range enum.Value {
+		if i < len(enumerators) {
+			e := enumerators[i]
+			b.add(sourcePath(p, enumValueTag, i), e.StartPosition, e.EndPosition, e.Document)
+		}
+	}
+}
+
+func (b *sourceInfoBuilder) addService(p []int32, service *descriptorpb.ServiceDescriptorProto, decl *lang.InterfaceDecl) {
+	b.add(p, decl.StartPosition, decl.EndPosition, decl.Document)
+
+	methods := make(map[string]*lang.FunctionDecl)
+	for _, method := range decl.GetType().GetMethods() {
+		methods[method.Name] = method
+	}
+	for i, method := range service.Method {
+		if d, ok := methods[method.GetName()]; ok {
+			b.add(sourcePath(p, serviceMethodTag, i), d.StartPosition, d.EndPosition, d.Document)
+		}
+	}
+}
+
+func (b *sourceInfoBuilder) add(p []int32, start *lang.Position, end *lang.Position, document *lang.Document) {
+	location := &descriptorpb.SourceCodeInfo_Location{
+		Path: p,
+		Span: span(start, end),
+	}
+	if comments := documentComments(document); len(comments) > 0 {
+		if document.Following {
+			location.TrailingComments = &comments
+		} else {
+			location.LeadingComments = &comments
+		}
+	}
+	b.info.Location = append(b.info.Location, location)
+}
+
+func sourcePath(parent []int32, tag int32, index int) []int32 {
+	p := make([]int32, 0, sourceLocationMaxPath)
+	p = append(p, parent...)
+	return append(p, tag, int32(index))
+}
+
+// span the zero based [start line, start column, end line, end column] as the protoc, the end line
+// is omitted if it is the same as the start line
+func span(start *lang.Position, end *lang.Position) []int32 {
+	startLine, startColumn := zeroBased(start.GetLine()), zeroBased(start.GetColumn())
+	endLine, endColumn := zeroBased(end.GetLine()), zeroBased(end.GetColumn())
+	if end == nil || endLine < startLine {
+		endLine, endColumn = startLine, startColumn
+	}
+	if endLine == startLine {
+		return []int32{startLine, startColumn, endColumn}
+	}
+	return []int32{startLine, startColumn, endLine, endColumn}
+}
+
+func zeroBased(value int64) int32 {
+	if value > 0 {
+		return int32(value - 1)
+	}
+	return 0
+}
+
+// documentComments the document in the format of the protoc comments, every line is started with a space
+// and ended with a line break
+func documentComments(document *lang.Document) string {
+	content := document.GetContent()
+	if len(strings.TrimSpace(content)) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimRight(line, " \t"); len(line) > 0 && !strings.HasPrefix(line, " ") {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(line)
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
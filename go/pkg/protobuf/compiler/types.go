@@ -0,0 +1,149 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// the converter sets the scalar fields with the message type and the scalar type name
+var scalarTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	"double":   descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	"float":    descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	"int64":    descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	"uint64":   descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	"int32":    descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	"fixed64":  descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+	"fixed32":  descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+	"bool":     descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	"string":   descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	"bytes":    descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	"uint32":   descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	"sfixed32": descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+	"sfixed64": descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+	"sint32":   descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+	"sint64":   descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+}
+
+// Types the full names of the messages and enums in the proto files, with the leading dot
+type Types map[string]descriptorpb.FieldDescriptorProto_Type
+
+func NewTypes(files ...*descriptorpb.FileDescriptorProto) Types {
+	types := make(Types)
+	for _, file := range files {
+		scope := ""
+		if len(file.GetPackage()) > 0 {
+			scope = "." + file.GetPackage()
+		}
+		types.addMessages(scope, file.MessageType)
+		types.addEnums(scope, file.EnumType)
+	}
+	return types
+}
+
+func (t Types) addMessages(scope string, messages []*descriptorpb.DescriptorProto) {
+	for _, message := range messages {
+		name := scope + "." + message.GetName()
+		t[name] = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		t.addMessages(name, message.NestedType)
+		t.addEnums(name, message.EnumType)
+	}
+}
+
+func (t Types) addEnums(scope string, enums []*descriptorpb.EnumDescriptorProto) {
+	for _, enum := range enums {
+		t[scope+"."+enum.GetName()] = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+	}
+}
+
+// Normalize return a copy of the file compiled from the mojo which is the same as the protoc parsed,
+// the type names are fully qualified, and the type names of the scalar fields are removed.
+func (t Types) Normalize(file *descriptorpb.FileDescriptorProto) (*descriptorpb.FileDescriptorProto, error) {
+	file = proto.Clone(file).(*descriptorpb.FileDescriptorProto)
+
+	scope := ""
+	if len(file.GetPackage()) > 0 {
+		scope = "." + file.GetPackage()
+	}
+	for _, message := range file.MessageType {
+		if err := t.normalizeMessage(scope, message); err != nil {
+			return nil, fmt.Errorf("failed to normalize the proto file %s: %w", file.GetName(), err)
+		}
+	}
+	for _, service := range file.Service {
+		for _, method := range service.Method {
+			input, err := t.resolve(scope, method.GetInputType())
+			if err != nil {
+				return nil, fmt.Errorf("failed to normalize the proto file %s: %w", file.GetName(), err)
+			}
+			output, err := t.resolve(scope, method.GetOutputType())
+			if err != nil {
+				return nil, fmt.Errorf("failed to normalize the proto file %s: %w", file.GetName(), err)
+			}
+			method.InputType = &input
+			method.OutputType = &output
+		}
+	}
+	return file, nil
+}
+
+func (t Types) normalizeMessage(scope string, message *descriptorpb.DescriptorProto) error {
+	scope = scope + "." + message.GetName()
+	for _, nested := range message.NestedType {
+		if err := t.normalizeMessage(scope, nested); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range message.Field {
+		if field.Label == nil {
+			field.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+		}
+
+		if t, ok := scalarTypes[field.GetTypeName()]; ok {
+			field.Type = t.Enum()
+			field.TypeName = nil
+			continue
+		}
+
+		switch field.GetType() {
+		case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+			descriptorpb.FieldDescriptorProto_TYPE_GROUP, 0:
+			name, err := t.resolve(scope, field.GetTypeName())
+			if err != nil {
+				return fmt.Errorf("field %s.%s: %w", strings.TrimPrefix(scope, "."), field.GetName(), err)
+			}
+			field.TypeName = &name
+			if field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+				field.Type = t[name].Enum()
+			}
+		default:
+			field.TypeName = nil
+		}
+	}
+	return nil
+}
+
+// resolve the type name by the protobuf scoping rules, search from the innermost scope to the outermost
+func (t Types) resolve(scope string, name string) (string, error) {
+	if strings.HasPrefix(name, ".") {
+		if _, ok := t[name]; ok {
+			return name, nil
+		}
+		return "", fmt.Errorf("type %s not found", name)
+	}
+
+	for {
+		candidate := scope + "." + name
+		if _, ok := t[candidate]; ok {
+			return candidate, nil
+		}
+		if len(scope) == 0 {
+			break
+		}
+		scope = scope[:strings.LastIndex(scope, ".")]
+	}
+	return "", fmt.Errorf("type %s not found", name)
+}
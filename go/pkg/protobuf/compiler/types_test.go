@@ -0,0 +1,49 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestTypes_Normalize(t *testing.T) {
+	message := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("a/b.proto"),
+		Package: proto.String("a.b"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Foo"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("name"), Type: &message, TypeName: proto.String("string")},
+				{Name: proto.String("bar"), Type: &message, TypeName: proto.String("Bar")},
+				{Name: proto.String("kind"), Type: &message, TypeName: proto.String("Kind")},
+				{Name: proto.String("baz"), Type: &message, TypeName: proto.String("a.Baz")},
+			},
+			EnumType: []*descriptorpb.EnumDescriptorProto{{Name: proto.String("Kind")}},
+		}, {
+			Name: proto.String("Bar"),
+		}},
+	}
+	dependency := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String("a/baz.proto"),
+		Package:     proto.String("a"),
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Baz")}},
+	}
+
+	normalized, err := NewTypes(dependency, file).Normalize(file)
+	assert.NoError(t, err)
+
+	fields := normalized.MessageType[0].Field
+	assert.Equal(t, descriptorpb.FieldDescriptorProto_TYPE_STRING, fields[0].GetType())
+	assert.Nil(t, fields[0].TypeName)
+	assert.Equal(t, ".a.b.Bar", fields[1].GetTypeName())
+	assert.Equal(t, descriptorpb.FieldDescriptorProto_TYPE_ENUM, fields[2].GetType())
+	assert.Equal(t, ".a.b.Foo.Kind", fields[2].GetTypeName())
+	assert.Equal(t, ".a.Baz", fields[3].GetTypeName())
+	assert.Equal(t, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, fields[3].GetLabel())
+
+	// the original file is not changed
+	assert.Equal(t, "string", file.MessageType[0].Field[0].GetTypeName())
+}
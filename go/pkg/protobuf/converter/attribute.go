@@ -0,0 +1,296 @@
+package converter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+const (
+	targetAttributeName = "target"
+	customOptionsKey    = "custom-options"
+
+	DescriptorDependency = "google/protobuf/descriptor.proto"
+
+	FieldOptionsExtendee   = ".google.protobuf.FieldOptions"
+	MessageOptionsExtendee = ".google.protobuf.MessageOptions"
+	MethodOptionsExtendee  = ".google.protobuf.MethodOptions"
+)
+
+// optionExtendees the DeclType targets of the attribute declaration to the protobuf options extended
+var optionExtendees = map[string]string{
+	"value":    FieldOptionsExtendee,
+	"type":     MessageOptionsExtendee,
+	"function": MethodOptionsExtendee,
+}
+
+// optionTypes the scalar types of the attribute declaration to the field types of the protobuf option
+var optionTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	core.BoolTypeName:    descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	core.Int8TypeName:    descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	core.Int16TypeName:   descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	core.Int32TypeName:   descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	core.Int64TypeName:   descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	core.IntTypeName:     descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	core.UInt8TypeName:   descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	core.UInt16TypeName:  descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	core.UInt32TypeName:  descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	core.UInt64TypeName:  descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	core.UIntTypeName:    descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	core.Float32TypeName: descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	core.Float64TypeName: descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	core.StringTypeName:  descriptorpb.FieldDescriptorProto_TYPE_STRING,
+}
+
+// Attribute compiles the attribute declaration with the number, like `attribute sensitive: Bool @50001`,
+// to the protobuf custom option extending the options decided by the `@target` of the declaration
+type Attribute struct {
+}
+
+// IsOptionAttribute the attribute declaration numbered is compiled to the protobuf custom option
+func IsOptionAttribute(decl *lang.AttributeDecl) bool {
+	return lang.HasAttribute(decl.GetNominalType().GetAttributes(), core.NumberAttributeName)
+}
+
+func (a Attribute) Compile(ctx context.Context, decl *lang.AttributeDecl, file *descriptor.File) error {
+	_ = ctx
+	if !IsOptionAttribute(decl) {
+		return nil
+	}
+
+	field, err := a.compileField(decl)
+	if err != nil {
+		return err
+	}
+
+	file.Proto.Extension = append(file.Proto.Extension, field)
+	file.AppendDependency(DescriptorDependency)
+	return nil
+}
+
+func (a Attribute) compileField(decl *lang.AttributeDecl) (*descriptorpb.FieldDescriptorProto, error) {
+	nominal := decl.GetNominalType()
+	typ, ok := optionTypes[nominal.GetName()]
+	if !ok || (len(nominal.GetPackageName()) > 0 && nominal.GetPackageName() != "mojo.core") {
+		return nil, fmt.Errorf("the type %s of the attribute %s is not a scalar type for the protobuf option", nominal.GetFullName(), decl.Name)
+	}
+
+	number, err := lang.GetIntegerAttribute(nominal.GetAttributes(), core.NumberAttributeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the number of the attribute %s: %s", decl.Name, err.Error())
+	}
+	if number <= 0 {
+		return nil, fmt.Errorf("number of the attribute %s must be positive", decl.Name)
+	}
+
+	extendee := FieldOptionsExtendee
+	if target := lang.GetAttribute(decl.Attributes, targetAttributeName); target != nil && len(target.Arguments) > 0 {
+		name := target.Arguments[0].GetValue().GetIdentifierExpr().GetName()
+		if extendee, ok = optionExtendees[name]; !ok {
+			return nil, fmt.Errorf("the target %s of the attribute %s is not supported by the protobuf options", name, decl.Name)
+		}
+	}
+
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(decl.Name),
+		Number:   proto.Int32(int32(number)),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+		Extendee: proto.String(extendee),
+	}, nil
+}
+
+// customOption the custom option compiled from the attribute declaration, with the extension type
+// to set the option values
+type customOption struct {
+	pkg       string // the package of the attribute declaration
+	file      string // the proto file of the attribute declaration
+	field     *descriptorpb.FieldDescriptorProto
+	extension protoreflect.ExtensionType
+}
+
+// customOptions the custom options of the package and its dependencies, indexed by the attribute name
+type customOptions map[string][]*customOption
+
+func newCustomOptions(pkg *lang.Package) (customOptions, error) {
+	options := make(customOptions)
+	visited := make(map[string]bool)
+
+	var collect func(pkg *lang.Package) error
+	collect = func(pkg *lang.Package) error {
+		if pkg == nil || visited[pkg.FullName] {
+			return nil
+		}
+		visited[pkg.FullName] = true
+
+		for _, sourceFile := range pkg.SourceFiles {
+			for _, statement := range sourceFile.Statements {
+				decl := statement.GetDeclaration().GetAttributeDecl()
+				if decl == nil || !IsOptionAttribute(decl) {
+					continue
+				}
+
+				field, err := Attribute{}.compileField(decl)
+				if err != nil {
+					return err
+				}
+				option := &customOption{
+					pkg:   sourceFile.PackageName,
+					file:  GetProtoFile(sourceFile.FullName),
+					field: field,
+				}
+				if option.extension, err = newExtensionType(option); err != nil {
+					return fmt.Errorf("failed to build the option of the attribute %s: %s", decl.Name, err.Error())
+				}
+				options[decl.Name] = append(options[decl.Name], option)
+			}
+		}
+
+		for _, child := range pkg.Children {
+			if err := collect(child); err != nil {
+				return err
+			}
+		}
+		for _, dependency := range pkg.ResolvedDependencies {
+			if err := collect(dependency); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := collect(pkg); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// newExtensionType build the dynamic extension type of the option, which is not registered globally
+func newExtensionType(option *customOption) (protoreflect.ExtensionType, error) {
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String(option.file),
+		Package:    proto.String(option.pkg),
+		Dependency: []string{DescriptorDependency},
+		Extension:  []*descriptorpb.FieldDescriptorProto{option.field},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		return nil, err
+	}
+	return dynamicpb.NewExtensionType(file.Extensions().Get(0)), nil
+}
+
+// lookup the option of the attribute extending the options, the option in the package is preferred
+func (o customOptions) lookup(attribute *lang.Attribute, extendee string, pkg string) *customOption {
+	var found *customOption
+	for _, option := range o[attribute.Name] {
+		if option.field.GetExtendee() != extendee {
+			continue
+		}
+		if len(attribute.PackageName) > 0 && option.pkg != attribute.PackageName &&
+			!strings.HasSuffix(option.pkg, "."+attribute.PackageName) {
+			continue
+		}
+		if option.pkg == pkg {
+			return option
+		}
+		if found == nil {
+			found = option
+		}
+	}
+	return found
+}
+
+// value the value of the attribute in the type of the option, the attribute without argument is true
+func (o *customOption) value(attribute *lang.Attribute) (interface{}, error) {
+	switch o.field.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return attribute.GetBool(), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return attribute.GetString()
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		var value float64
+		var err error
+		if len(attribute.Arguments) > 0 {
+			expr := attribute.Arguments[0].GetValue()
+			if expr.GetIntegerLiteralExpr() != nil {
+				var v int64
+				v, err = expr.EvalIntegerLiteral()
+				value = float64(v)
+			} else {
+				value, err = expr.EvalFloatLiteral()
+			}
+		}
+		if o.field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_FLOAT {
+			return float32(value), err
+		}
+		return value, err
+	}
+
+	value, err := attribute.GetInteger()
+	if err != nil {
+		return nil, err
+	}
+	switch o.field.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32:
+		if value < math.MinInt32 || value > math.MaxInt32 {
+			return nil, fmt.Errorf("the value %d is out of the range of int32", value)
+		}
+		return int32(value), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32:
+		if value < 0 || value > math.MaxUint32 {
+			return nil, fmt.Errorf("the value %d is out of the range of uint32", value)
+		}
+		return uint32(value), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64:
+		if value < 0 {
+			return nil, fmt.Errorf("the value %d is out of the range of uint64", value)
+		}
+		return uint64(value), nil
+	default:
+		return value, nil
+	}
+}
+
+// setCustomOptions set the attributes declared as the custom options to the options, which is created
+// when the first option set, and import the proto files declaring the options
+func setCustomOptions(ctx context.Context, attributes []*lang.Attribute, extendee string, options func() proto.Message) error {
+	index, _ := ctx.Value(customOptionsKey).(customOptions)
+	if len(index) == 0 {
+		return nil
+	}
+
+	file := context.FileDescriptor(ctx)
+	if file == nil {
+		return nil
+	}
+
+	for _, attribute := range attributes {
+		option := index.lookup(attribute, extendee, file.GetPackageName())
+		if option == nil {
+			continue
+		}
+
+		value, err := option.value(attribute)
+		if err != nil {
+			return fmt.Errorf("failed to get the value of the option %s: %s", attribute.GetFullName(), err.Error())
+		}
+		proto.SetExtension(options(), option.extension, value)
+
+		if option.file != file.GetName() {
+			file.AppendDependency(option.file)
+		}
+	}
+	return nil
+}
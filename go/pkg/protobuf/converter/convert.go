@@ -53,7 +53,10 @@ func (c *Convert) CompilePackages(packages map[string]*lang.Package) error {
 }
 
 func (c *Convert) CompilePackage(ctx context.Context, pkg *lang.Package) error {
-	thisCtx := context.WithType(ctx, pkg)
+	thisCtx, err := withCustomOptions(context.WithType(ctx, pkg), pkg)
+	if err != nil {
+		return err
+	}
 
 	for _, sourceFile := range pkg.SourceFiles {
 		file, err := c.compileFile(thisCtx, sourceFile)
@@ -79,7 +82,10 @@ func (c *Convert) CompileFile(file *lang.SourceFile) (*descriptor.File, error) {
 }
 
 func (c *Convert) compilePackage(ctx context.Context, pkg *lang.Package) error {
-	thisCtx := context.WithType(ctx, pkg)
+	thisCtx, err := withCustomOptions(context.WithType(ctx, pkg), pkg)
+	if err != nil {
+		return err
+	}
 
 	for _, sourceFile := range pkg.SourceFiles {
 		file, err := c.compileFile(thisCtx, sourceFile)
@@ -125,7 +131,7 @@ func (c *Convert) compileFile(ctx context.Context, file *lang.SourceFile) (*desc
 
 			switch decl.Declaration.(type) {
 			case *lang.Declaration_TypeAliasDecl:
-				if _, ok := systemMessages[decl.GetName()]; ok {
+				if _, ok := systemMessages[decl.GetName()]; ok || isMappedDecl(file, decl) {
 					continue
 				}
 
@@ -133,7 +139,7 @@ func (c *Convert) compileFile(ctx context.Context, file *lang.SourceFile) (*desc
 					return nil, err
 				}
 			case *lang.Declaration_StructDecl:
-				if _, ok := systemMessages[decl.GetName()]; ok {
+				if _, ok := systemMessages[decl.GetName()]; ok || isMappedDecl(file, decl) {
 					continue
 				}
 
@@ -141,6 +147,9 @@ func (c *Convert) compileFile(ctx context.Context, file *lang.SourceFile) (*desc
 					return nil, err
 				}
 			case *lang.Declaration_EnumDecl:
+				if isMappedDecl(file, decl) {
+					continue
+				}
 				if err := c.compileEnum(thisCtx, decl.GetEnumDecl(), descriptor.NewEnum(fileDescriptor)); err != nil {
 					return nil, err
 				}
@@ -148,12 +157,22 @@ func (c *Convert) compileFile(ctx context.Context, file *lang.SourceFile) (*desc
 				if err := c.compileInterface(thisCtx, decl.GetInterfaceDecl(), descriptor.NewService(fileDescriptor)); err != nil {
 					return nil, err
 				}
+			case *lang.Declaration_AttributeDecl:
+				if err := (Attribute{}).Compile(thisCtx, decl.GetAttributeDecl(), fileDescriptor); err != nil {
+					return nil, err
+				}
 			}
 		case *lang.Statement_Expression:
 		default:
 		}
 	}
 
+	// only the file declaring the options alone is printed in proto2 with the extend blocks, which could be parsed back
+	if len(fileDescriptor.Proto.GetExtension()) > 0 &&
+		len(fileDescriptor.Messages)+len(fileDescriptor.Enums)+len(fileDescriptor.Services) > 0 {
+		return nil, fmt.Errorf("the attributes compiled to the protobuf options should be declared in a file without the other types, move them out of %s", file.FullName)
+	}
+
 	// compile imports
 	if err := c.compileImport(file, fileDescriptor); err != nil {
 		return nil, err
@@ -162,6 +181,20 @@ func (c *Convert) compileFile(ctx context.Context, file *lang.SourceFile) (*desc
 	return fileDescriptor, nil
 }
 
+// withCustomOptions index the custom options declared in the package and its dependencies once
+// for the top package compiling
+func withCustomOptions(ctx context.Context, pkg *lang.Package) (context.Context, error) {
+	if ctx.Value(customOptionsKey) != nil {
+		return ctx, nil
+	}
+
+	options, err := newCustomOptions(pkg)
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValues(ctx, customOptionsKey, options), nil
+}
+
 func (c *Convert) compilePackageDecl(ctx context.Context, file *lang.SourceFile, descriptor *descriptor.File) error {
 	_ = ctx
 	descriptor.SetPackageName(file.PackageName)
@@ -200,7 +233,7 @@ func (c *Convert) compileFileOptions(ctx context.Context, file *lang.SourceFile,
 
 func (c *Convert) compileImport(file *lang.SourceFile, descriptor *descriptor.File) error {
 	for _, dependency := range file.ResolvedIdentifiers {
-		if dependency.IsGenericInstantiated() {
+		if dependency.IsGenericInstantiated() || mappedType(dependency.FullName) != nil {
 			continue
 		}
 
@@ -1,10 +1,14 @@
 package converter
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/mojo-lang/mojo/go/pkg/config"
 	"github.com/mojo-lang/mojo/go/pkg/context"
 	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
 	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser/semantic"
@@ -30,3 +34,106 @@ func TestConvert_CompilePackages(t *testing.T) {
 		}
 	}
 }
+
+func TestConvert_CompilePackage_TypeMappings(t *testing.T) {
+	config.Get().SetTypeMapping(config.ProtobufTypeMappingTarget, "mojo.core.BoolValue", &config.TypeMapping{Type: "google.protobuf.BoolValue"})
+	config.Get().SetTypeMapping(config.ProtobufTypeMappingTarget, "mojo.core.Float64Value", &config.TypeMapping{Type: "double"})
+	t.Cleanup(func() { delete(config.Get().Types, config.ProtobufTypeMappingTarget) })
+
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "../testdata/mojo-test")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	compiler := New()
+	if !assert.NoError(t, compiler.CompilePackage(context.Empty(), pkg)) {
+		t.FailNow()
+	}
+	files := compiler.Descriptors.Filter(pkg.FullName, false)
+	if !assert.Equal(t, 1, len(files)) {
+		t.FailNow()
+	}
+
+	file := files[0].Proto
+	fields := file.MessageType[0].Field
+	assert.Equal(t, "google.protobuf.BoolValue", fields[1].GetTypeName())
+	assert.Equal(t, "double", fields[2].GetTypeName())
+	assert.Equal(t, []string{"google/protobuf/wrappers.proto"}, file.Dependency)
+
+	// the mapped message is neither linked nor imported
+	config.Get().SetTypeMapping(config.ProtobufTypeMappingTarget, "mojo.core.BoolValue", &config.TypeMapping{Type: "acme.Flag"})
+	assert.ErrorContains(t, New().CompilePackage(context.Empty(), pkg), "the proto file of the mapped type acme.Flag is not found")
+}
+
+// newOptionPackage copy the mojo-option package to a temporary directory with the source files replaced
+func newOptionPackage(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	source := "../../mojo/testdata/mojo-option"
+	for _, name := range []string{"package.mojo", "mojo/test/account.mojo", "mojo/test/options.mojo"} {
+		content, ok := files[name]
+		if !ok {
+			bytes, err := os.ReadFile(filepath.Join(source, name))
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+			content = string(bytes)
+		}
+		if len(content) == 0 {
+			continue
+		}
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func compileOptionPackage(t *testing.T, dir string) error {
+	// the package path is parsed relative to the working directory
+	wd, err := os.Getwd()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	path, err := filepath.Rel(wd, dir)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return New().CompilePackage(context.Empty(), pkg)
+}
+
+func TestConvert_CompilePackage_MixedOptions(t *testing.T) {
+	options, err := os.ReadFile("../../mojo/testdata/mojo-option/mojo/test/options.mojo")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	account, err := os.ReadFile("../../mojo/testdata/mojo-option/mojo/test/account.mojo")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	dir := newOptionPackage(t, map[string]string{
+		"mojo/test/account.mojo": string(options) + "\n" + string(account),
+		"mojo/test/options.mojo": "",
+	})
+	assert.ErrorContains(t, compileOptionPackage(t, dir), "should be declared in a file without the other types")
+}
+
+func TestConvert_CompilePackage_OptionOutOfRange(t *testing.T) {
+	account, err := os.ReadFile("../../mojo/testdata/mojo-option/mojo/test/account.mojo")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	for _, value := range []string{"4294967296", "-2147483649"} {
+		dir := newOptionPackage(t, map[string]string{
+			"mojo/test/account.mojo": strings.Replace(string(account), "@max_size(64)", "@max_size("+value+")", 1),
+		})
+		assert.ErrorContains(t, compileOptionPackage(t, dir), "out of the range of int32")
+	}
+}
@@ -38,7 +38,7 @@ func (e Enum) ConvertTo(ctx context.Context, decl *lang.EnumDecl, enum *descript
 	file := context.FileDescriptor(thisCtx)
 	if message == nil && file != nil {
 		if register, ok := ctx.Value("register_enum").(bool); !ok || register {
-			file.Enums = append(file.Enums, enum)
+			file.AppendEnum(enum)
 		}
 	}
 
@@ -4,8 +4,11 @@ import (
 	"github.com/mojo-lang/core/go/pkg/logs"
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
 	"github.com/mojo-lang/mojo/go/pkg/protobuf/decompiler"
 )
 
@@ -39,7 +42,7 @@ func (i Interface) Compile(ctx context.Context, decl *lang.InterfaceDecl, descri
 
 	file := context.FileDescriptor(ctx)
 	if file != nil {
-		file.Services = append(file.Services, descriptor)
+		file.AppendService(descriptor)
 	}
 	return nil
 }
@@ -67,6 +70,7 @@ func (i Interface) compileMethod(ctx context.Context, method *lang.FunctionDecl,
 			return logs.NewErrorw("failed to compile request", "request", req.Name, "method", method.Name, "service", service.FullName, "error", err.Error())
 		}
 		m.Input = input
+		m.Proto.InputType = proto.String("." + input.GetFullName())
 
 		if resp.Implicit && resp.PackageName == file.GetPackageName() {
 			output = descriptor.NewMessage(file)
@@ -81,6 +85,23 @@ func (i Interface) compileMethod(ctx context.Context, method *lang.FunctionDecl,
 			return logs.NewErrorw("failed to compile response", "response", resp.GetFullName(), "method", method.Name, "service", service.FullName, "error", err.Error())
 		}
 		m.Output = output
+		m.Proto.OutputType = proto.String("." + output.GetFullName())
+	}
+
+	if compiler.IsClientStreamingMethod(method) {
+		m.Proto.ClientStreaming = proto.Bool(true)
+	}
+	if compiler.IsServerStreamingMethod(method) {
+		m.Proto.ServerStreaming = proto.Bool(true)
+	}
+
+	if err := setCustomOptions(ctx, method.Attributes, MethodOptionsExtendee, func() proto.Message {
+		if m.Proto.Options == nil {
+			m.Proto.Options = &descriptorpb.MethodOptions{}
+		}
+		return m.Proto.Options
+	}); err != nil {
+		return err
 	}
 
 	service.AppendMethod(m)
@@ -42,6 +42,20 @@ func (n Nominal) Compile(ctx context.Context, t *lang.NominalType) (string, stri
 		}
 	}
 
+	if mapping := mappedType(t.GetFullName()); mapping != nil {
+		if file := context.FileDescriptor(ctx); file != nil {
+			fileName, err := mappedTypeImport(mapping)
+			if err != nil {
+				return "", "", err
+			}
+			if len(fileName) > 0 {
+				file.AppendDependency(fileName)
+			}
+		}
+		tp, typeName := compileMappedType(mapping)
+		return tp, typeName, nil
+	}
+
 	if t.IsScalar() {
 		return "Scalar", t.GetFullName(), nil
 	}
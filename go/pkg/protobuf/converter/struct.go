@@ -8,7 +8,9 @@ import (
 	"github.com/mojo-lang/core/go/pkg/logs"
 	"github.com/mojo-lang/core/go/pkg/mojo/core"
 	"github.com/mojo-lang/db/go/pkg/mojo/db"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/runtime/protoimpl"
+	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/mojo-lang/core/go/pkg/mojo"
 	"github.com/mojo-lang/core/go/pkg/mojo/core/strcase"
@@ -86,6 +88,15 @@ func (s Struct) Compile(ctx context.Context, decl *lang.StructDecl, structDescri
 		}
 	}
 
+	if err := setCustomOptions(thisCtx, decl.Attributes, MessageOptionsExtendee, func() proto.Message {
+		if structDescriptor.Proto.Options == nil {
+			structDescriptor.Proto.Options = &descriptorpb.MessageOptions{}
+		}
+		return structDescriptor.Proto.Options
+	}); err != nil {
+		return err
+	}
+
 	switch structDescriptor.GetName() {
 	case core.BoolValuesTypeName, core.StringValuesTypeName,
 		core.Int32ValuesTypeName, core.UInt32ValuesTypeName,
@@ -109,7 +120,7 @@ func (s Struct) Compile(ctx context.Context, decl *lang.StructDecl, structDescri
 			}
 		} else if file != nil {
 			if !file.IsMessageExist(structDescriptor.GetName()) {
-				file.Messages = append(file.Messages, structDescriptor)
+				file.AppendMessage(structDescriptor)
 			}
 		}
 	}
@@ -148,7 +159,7 @@ func (s Struct) compileStructInherit(ctx context.Context, inherit *lang.NominalT
 
 	for _, dependency := range decl.ResolvedIdentifiers {
 		fileName := unifyFileName(dependency.SourceFileName)
-		if file != nil && !IsSystemFile(fileName) && fileName != file.GetName() /*&& !sourceFiles[fileName]*/ {
+		if file != nil && !IsSystemFile(fileName) && fileName != file.GetName() && mappedType(dependency.FullName) == nil /*&& !sourceFiles[fileName]*/ {
 			file.AppendDependency(fileName)
 		}
 	}
@@ -282,6 +293,15 @@ func (s Struct) compileStructFields(ctx context.Context, fields []*lang.ValueDec
 			addOptionsDependency(fieldCtx)
 		}
 
+		if err := setCustomOptions(fieldCtx, append(field.Attributes, field.Type.Attributes...), FieldOptionsExtendee, func() proto.Message {
+			if member.Proto.Options == nil {
+				member.Proto.Options = &descriptorpb.FieldOptions{}
+			}
+			return member.Proto.Options
+		}); err != nil {
+			return err
+		}
+
 		msgDescriptor.AppendField(member)
 	}
 	return nil
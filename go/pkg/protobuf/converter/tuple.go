@@ -62,6 +62,6 @@ func (p *Tuple) ConvertTo(ctx context.Context, t *lang.NominalType) (string, str
 		return "", "", errors.New(fmt.Sprintf("failed to compile struct: %s", err.Error()))
 	}
 
-	file.Messages = append(file.Messages, message)
+	file.AppendMessage(message)
 	return "struct", s.Name, nil
 }
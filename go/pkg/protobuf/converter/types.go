@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	// register the well-known types for looking up the files of the mapped types
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/parser/semantic"
+)
+
+// mappedType the proto type which the mojo type is mapped to by the `types.protobuf` in the mojo.yaml,
+// the declaration of the mapped mojo type will not be compiled to the proto file
+func mappedType(fullName string) *config.TypeMapping {
+	return config.Get().GetTypeMapping(config.ProtobufTypeMappingTarget, fullName)
+}
+
+func isMappedDecl(file *lang.SourceFile, decl *lang.Declaration) bool {
+	return mappedType(lang.GetFullName(file.GetPackageName(), nil, decl.GetName())) != nil
+}
+
+// compileMappedType the type and the type name of the mapped proto type, same as the Nominal.Compile returns
+func compileMappedType(mapping *config.TypeMapping) (string, string) {
+	if semantic.IsScalarType(mapping.Type) {
+		return "Scalar", mapping.Type
+	}
+	return "Struct", mapping.Type
+}
+
+// mappedTypeImport the proto file declaring the mapped type, looked up in the linked proto files
+// like the well-known types if not configured
+func mappedTypeImport(mapping *config.TypeMapping) (string, error) {
+	if len(mapping.Import) > 0 || semantic.IsScalarType(mapping.Type) {
+		return mapping.Import, nil
+	}
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(strings.TrimPrefix(mapping.Type, ".")))
+	if err != nil {
+		return "", fmt.Errorf("the proto file of the mapped type %s is not found, set the import of the type mapping", mapping.Type)
+	}
+	return d.ParentFile().Path(), nil
+}
@@ -66,7 +66,7 @@ func CompileMethodRequest(ctx context.Context, method *lang.FunctionDecl) (*lang
 
 	pagination, _ := lang.GetBoolAttribute(method.Attributes, core.PaginationAttributeName)
 	if pagination {
-		req.Type.MergeFields(compiler.PaginationRequestFields())
+		req.Type.MergeFields(compiler.PaginationRequestFieldsOf(method))
 	}
 
 	if query, _ := lang.GetBoolAttribute(method.Attributes, core.QueryAttributeName); query {
@@ -1,26 +1,70 @@
 package formatter
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+
 	"github.com/mojo-lang/mojo/go/pkg/context"
 	"github.com/mojo-lang/mojo/go/pkg/protobuf/parser/syntax"
 	"github.com/mojo-lang/mojo/go/pkg/protobuf/printer"
 )
 
+// Formatter formats the proto files, the formatted file is rewritten in place
 type Formatter struct {
+	// backup the original proto file with the .back suffix before rewriting it
+	Backup bool
 }
 
 func New() *Formatter {
 	return &Formatter{}
 }
 
+// FormatFile formats the proto file, the file is left untouched if it has been formatted
 func (f *Formatter) FormatFile(ctx context.Context, filename string) error {
-	return nil
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	formatted, err := f.FormatString(ctx, string(content))
+	if err != nil {
+		return logs.NewErrorw("failed to format the proto file", "file", filename, "error", err.Error())
+	}
+	if formatted == string(content) {
+		return nil
+	}
+
+	if f.Backup {
+		if err = os.WriteFile(filename+".back", content, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	logs.Infow("format the proto file", "file", filename)
+	return os.WriteFile(filename, []byte(formatted), info.Mode().Perm())
 }
 
+// FormatPath formats all the proto files under the path, or the proto file if the path is a file
 func (f *Formatter) FormatPath(ctx context.Context, path string) error {
-	return nil
+	return filepath.Walk(path, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(name, ".proto") {
+			return nil
+		}
+		return f.FormatFile(ctx, name)
+	})
 }
 
+// FormatString formats the content of the proto file
 func (f *Formatter) FormatString(ctx context.Context, content string) (string, error) {
 	file, err := syntax.New(nil).ParseString(ctx, content)
 	if err != nil {
@@ -32,5 +76,13 @@ func (f *Formatter) FormatString(ctx context.Context, content string) (string, e
 		return content, p.Error
 	}
 
-	return p.Buffer.String(), nil
+	// the formatted file should be parsed back with nothing dropped before rewriting the proto file
+	formatted := p.Buffer.String()
+	if err = checkTokens(content, formatted); err != nil {
+		return content, err
+	}
+	if _, err = syntax.New(nil).ParseString(ctx, formatted); err != nil {
+		return content, fmt.Errorf("failed to parse the formatted file: %s", err.Error())
+	}
+	return formatted, nil
 }
@@ -0,0 +1,152 @@
+package formatter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+const unformattedProto = `// the library service
+syntax="proto3";
+package acme.library.v1;
+import   "google/protobuf/timestamp.proto";
+option go_package="github.com/acme/library;library";
+
+// Book the book in the library
+message Book {
+  string name = 1; // the resource name
+  repeated string authors=3;
+    map<string,int32> labels = 6;
+
+  oneof source {
+  string isbn = 8;
+  string url = 9 [json_name="URL"];
+  }
+  google.protobuf.Timestamp publish_time = 16 [deprecated=true];
+  enum Format { FORMAT_UNSPECIFIED = 0; FORMAT_HARDCOVER = 1; }
+}
+
+service Library {
+  // get the book
+  rpc GetBook(Book) returns (Book) { option deprecated = true; }
+  rpc WatchBooks(stream Book) returns (stream Book);
+}
+`
+
+const formattedProto = `// the library service
+syntax = "proto3";
+
+package acme.library.v1;
+
+import "google/protobuf/timestamp.proto";
+
+option go_package = "github.com/acme/library;library";
+
+// Book the book in the library
+message Book {
+    enum Format {
+        FORMAT_UNSPECIFIED = 0;
+        FORMAT_HARDCOVER   = 1;
+    }
+
+    string             name    = 1; // the resource name
+    repeated string    authors = 3;
+    map<string, int32> labels  = 6;
+
+    oneof source {
+        string isbn = 8;
+        string url  = 9 [json_name = "URL"];
+    }
+    google.protobuf.Timestamp publish_time = 16 [deprecated = true];
+}
+
+service Library {
+    // get the book
+    rpc GetBook(Book) returns (Book) {
+        option deprecated = true;
+    }
+
+    rpc WatchBooks(stream Book) returns (stream Book);
+}
+`
+
+func TestFormatter_FormatString(t *testing.T) {
+	formatted, err := New().FormatString(context.Empty(), unformattedProto)
+	assert.NoError(t, err)
+	assert.Equal(t, formattedProto, formatted)
+
+	formatted, err = New().FormatString(context.Empty(), formattedProto)
+	assert.NoError(t, err)
+	assert.Equal(t, formattedProto, formatted)
+}
+
+func TestFormatter_FormatPath(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "acme", "library.proto")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	assert.NoError(t, os.WriteFile(name, []byte(unformattedProto), 0o644))
+
+	formatter := New()
+	formatter.Backup = true
+	assert.NoError(t, formatter.FormatPath(context.Empty(), dir))
+
+	content, err := os.ReadFile(name)
+	assert.NoError(t, err)
+	assert.Equal(t, formattedProto, string(content))
+
+	backup, err := os.ReadFile(name + ".back")
+	assert.NoError(t, err)
+	assert.Equal(t, unformattedProto, string(backup))
+}
+
+const proto2Group = `syntax = "proto2";
+package acme.search;
+message SearchResponse {
+  repeated group Result = 1 {
+    required string url = 2;
+    optional string title = 3;
+  }
+  optional int32 total = 4;
+  optional double max_score = 5 [default = inf];
+  optional double min_score = 6 [default = -inf];
+  optional float ratio = 7 [default = nan];
+}
+`
+
+const formattedProto2Group = `syntax = "proto2";
+
+package acme.search;
+
+message SearchResponse {
+    repeated group Result = 1 {
+        required string url   = 2;
+        optional string title = 3;
+    }
+    optional int32  total     = 4;
+    optional double max_score = 5 [default = inf];
+    optional double min_score = 6 [default = -inf];
+    optional float  ratio     = 7 [default = nan];
+}
+`
+
+func TestFormatter_FormatString_Proto2(t *testing.T) {
+	formatted, err := New().FormatString(context.Empty(), proto2Group)
+	assert.NoError(t, err)
+	assert.Equal(t, formattedProto2Group, formatted)
+
+	formatted, err = New().FormatString(context.Empty(), formattedProto2Group)
+	assert.NoError(t, err)
+	assert.Equal(t, formattedProto2Group, formatted)
+}
+
+func TestCheckTokens(t *testing.T) {
+	assert.NoError(t, checkTokens(unformattedProto, formattedProto))
+
+	dropped := strings.Replace(formattedProto, "    repeated string    authors = 3;\n", "", 1)
+	assert.ErrorContains(t, checkTokens(unformattedProto, dropped), "the tokens are dropped: 3 = authors repeated string")
+}
@@ -0,0 +1,98 @@
+package formatter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/parser/syntax"
+	proto2 "github.com/mojo-lang/mojo/go/pkg/protobuf2/parser/syntax"
+	proto3 "github.com/mojo-lang/mojo/go/pkg/protobuf3/parser/syntax"
+)
+
+// tokenTypes the token types of the lexer normalized or skipped in the comparing
+type tokenTypes struct {
+	str     int
+	integer int
+	float   int
+	skipped map[int]bool
+}
+
+// tokens the significant tokens of the proto file counted, the literals are normalized to their values
+// and the separators are skipped, which are not kept as they are by the printer
+func tokens(content string) map[string]int {
+	var lexer antlr.Lexer
+	var types tokenTypes
+	if syntax.IsProto3(content) {
+		lexer = proto3.NewProtobuf3Lexer(antlr.NewInputStream(content))
+		types = tokenTypes{
+			str:     proto3.Protobuf3LexerSTR_LIT,
+			integer: proto3.Protobuf3LexerINT_LIT,
+			float:   proto3.Protobuf3LexerFLOAT_LIT,
+			skipped: map[int]bool{proto3.Protobuf3LexerSEMI: true, proto3.Protobuf3LexerCOMMA: true},
+		}
+	} else {
+		lexer = proto2.NewProtobuf2Lexer(antlr.NewInputStream(content))
+		types = tokenTypes{
+			str:     proto2.Protobuf2LexerSTR_LIT,
+			integer: proto2.Protobuf2LexerINT_LIT,
+			float:   proto2.Protobuf2LexerFLOAT_LIT,
+			skipped: map[int]bool{proto2.Protobuf2LexerSEMI: true, proto2.Protobuf2LexerCOMMA: true},
+		}
+	}
+	lexer.RemoveErrorListeners()
+
+	counts := make(map[string]int)
+	for token := lexer.NextToken(); token.GetTokenType() != antlr.TokenEOF; token = lexer.NextToken() {
+		text := token.GetText()
+		switch typ := token.GetTokenType(); {
+		case types.skipped[typ]:
+			continue
+		case typ == types.str && len(text) >= 2:
+			text = strings.NewReplacer(`\"`, `"`, `\'`, `'`).Replace(text[1 : len(text)-1])
+		case typ == types.integer:
+			if value, err := strconv.ParseUint(text, 0, 64); err == nil {
+				text = strconv.FormatUint(value, 10)
+			}
+		case typ == types.float:
+			if value, err := strconv.ParseFloat(text, 64); err == nil {
+				text = strconv.FormatFloat(value, 'g', -1, 64)
+			}
+		}
+		counts[text]++
+	}
+	return counts
+}
+
+// checkTokens checks the formatted content keeps the tokens of the proto file, the elements not modeled
+// by the parser are dropped in the formatting otherwise. the optional labels are added to the proto2 fields
+func checkTokens(content string, formatted string) error {
+	expected := tokens(content)
+	actual := tokens(formatted)
+
+	var missing []string
+	for token, count := range expected {
+		if actual[token] < count {
+			missing = append(missing, token)
+		}
+	}
+	var added []string
+	for token, count := range actual {
+		if expected[token] < count && token != "optional" {
+			added = append(added, token)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(added)
+
+	if len(missing) > 0 {
+		return fmt.Errorf("the elements are not supported by the formatter, the tokens are dropped: %s", strings.Join(missing, " "))
+	}
+	if len(added) > 0 {
+		return fmt.Errorf("the formatted file has the unexpected tokens: %s", strings.Join(added, " "))
+	}
+	return nil
+}
@@ -116,16 +116,19 @@ func (g *Generator) GeneratePackage(pkg *lang.Package) ([]*util.GeneratedFile, e
 }
 
 func (g *Generator) writeGeneratedFiles(files []*util.GeneratedFile, out string) error {
-	if len(files) > 0 {
-		guard := &util.PathGuard{
-			OnlyClearGenerated: true,
-			Suffixes:           []string{".proto"},
-		}
+	guard := &util.PathGuard{
+		OnlyClearGenerated: true,
+		Suffixes:           []string{".proto"},
+		Target:             "protobuf",
+	}
+	// record the output even without any file, so the stale files are pruned
+	if err := guard.Record(out); err != nil {
+		return err
+	}
 
-		for _, file := range files {
-			if err := file.WriteTo(out, guard); err != nil {
-				return err
-			}
+	for _, file := range files {
+		if err := file.WriteTo(out, guard); err != nil {
+			return err
 		}
 	}
 	return nil
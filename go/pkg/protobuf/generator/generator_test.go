@@ -3,7 +3,10 @@ package generator
 import (
 	"testing"
 
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
 	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
@@ -11,6 +14,8 @@ import (
 	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
 	"github.com/mojo-lang/mojo/go/pkg/plugin"
 	"github.com/mojo-lang/mojo/go/pkg/protobuf/converter"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/parser/semantic"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/parser/syntax"
 )
 
 func TestGenerator_GenerateDescriptors(t *testing.T) {
@@ -28,3 +33,115 @@ func TestGenerator_GenerateDescriptors(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerator_GenerateDescriptors_ProtoSource(t *testing.T) {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "../../mojo/testdata/mojo-proto")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	compiler := converter.New()
+	if !assert.NoError(t, compiler.CompilePackage(context.Empty(), pkg)) {
+		t.FailNow()
+	}
+
+	messages := make(map[string]*descriptorpb.DescriptorProto)
+	for _, file := range compiler.Descriptors.Filter(pkg.FullName, false) {
+		for _, message := range file.Proto.MessageType {
+			messages[message.GetName()] = message
+		}
+	}
+
+	user := messages["User"]
+	if assert.NotNil(t, user) {
+		assert.Equal(t, "Address", user.Field[1].GetTypeName())
+	}
+	address := messages["Address"]
+	if assert.NotNil(t, address) {
+		assert.Equal(t, "mojo.core.Timestamp", address.Field[2].GetTypeName())
+		assert.Equal(t, "User", address.Field[3].GetTypeName())
+		assert.Equal(t, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, address.Field[3].GetLabel())
+	}
+}
+
+func TestGenerator_GenerateDescriptors_Stream(t *testing.T) {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "../../mojo/testdata/mojo-stream")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	compiler := converter.New()
+	if !assert.NoError(t, compiler.CompilePackage(context.Empty(), pkg)) {
+		t.FailNow()
+	}
+
+	out, err := New().GenerateDescriptors(compiler.Descriptors.Filter(pkg.FullName, false))
+	if !assert.NoError(t, err) || !assert.Len(t, out, 1) {
+		t.FailNow()
+	}
+
+	content := out[0].Content
+	assert.Contains(t, content, "rpc upload(stream UploadRequest) returns (UploadSummary);")
+	assert.Contains(t, content, "rpc watch(WatchRequest) returns (stream Message);")
+	assert.Contains(t, content, "rpc chat(stream ChatRequest) returns (stream Message);")
+	assert.Contains(t, content, "rpc get_message(GetMessageRequest) returns (Message);")
+}
+
+func TestGenerator_GenerateDescriptors_CustomOptions(t *testing.T) {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "../../mojo/testdata/mojo-option")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	compiler := converter.New()
+	if !assert.NoError(t, compiler.CompilePackage(context.Empty(), pkg)) {
+		t.FailNow()
+	}
+
+	out, err := New().GenerateDescriptors(compiler.Descriptors.Filter(pkg.FullName, false))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	contents := make(map[string]string)
+	for _, file := range out {
+		contents[file.Name] = file.Content
+	}
+
+	options := contents["test/options.proto"]
+	assert.Contains(t, options, `syntax = "proto2";`)
+	assert.Contains(t, options, `import "google/protobuf/descriptor.proto";`)
+	assert.Contains(t, options, "extend google.protobuf.FieldOptions {\n    optional bool sensitive = 50001;\n    optional int32 max_size = 50002;\n}")
+	assert.Contains(t, options, "extend google.protobuf.MessageOptions {\n    optional string table = 50003;\n}")
+	assert.Contains(t, options, "extend google.protobuf.MethodOptions {\n    optional string permission = 50004;\n}")
+
+	// the options file is parsed back to the attribute declarations
+	source, err := syntax.New(nil).ParseString(context.Empty(), options)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	source.Name = "options.proto"
+	file, err := semantic.ConvertSource(source)
+	if !assert.NoError(t, err) || !assert.Len(t, file.Statements, 4) {
+		t.FailNow()
+	}
+	sensitive := file.Statements[0].GetDeclaration().GetAttributeDecl()
+	if assert.NotNil(t, sensitive) {
+		assert.Equal(t, "sensitive", sensitive.Name)
+		assert.Equal(t, "mojo.core.Bool", sensitive.GetNominalType().GetFullName())
+		number, _ := lang.GetIntegerAttribute(sensitive.GetNominalType().Attributes, core.NumberAttributeName)
+		assert.Equal(t, int64(50001), number)
+	}
+	table := file.Statements[2].GetDeclaration().GetAttributeDecl()
+	if assert.NotNil(t, table) {
+		assert.Equal(t, "type", table.Attributes[0].Arguments[0].GetValue().GetIdentifierExpr().GetName())
+	}
+
+	account := contents["test/account.proto"]
+	assert.Contains(t, account, `import "test/options.proto";`)
+	assert.Contains(t, account, `option (test.table) = "accounts";`)
+	assert.Contains(t, account, `string password = 2 [(test.sensitive)=true, (test.max_size)=64];`)
+	assert.Contains(t, account, `option (test.permission) = "account.read";`)
+}
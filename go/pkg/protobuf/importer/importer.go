@@ -0,0 +1,233 @@
+package importer
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/printer"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/parser/semantic"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/parser/syntax"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+const defaultVersion = "0.1.0"
+
+// Importer imports a tree of the proto files into a mojo package, the proto packages become the
+// sub packages of the mojo package, which lies in the `mojo/<package path>` directories
+type Importer struct {
+	// the directory of the proto files, the import paths are relative to it
+	Path string
+
+	// the mojo package name, the common prefix of the proto packages if not specified
+	PackageName string
+
+	// the version in the package.mojo, 0.1.0 if not specified
+	Version string
+
+	// the type mappings between the mojo and the proto types, keyed by the full name of the mojo type
+	TypeMappings map[string]*config.TypeMapping
+
+	files []*semantic.File
+}
+
+func New(path string) *Importer {
+	return &Importer{Path: path}
+}
+
+// Import parse the proto files and convert them to the mojo source files, the full name of the
+// source file is the path relative to the package root, including the `package.mojo`
+func (i *Importer) Import(ctx context.Context) ([]*lang.SourceFile, error) {
+	if err := i.load(ctx); err != nil {
+		return nil, err
+	}
+	if err := i.checkImports(); err != nil {
+		return nil, err
+	}
+
+	pkgName, err := i.packageName()
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := semantic.NewConverter(i.files...).WithTypeMappings(i.TypeMappings).Convert()
+	if err != nil {
+		return nil, err
+	}
+
+	sourceFiles := []*lang.SourceFile{i.packageFile(pkgName)}
+	for index, sourceFile := range converted {
+		if len(sourceFile.Statements) > 0 {
+			sourceFile.FullName = sourceFileName(i.files[index])
+			sourceFiles = append(sourceFiles, sourceFile)
+		}
+	}
+	return sourceFiles, nil
+}
+
+// Generate import the proto files and print them to the mojo source files
+func (i *Importer) Generate(ctx context.Context) (util.GeneratedFiles, error) {
+	sourceFiles, err := i.Import(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files util.GeneratedFiles
+	for _, sourceFile := range sourceFiles {
+		p := printer.New(&printer.Config{}).PrintSourceFile(context.WithType(ctx, sourceFile), sourceFile)
+		if err = p.GetError(); err != nil {
+			logs.Errorw("failed to print the mojo file", "file", sourceFile.FullName, "error", err.Error())
+			return nil, err
+		}
+
+		content := p.Buffer.String()
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		files = append(files, &util.GeneratedFile{Name: sourceFile.FullName, Content: content})
+	}
+	return files, nil
+}
+
+func (i *Importer) load(ctx context.Context) error {
+	parser := syntax.New(nil)
+	err := filepath.WalkDir(i.Path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != i.Path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(p) != ".proto" {
+			return nil
+		}
+
+		name, err := filepath.Rel(i.Path, p)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if semantic.IsWellKnownFile(name) {
+			logs.Infow("skip the well-known proto file", "file", name)
+			return nil
+		}
+
+		source, err := parser.ParseFile(ctx, p)
+		if err != nil {
+			return fmt.Errorf("failed to parse the proto file %s: %w", name, err)
+		}
+		file := semantic.NewFile(name, source)
+		i.files = append(i.files, file)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(i.files) == 0 {
+		return fmt.Errorf("no proto file found in %s", i.Path)
+	}
+	sort.Slice(i.files, func(x, y int) bool {
+		return i.files[x].Name < i.files[y].Name
+	})
+	return nil
+}
+
+func (i *Importer) checkImports() error {
+	names := make(map[string]bool)
+	for _, file := range i.files {
+		names[file.Name] = true
+	}
+
+	for _, file := range i.files {
+		for _, statement := range file.Source.Statements {
+			if decl := statement.GetDeclaration().GetImportDecl(); decl != nil {
+				if !names[decl.ImportFileName] && !semantic.IsWellKnownFile(decl.ImportFileName) {
+					return fmt.Errorf("failed to resolve the proto file %s imported by %s", decl.ImportFileName, file.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// packageName the specified package name, or the common prefix of the proto packages,
+// the proto files without package will be placed in the mojo package
+func (i *Importer) packageName() (string, error) {
+	name := i.PackageName
+	if len(name) > 0 {
+		for _, file := range i.files {
+			if len(file.Package) > 0 && file.Package != name && !strings.HasPrefix(file.Package, name+".") {
+				return "", fmt.Errorf("the package %s of the proto file %s is not in the package %s", file.Package, file.Name, name)
+			}
+		}
+	} else {
+		var prefix []string
+		for _, file := range i.files {
+			if len(file.Package) == 0 {
+				continue
+			}
+			segments := strings.Split(file.Package, ".")
+			if prefix == nil {
+				prefix = segments
+				continue
+			}
+			l := 0
+			for l < len(prefix) && l < len(segments) && prefix[l] == segments[l] {
+				l++
+			}
+			prefix = prefix[:l]
+		}
+
+		name = strings.Join(prefix, ".")
+		if len(name) == 0 {
+			return "", fmt.Errorf("the proto packages in %s have no common prefix, please specify the mojo package name", i.Path)
+		}
+	}
+
+	for _, file := range i.files {
+		if len(file.Package) == 0 {
+			file.Package = name
+		}
+	}
+	return name, nil
+}
+
+func (i *Importer) packageFile(name string) *lang.SourceFile {
+	version := i.Version
+	if len(version) == 0 {
+		version = defaultVersion
+	}
+
+	decl := &lang.PackageDecl{
+		Name: name,
+		PackageLiteralExpr: &lang.ObjectLiteralExpr{
+			Fields: []*lang.ObjectLiteralExpr_Field{{
+				Name:  "version",
+				Value: lang.NewStringLiteralExpressionFrom(version),
+			}},
+		},
+	}
+	return &lang.SourceFile{
+		Name:       "package.mojo",
+		FullName:   "package.mojo",
+		Statements: []*lang.Statement{lang.NewPackageDeclStatement(decl)},
+	}
+}
+
+// sourceFileName the mojo source file for the proto file, in the directory of its package
+func sourceFileName(file *semantic.File) string {
+	name := strings.TrimSuffix(path.Base(file.Name), ".proto") + ".mojo"
+	return path.Join("mojo", strings.ReplaceAll(file.Package, ".", "/"), name)
+}
@@ -0,0 +1,129 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/converter"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/generator"
+)
+
+func TestImporter_Generate(t *testing.T) {
+	files, err := New("./testdata/library").Generate(context.Empty())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	dir := t.TempDir()
+	contents := make(map[string]string)
+	for _, file := range files {
+		contents[file.Name] = file.Content
+		name := filepath.Join(dir, file.Name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		assert.NoError(t, os.WriteFile(name, []byte(file.Content), 0o644))
+	}
+
+	assert.Contains(t, contents["package.mojo"], "package acme {")
+
+	options := contents["mojo/acme/common/v1/options.mojo"]
+	assert.Contains(t, options, "/// the field can be searched by the full text\n@target(DeclType.value)\nattribute searchable: Bool @50001")
+	assert.Contains(t, options, "@target(DeclType.type)\nattribute table: String @50003")
+	assert.Contains(t, options, "@target(DeclType.function)\nattribute permission: String @50004")
+	assert.NotContains(t, options, "owner")
+	assert.Contains(t, contents["mojo/acme/common/v1/money.mojo"], "@table(\"money\")\ntype Money {")
+	assert.Contains(t, contents["mojo/acme/common/v1/money.mojo"], "currency_code: String @1 @max_length(3)")
+
+	book := contents["mojo/acme/library/v1/book.mojo"]
+	assert.Contains(t, book, "title: String @2 @acme.common.v1.searchable\n")
+	assert.Contains(t, book, "/// Book the book in the library\ntype Book {")
+	assert.Contains(t, book, "name: String @1 //< the resource name")
+	assert.Contains(t, book, "e_book      @2")
+	assert.Contains(t, book, "price: acme.common.v1.Money @5")
+	assert.Contains(t, book, "labels: {String: String} @6")
+	assert.Contains(t, book, `publish_time: Timestamp @7 @alias("published")`)
+	assert.Contains(t, book, `source: String @8 @label("isbn") | String @9 @label("url")`)
+	assert.Contains(t, book, "pages: Int32 @10 @deprecated")
+
+	library := contents["mojo/acme/library/v1/library.mojo"]
+	assert.NotContains(t, library, "type GetBookRequest")
+	assert.Contains(t, library, "type ListBooksResponse {")
+	assert.Contains(t, library, "    /// get the book by the name\n    @http.get(\"/v1/{name}\")\n    getBook(name String @1) -> Book")
+	assert.Contains(t, library, "createBook(book Book @1 @http.body, book_id String @2) -> Book")
+	assert.Contains(t, library, "@acme.common.v1.permission(\"books.delete\")\n    deleteBook(name String @1)\n")
+	assert.Contains(t, library, "watchBooks(filter String @1) -> Book @stream")
+	assert.Contains(t, library, "importBooks(request Book @stream @method_request_type) -> ImportBooksResponse")
+
+	// the package path is relative to the working directory
+	wd, _ := os.Getwd()
+	rel, err := filepath.Rel(wd, dir)
+	assert.NoError(t, err)
+
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), rel)
+	if !assert.NoError(t, err) || !assert.NotNil(t, pkg) {
+		t.FailNow()
+	}
+	assert.Equal(t, "acme", pkg.FullName)
+
+	// the attribute declarations are compiled back to the custom options
+	common := findPackage(pkg, "acme.common.v1")
+	if !assert.NotNil(t, common) {
+		t.FailNow()
+	}
+	c := converter.New()
+	if !assert.NoError(t, c.CompilePackage(context.Empty(), common)) {
+		t.FailNow()
+	}
+	out, err := generator.New().GenerateDescriptors(c.Descriptors.Filter(common.FullName, false))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	protos := make(map[string]string)
+	for _, file := range out {
+		protos[file.Name] = file.Content
+	}
+	assert.Contains(t, protos["acme/common/v1/options.proto"], "extend google.protobuf.FieldOptions {\n    optional bool searchable = 50001;\n    optional int32 max_length = 50002;\n}")
+	assert.Contains(t, protos["acme/common/v1/money.proto"], `option (acme.common.v1.table) = "money";`)
+	assert.Contains(t, protos["acme/common/v1/money.proto"], "string currency_code = 1 [(acme.common.v1.max_length)=3];")
+}
+
+func findPackage(pkg *lang.Package, fullName string) *lang.Package {
+	if pkg.FullName == fullName {
+		return pkg
+	}
+	for _, child := range pkg.Children {
+		if p := findPackage(child, fullName); p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+func TestImporter_Generate_TypeMappings(t *testing.T) {
+	imp := New("./testdata/library")
+	imp.TypeMappings = map[string]*config.TypeMapping{
+		"mojo.core.Decimal": {Type: "acme.common.v1.Money"},
+	}
+	files, err := imp.Generate(context.Empty())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	// the mappings are not merged into the global config
+	assert.Nil(t, config.Get().GetTypeMappings(config.ProtobufTypeMappingTarget))
+
+	for _, file := range files {
+		if file.Name == "mojo/acme/library/v1/book.mojo" {
+			assert.Contains(t, file.Content, "price: Decimal @5")
+		}
+	}
+}
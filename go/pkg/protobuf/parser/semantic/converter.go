@@ -0,0 +1,674 @@
+package semantic
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/core/go/pkg/mojo/core/strcase"
+	"github.com/mojo-lang/http/go/pkg/mojo/http"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+)
+
+// streamAttributeName the mojo attribute of the streamed request or response type, same as the
+// compiler.StreamAttributeName, which can not be imported here for the mojo compiler tests import this parser
+const streamAttributeName = "stream"
+
+// httpVerbs the methods of the google.api.http option to the http attributes
+var httpVerbs = map[string]string{
+	"get":    http.GetAttributeName,
+	"post":   http.PostAttributeName,
+	"put":    http.PutAttributeName,
+	"patch":  http.PatchAttributeName,
+	"delete": http.DeleteAttributeName,
+}
+
+// optionTargets the options extended by the custom options to the DeclType targets of the mojo attribute declarations
+var optionTargets = map[string]string{
+	"google.protobuf.FieldOptions":   "value",
+	"google.protobuf.MessageOptions": "type",
+	"google.protobuf.MethodOptions":  "function",
+}
+
+// the path template `{name=shelves/*}` of the google.api.http, only the variable name will be kept
+var pathVariable = regexp.MustCompile(`\{([a-zA-Z0-9_.]+)=[^}]*}`)
+
+// Converter converts the parsed proto files to the idiomatic mojo source files, the types referenced
+// are resolved by the proto scoping rules, and mapped to the mojo.core types if they are scalar or
+// well-known types, the `google.api.http` options are converted to the http attributes, and the
+// custom options declared in the extend blocks are converted to the attribute declarations
+type Converter struct {
+	// converting the proto file as a source of the mojo package, the types not declared in the
+	// proto files are left to the semantic parser, and the request messages are not inlined
+	Source bool
+
+	files      []*File
+	symbols    map[string]*symbol
+	extensions map[string]*extension
+
+	// the proto types mapped to the mojo types by the mojo.yaml
+	types map[string]string
+}
+
+func NewConverter(files ...*File) *Converter {
+	c := &Converter{
+		files:      files,
+		symbols:    make(map[string]*symbol),
+		extensions: make(map[string]*extension),
+		types:      mappedTypes(config.Get().GetTypeMappings(config.ProtobufTypeMappingTarget)),
+	}
+	for _, file := range files {
+		c.registerSymbols(file)
+	}
+	return c
+}
+
+// WithTypeMappings replaces the type mappings of the global config with the ones of the proto files converting
+func (c *Converter) WithTypeMappings(mappings map[string]*config.TypeMapping) *Converter {
+	c.types = mappedTypes(mappings)
+	return c
+}
+
+// mappedTypes the proto types to the full names of the mojo types reversed from the type mappings in
+// the mojo.yaml, which take precedence over the declared and the well-known types
+func mappedTypes(mappings map[string]*config.TypeMapping) map[string]string {
+	return config.ReverseTypeMappings(mappings, IsScalarType)
+}
+
+// ConvertSource convert the parsed proto file lying in a mojo package to the mojo source file,
+// the types not declared in the proto file are left to be resolved with the mojo sources by the
+// semantic parser of mojo
+func ConvertSource(source *lang.SourceFile) (*lang.SourceFile, error) {
+	c := NewConverter(NewFile(source.Name, source))
+	c.Source = true
+
+	sourceFiles, err := c.Convert()
+	if err != nil {
+		return nil, err
+	}
+	return sourceFiles[0], nil
+}
+
+// Convert the proto files to the mojo source files in order, the source files are named as the
+// mojo ones, and have only the declarations
+func (c *Converter) Convert() ([]*lang.SourceFile, error) {
+	if err := c.countReferences(); err != nil {
+		return nil, err
+	}
+
+	var sourceFiles []*lang.SourceFile
+	for _, file := range c.files {
+		sourceFile, err := c.convertFile(file)
+		if err != nil {
+			return nil, err
+		}
+		sourceFiles = append(sourceFiles, sourceFile)
+	}
+	return sourceFiles, nil
+}
+
+// countReferences count the times the messages referenced by the fields and the rpc methods,
+// the request message referenced only by its method will be inlined as the method parameters
+func (c *Converter) countReferences() error {
+	for _, file := range c.files {
+		for _, statement := range file.Source.Statements {
+			decl := statement.GetDeclaration()
+			if structDecl := decl.GetStructDecl(); structDecl != nil && !isExtend(structDecl) {
+				if err := c.countStructReferences(file, structDecl, []string{structDecl.Name}); err != nil {
+					return err
+				}
+			} else if interfaceDecl := decl.GetInterfaceDecl(); interfaceDecl != nil {
+				for _, method := range interfaceDecl.GetType().GetMethods() {
+					for _, param := range method.GetSignature().GetParameters() {
+						if err := c.countTypeReferences(file, nil, param.Type); err != nil {
+							return err
+						}
+					}
+					if err := c.countTypeReferences(file, nil, method.GetSignature().GetResultType()); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Converter) countStructReferences(file *File, decl *lang.StructDecl, scope []string) error {
+	for _, field := range decl.GetType().GetFields() {
+		if err := c.countTypeReferences(file, scope, field.Type); err != nil {
+			return err
+		}
+	}
+	for _, nested := range decl.StructDecls {
+		if isExtend(nested) {
+			continue
+		}
+		if err := c.countStructReferences(file, nested, append(append([]string{}, scope...), nested.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Converter) countTypeReferences(file *File, scope []string, typ *lang.NominalType) error {
+	if typ == nil {
+		return nil
+	}
+	if isContainerType(typ) {
+		for _, argument := range typ.GenericArguments {
+			if err := c.countTypeReferences(file, scope, argument); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, ok := scalarTypes[typ.Name]; ok && typ.Enclosing == nil {
+		return nil
+	}
+
+	fullName, s := c.lookup(file, scope, typ)
+	if _, ok := c.types[fullName]; ok {
+		return nil
+	} else if s != nil {
+		s.references++
+	} else if _, ok := wellKnownTypes[fullName]; !ok && !c.Source {
+		return fmt.Errorf("failed to resolve the type %s in the proto file %s", reference(typ), file.Name)
+	}
+	return nil
+}
+
+func (c *Converter) convertFile(file *File) (*lang.SourceFile, error) {
+	sourceFile := &lang.SourceFile{
+		Name: strings.TrimSuffix(path.Base(file.Name), ".proto") + ".mojo",
+	}
+
+	// the request messages inlined as the method parameters
+	inlined := make(map[*lang.StructDecl]bool)
+	var interfaces []*lang.Statement
+	for _, statement := range file.Source.Statements {
+		if decl := statement.GetDeclaration().GetInterfaceDecl(); decl != nil {
+			interfaceDecl, err := c.convertInterface(file, decl, inlined)
+			if err != nil {
+				return nil, err
+			}
+			interfaces = append(interfaces, lang.NewInterfaceDeclStatement(interfaceDecl))
+		}
+	}
+
+	for _, statement := range file.Source.Statements {
+		decl := statement.GetDeclaration()
+		if structDecl := decl.GetStructDecl(); isExtend(structDecl) {
+			attributes, err := c.convertExtend(file, structDecl)
+			if err != nil {
+				return nil, err
+			}
+			for _, attribute := range attributes {
+				sourceFile.Statements = append(sourceFile.Statements, lang.NewAttributeDeclStatement(attribute))
+			}
+		} else if structDecl != nil {
+			if inlined[structDecl] || c.isMapped(file, structDecl.Name) {
+				continue
+			}
+			s, err := c.convertStruct(file, structDecl, []string{structDecl.Name})
+			if err != nil {
+				return nil, err
+			}
+			sourceFile.Statements = append(sourceFile.Statements, lang.NewStructDeclStatement(s))
+		} else if enumDecl := decl.GetEnumDecl(); enumDecl != nil && !c.isMapped(file, enumDecl.Name) {
+			sourceFile.Statements = append(sourceFile.Statements, lang.NewEnumDeclStatement(c.convertEnum(file, enumDecl)))
+		}
+	}
+	sourceFile.Statements = append(sourceFile.Statements, interfaces...)
+	return sourceFile, nil
+}
+
+// isMapped the message or enum declared in the proto file is mapped to the mojo type, which will not be converted
+func (c *Converter) isMapped(file *File, name string) bool {
+	_, ok := c.types[lang.GetFullName(file.Package, nil, name)]
+	return ok
+}
+
+func (c *Converter) convertStruct(file *File, decl *lang.StructDecl, scope []string) (*lang.StructDecl, error) {
+	structDecl := &lang.StructDecl{
+		Document:   decl.Document,
+		Name:       decl.Name,
+		Attributes: c.convertDeclAttributes(file, decl.Attributes),
+		Type:       &lang.StructType{},
+	}
+
+	for _, enum := range decl.EnumDecls {
+		structDecl.EnumDecls = append(structDecl.EnumDecls, c.convertEnum(file, enum))
+	}
+	for _, nested := range decl.StructDecls {
+		if isExtend(nested) {
+			logs.Debugw("drop the extend block nested in the message", "message", decl.Name)
+			continue
+		}
+		s, err := c.convertStruct(file, nested, append(append([]string{}, scope...), nested.Name))
+		if err != nil {
+			return nil, err
+		}
+		structDecl.StructDecls = append(structDecl.StructDecls, s)
+	}
+	for _, field := range decl.GetType().GetFields() {
+		f, err := c.convertField(file, scope, field)
+		if err != nil {
+			return nil, err
+		}
+		structDecl.Type.Fields = append(structDecl.Type.Fields, f)
+	}
+	return structDecl, nil
+}
+
+func (c *Converter) convertField(file *File, scope []string, field *lang.ValueDecl) (*lang.ValueDecl, error) {
+	typ, err := c.convertType(file, scope, field.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &lang.ValueDecl{
+		Document: field.Document,
+		Name:     field.Name,
+		Type:     typ,
+	}, nil
+}
+
+// convertType resolve the type referenced in the proto file to the mojo type, with the attributes converted
+func (c *Converter) convertType(file *File, scope []string, typ *lang.NominalType) (*lang.NominalType, error) {
+	if typ == nil {
+		return nil, nil
+	}
+
+	var nominal *lang.NominalType
+	if isContainerType(typ) {
+		nominal = &lang.NominalType{PackageName: typ.PackageName, Name: typ.Name}
+		for _, argument := range typ.GenericArguments {
+			t, err := c.convertType(file, scope, argument)
+			if err != nil {
+				return nil, err
+			}
+			nominal.GenericArguments = append(nominal.GenericArguments, t)
+		}
+	} else if name, ok := scalarTypes[typ.Name]; ok && typ.Enclosing == nil {
+		nominal = &lang.NominalType{PackageName: corePackageName, Name: name}
+	} else {
+		fullName, s := c.lookup(file, scope, typ)
+		if name, ok := c.types[fullName]; ok {
+			nominal = config.MojoType(file.Package, name)
+		} else if s != nil {
+			nominal = c.symbolType(file, s)
+		} else if name, ok := wellKnownTypes[fullName]; ok {
+			nominal = &lang.NominalType{PackageName: corePackageName, Name: name}
+		} else if c.Source {
+			nominal = unresolvedType(fullName)
+		} else {
+			return nil, fmt.Errorf("failed to resolve the type %s in the proto file %s", reference(typ), file.Name)
+		}
+	}
+
+	nominal.Attributes = c.convertTypeAttributes(file, typ.Attributes)
+	return nominal, nil
+}
+
+// symbolType the mojo type of the message or enum, qualified with the package name if in the other package
+func (c *Converter) symbolType(file *File, s *symbol) *lang.NominalType {
+	var typ *lang.NominalType
+	for _, name := range s.names {
+		t := &lang.NominalType{Name: name, Enclosing: typ}
+		if s.pkg != file.Package {
+			t.PackageName = s.pkg
+		}
+		typ = t
+	}
+	return typ
+}
+
+// lookup resolve the type reference by the proto scoping rules, searching from the innermost scope
+// to the outermost, returns the full name and the symbol declared in the proto files if found
+func (c *Converter) lookup(file *File, scope []string, typ *lang.NominalType) (string, *symbol) {
+	ref := reference(typ)
+	if strings.HasPrefix(ref, ".") { // the fully qualified reference
+		ref = strings.TrimPrefix(ref, ".")
+		return ref, c.symbols[ref]
+	}
+
+	scopes := append(strings.Split(file.Package, "."), scope...)
+	for i := len(scopes); i >= 0; i-- {
+		fullName := lang.GetFullName(strings.Join(scopes[:i], "."), nil, ref)
+		if s, ok := c.symbols[fullName]; ok {
+			return fullName, s
+		}
+		if _, ok := wellKnownTypes[fullName]; ok {
+			return fullName, nil
+		}
+		if _, ok := c.types[fullName]; ok {
+			return fullName, nil
+		}
+	}
+	return ref, nil
+}
+
+func (c *Converter) convertEnum(file *File, decl *lang.EnumDecl) *lang.EnumDecl {
+	enumDecl := &lang.EnumDecl{
+		Document:   decl.Document,
+		Name:       decl.Name,
+		Attributes: c.convertDeclAttributes(file, decl.Attributes),
+		Type:       &lang.EnumType{},
+	}
+
+	// the enumerators are prefixed with the enum name by the proto style guide
+	prefix := strcase.ToScreamingSnake(decl.Name) + "_"
+	trimPrefix := true
+	for _, enumerator := range decl.GetType().GetEnumerators() {
+		if !strings.HasPrefix(enumerator.Name, prefix) || len(enumerator.Name) == len(prefix) {
+			trimPrefix = false
+		}
+	}
+
+	for _, enumerator := range decl.GetType().GetEnumerators() {
+		name := enumerator.Name
+		if trimPrefix {
+			name = strings.TrimPrefix(name, prefix)
+		}
+		e := &lang.ValueDecl{
+			Document: enumerator.Document,
+			Name:     strcase.ToSnake(name),
+		}
+		if literal := enumerator.GetInitializer().GetValue().GetIntegerLiteralExpr(); literal != nil {
+			e.Attributes = append(e.Attributes, lang.NewIntegerAttribute("", core.NumberAttributeName, literal.EvalValue()))
+		}
+		e.Attributes = append(e.Attributes, c.convertDeclAttributes(file, enumerator.Attributes)...)
+		enumDecl.Type.Enumerators = append(enumDecl.Type.Enumerators, e)
+	}
+	return enumDecl
+}
+
+func (c *Converter) convertInterface(file *File, decl *lang.InterfaceDecl, inlined map[*lang.StructDecl]bool) (*lang.InterfaceDecl, error) {
+	interfaceDecl := &lang.InterfaceDecl{
+		Document:   decl.Document,
+		Name:       decl.Name,
+		Attributes: c.convertDeclAttributes(file, decl.Attributes),
+		Type:       &lang.InterfaceType{},
+	}
+
+	for _, method := range decl.GetType().GetMethods() {
+		m, err := c.convertMethod(file, method, inlined)
+		if err != nil {
+			return nil, err
+		}
+		interfaceDecl.Type.Methods = append(interfaceDecl.Type.Methods, m)
+	}
+	return interfaceDecl, nil
+}
+
+func (c *Converter) convertMethod(file *File, decl *lang.FunctionDecl, inlined map[*lang.StructDecl]bool) (*lang.FunctionDecl, error) {
+	method := &lang.FunctionDecl{
+		Document:  decl.Document,
+		Name:      strcase.ToLowerCamel(decl.Name),
+		Signature: &lang.FunctionSignature{Parameter: &lang.FunctionSignature_Parameter{}},
+	}
+
+	for _, param := range decl.GetSignature().GetParameters() {
+		fullName, s := c.lookup(file, nil, param.Type)
+		if fullName == emptyTypeFullName {
+			continue
+		}
+
+		// the streamed request message is kept, as the stream attribute is on the request type
+		if !isStream(param.Type) && c.isInlinedRequest(file, decl, s) {
+			inlined[s.structDecl] = true
+			for _, field := range s.structDecl.GetType().GetFields() {
+				f, err := c.convertField(file, s.names, field)
+				if err != nil {
+					return nil, err
+				}
+				method.Signature.AppendParameter(f)
+			}
+		} else {
+			typ, err := c.convertType(file, nil, param.Type)
+			if err != nil {
+				return nil, err
+			}
+			typ.Attributes = append(typ.Attributes, &lang.Attribute{Name: protobuf.MethodRequestTypeAttributeName})
+			method.Signature.AppendParameter(&lang.ValueDecl{Name: "request", Type: typ})
+		}
+	}
+
+	// the printer checks the following documents of all the parameters
+	for _, param := range method.Signature.GetParameters() {
+		if param.Document == nil {
+			param.Document = &lang.Document{}
+		}
+	}
+
+	if result := decl.GetSignature().GetResultType(); result != nil {
+		if fullName, _ := c.lookup(file, nil, result); fullName != emptyTypeFullName {
+			typ, err := c.convertType(file, nil, result)
+			if err != nil {
+				return nil, err
+			}
+			method.Signature.Result = lang.NewFunctionResult(typ)
+		}
+	}
+
+	for _, attribute := range decl.Attributes {
+		if attribute.PackageName == "google.api" && attribute.Name == "http" {
+			c.convertHttpOption(method, attribute)
+		} else {
+			method.Attributes = append(method.Attributes, c.convertDeclAttributes(file, []*lang.Attribute{attribute})...)
+		}
+	}
+	return method, nil
+}
+
+// isInlinedRequest the request message named `<Method>Request` in the same file, and only used by
+// the method, will be inlined as the method parameters, as the mojo compiler generates it from them
+func (c *Converter) isInlinedRequest(file *File, method *lang.FunctionDecl, s *symbol) bool {
+	return !c.Source && s != nil && s.structDecl != nil && s.file == file &&
+		len(s.names) == 1 && s.names[0] == method.Name+"Request" &&
+		s.references == 1 &&
+		len(s.structDecl.StructDecls) == 0 && len(s.structDecl.EnumDecls) == 0
+}
+
+// convertHttpOption convert the google.api.http option to the http attributes of the method
+func (c *Converter) convertHttpOption(method *lang.FunctionDecl, option *lang.Attribute) {
+	var object *lang.ObjectLiteralExpr
+	if len(option.Arguments) > 0 {
+		object = option.Arguments[0].GetValue().GetObjectLiteralExpr()
+	}
+	if object == nil {
+		return
+	}
+
+	for _, field := range object.Fields {
+		value := field.GetValue().GetStringLiteralExpr().GetValue()
+		if verb, ok := httpVerbs[field.Name]; ok {
+			p := pathVariable.ReplaceAllString(value, "{$1}")
+			method.Attributes = append(method.Attributes, lang.NewStringAttribute("http", verb, p))
+		} else if field.Name == "body" && value != "*" && len(value) > 0 {
+			found := false
+			for _, param := range method.Signature.GetParameters() {
+				if param.Name == value {
+					param.Type.Attributes = append(param.Type.Attributes, &lang.Attribute{PackageName: "http", Name: http.BodyAttributeName})
+					found = true
+				}
+			}
+			if !found {
+				logs.Warnw("the body field of the http option not found in the method parameters", "method", method.Name, "body", value)
+			}
+		}
+	}
+}
+
+// convertExtend convert the custom options declared in the extend block to the attribute declarations,
+// with the DeclType target of the options extended and the field number
+func (c *Converter) convertExtend(file *File, decl *lang.StructDecl) ([]*lang.AttributeDecl, error) {
+	var attributes []*lang.AttributeDecl
+	for _, field := range decl.GetType().GetFields() {
+		e := c.extensions[lang.GetFullName(file.Package, nil, field.Name)]
+		if e == nil || e.decl != field {
+			continue
+		}
+
+		typ, err := c.convertType(file, nil, field.Type)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, &lang.AttributeDecl{
+			Document: field.Document,
+			Name:     field.Name,
+			Attributes: []*lang.Attribute{{
+				Name:      "target",
+				Arguments: []*lang.Argument{{Value: lang.NewIdentifierExpressionFrom("DeclType", optionTargets[e.extendee])}},
+			}},
+			Type: &lang.AttributeDecl_NominalType{NominalType: typ},
+		})
+	}
+	return attributes, nil
+}
+
+// lookupExtension resolve the custom option referenced in the proto file by the proto scoping rules
+func (c *Converter) lookupExtension(file *File, attribute *lang.Attribute) *extension {
+	ref := lang.GetFullName(attribute.PackageName, nil, attribute.Name)
+	scopes := strings.Split(file.Package, ".")
+	for i := len(scopes); i >= 0; i-- {
+		if e, ok := c.extensions[lang.GetFullName(strings.Join(scopes[:i], "."), nil, ref)]; ok {
+			return e
+		}
+	}
+	return nil
+}
+
+// convertCustomOption convert the custom option declared in the proto files to the mojo attribute,
+// qualified with the package name if in the other package
+func (c *Converter) convertCustomOption(file *File, attribute *lang.Attribute) *lang.Attribute {
+	if len(attribute.Fields) > 0 {
+		return nil
+	}
+
+	e := c.lookupExtension(file, attribute)
+	if e == nil {
+		return nil
+	}
+
+	attr := &lang.Attribute{Name: e.decl.Name}
+	if e.pkg != file.Package {
+		attr.PackageName = e.pkg
+	}
+
+	// the bool option set to true is the attribute without argument
+	var literal *lang.BoolLiteralExpr
+	if len(attribute.Arguments) > 0 {
+		literal = attribute.Arguments[0].GetValue().GetBoolLiteralExpr()
+	}
+	if literal == nil || !literal.Value {
+		attr.Arguments = attribute.Arguments
+	}
+	return attr
+}
+
+// convertDeclAttributes keep the options which have the mojo counterparts
+func (c *Converter) convertDeclAttributes(file *File, attributes []*lang.Attribute) []*lang.Attribute {
+	var attrs []*lang.Attribute
+	for _, attribute := range attributes {
+		if len(attribute.PackageName) == 0 && attribute.Name == core.DeprecatedAttributeName {
+			if isTrue(attribute) {
+				attrs = append(attrs, &lang.Attribute{Name: core.DeprecatedAttributeName})
+			}
+			continue
+		}
+		if attr := c.convertCustomOption(file, attribute); attr != nil {
+			attrs = append(attrs, attr)
+			continue
+		}
+		logs.Debugw("drop the proto option without the mojo counterpart", "option", attribute.GetFullName())
+	}
+	return attrs
+}
+
+// convertTypeAttributes convert the field number, label and the field options to the mojo attributes
+func (c *Converter) convertTypeAttributes(file *File, attributes []*lang.Attribute) []*lang.Attribute {
+	var attrs []*lang.Attribute
+	for _, attribute := range attributes {
+		switch {
+		case attribute.PackageName == "protobuf" && attribute.Name == "number":
+			if value, err := attribute.GetInteger(); err == nil {
+				attrs = append(attrs, lang.NewIntegerAttribute("", core.NumberAttributeName, value))
+			}
+		case attribute.PackageName == "protobuf" && attribute.Name == "required":
+			attrs = append(attrs, lang.NewBoolAttribute("", core.RequiredAttributeName))
+		case len(attribute.PackageName) == 0 && attribute.Name == core.LabelAttributeName:
+			attrs = append(attrs, attribute)
+		case len(attribute.PackageName) == 0 && attribute.Name == "json_name":
+			if value, err := attribute.GetString(); err == nil {
+				attrs = append(attrs, lang.NewStringAttribute("", core.AliasAttributeName, value))
+			}
+		case len(attribute.PackageName) == 0 && attribute.Name == core.DeprecatedAttributeName:
+			if isTrue(attribute) {
+				attrs = append(attrs, &lang.Attribute{Name: core.DeprecatedAttributeName})
+			}
+		case attribute.PackageName == "protobuf" && attribute.Name == "stream":
+			attrs = append(attrs, &lang.Attribute{Name: streamAttributeName})
+		default:
+			if attr := c.convertCustomOption(file, attribute); attr != nil {
+				attrs = append(attrs, attr)
+				continue
+			}
+			logs.Debugw("drop the proto option without the mojo counterpart", "option", attribute.GetFullName())
+		}
+	}
+	return attrs
+}
+
+func isTrue(attribute *lang.Attribute) bool {
+	return attribute.GetBool()
+}
+
+func isExtend(decl *lang.StructDecl) bool {
+	return decl != nil && lang.HasAttribute(decl.Attributes, "protobuf.extend")
+}
+
+func isStream(typ *lang.NominalType) bool {
+	return lang.HasAttribute(typ.GetAttributes(), "protobuf.stream")
+}
+
+func isContainerType(typ *lang.NominalType) bool {
+	return typ.PackageName == corePackageName &&
+		(typ.Name == core.ArrayTypeName || typ.Name == core.MapTypeName || typ.Name == core.UnionTypeName)
+}
+
+// unresolvedType the type not declared in the proto files, the leading lower case segments of the
+// reference are taken as the package name, and the others as the enclosing types
+func unresolvedType(ref string) *lang.NominalType {
+	var pkg []string
+	var typ *lang.NominalType
+	for _, segment := range strings.Split(ref, ".") {
+		if typ == nil && !lang.IsTypeName(segment) {
+			pkg = append(pkg, segment)
+			continue
+		}
+		typ = &lang.NominalType{Name: segment, Enclosing: typ}
+	}
+	if typ == nil {
+		return &lang.NominalType{Name: ref}
+	}
+	for t := typ; t != nil; t = t.Enclosing {
+		t.PackageName = strings.Join(pkg, ".")
+	}
+	return typ
+}
+
+// reference the dotted name of the type referenced in the proto file, with the leading dot if fully qualified
+func reference(typ *lang.NominalType) string {
+	var names []string
+	for t := typ; t != nil; t = t.Enclosing {
+		names = append([]string{t.Name}, names...)
+	}
+	return strings.Join(names, ".")
+}
@@ -0,0 +1,92 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/protobuf/parser/syntax"
+)
+
+func TestConvertSource(t *testing.T) {
+	const content = `syntax = "proto3";
+
+package test;
+
+message Address {
+  string city = 1;
+  repeated User residents = 2;
+  geo.v1.Point.Coordinate location = 3;
+  Kind kind = 4;
+
+  enum Kind {
+    KIND_UNSPECIFIED = 0;
+    KIND_HOME = 1;
+  }
+}
+`
+	source, err := syntax.New(nil).ParseString(context.Empty(), content)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	source.Name = "address.proto"
+
+	file, err := ConvertSource(source)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "address.mojo", file.Name)
+
+	decl := file.Statements[0].GetDeclaration().GetStructDecl()
+	fields := decl.Type.Fields
+	assert.Equal(t, "mojo.core.String", fields[0].Type.GetFullName())
+	assert.Equal(t, "User", fields[1].Type.GenericArguments[0].GetFullName())
+	assert.Equal(t, "geo.v1", fields[2].Type.PackageName)
+	assert.Equal(t, "geo.v1.Point.Coordinate", fields[2].Type.GetFullName())
+	assert.Equal(t, "Address.Kind", fields[3].Type.GetFullName())
+	assert.Equal(t, "home", decl.EnumDecls[0].Type.Enumerators[1].Name)
+}
+
+func TestConverter_TypeMappings(t *testing.T) {
+	config.Get().SetTypeMapping(config.ProtobufTypeMappingTarget, "mojo.core.Decimal", &config.TypeMapping{Type: "test.Decimal"})
+	config.Get().SetTypeMapping(config.ProtobufTypeMappingTarget, "mojo.core.Timestamp", &config.TypeMapping{Type: "int64"})
+	config.Get().SetTypeMapping(config.ProtobufTypeMappingTarget, "test.Time", &config.TypeMapping{Type: "google.protobuf.Timestamp"})
+	t.Cleanup(func() { delete(config.Get().Types, config.ProtobufTypeMappingTarget) })
+
+	const content = `syntax = "proto3";
+
+package test;
+
+import "google/protobuf/timestamp.proto";
+
+message Decimal {
+  string value = 1;
+}
+
+message Order {
+  Decimal price = 1;
+  google.protobuf.Timestamp create_time = 2;
+  int64 update_time = 3;
+}
+`
+	source, err := syntax.New(nil).ParseString(context.Empty(), content)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	files, err := NewConverter(NewFile("test/order.proto", source)).Convert()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	statements := files[0].Statements
+	if !assert.Equal(t, 1, len(statements)) {
+		t.FailNow()
+	}
+	fields := statements[0].GetDeclaration().GetStructDecl().Type.Fields
+	assert.Equal(t, "mojo.core.Decimal", fields[0].Type.GetFullName())
+	assert.Equal(t, "Time", fields[1].Type.GetFullName())
+	assert.Equal(t, "mojo.core.Int64", fields[2].Type.GetFullName())
+}
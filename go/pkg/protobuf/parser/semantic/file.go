@@ -0,0 +1,107 @@
+package semantic
+
+import (
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+)
+
+// File the parsed proto file to convert
+type File struct {
+	Name    string // the import path of the proto file
+	Package string
+	Source  *lang.SourceFile
+}
+
+func NewFile(name string, source *lang.SourceFile) *File {
+	file := &File{Name: name, Source: source}
+	for _, statement := range source.Statements {
+		if decl := statement.GetDeclaration().GetPackageDecl(); decl != nil {
+			file.Package = lang.GetFullName(decl.PackageName, nil, decl.Name)
+		}
+	}
+	return file
+}
+
+// symbol the message or enum declared in the proto files
+type symbol struct {
+	pkg        string
+	names      []string // the names in the package, including the enclosing message names
+	file       *File
+	structDecl *lang.StructDecl
+	enumDecl   *lang.EnumDecl
+
+	// the times referenced by the fields and the rpc methods
+	references int
+}
+
+func (s *symbol) fullName() string {
+	return lang.GetFullName(s.pkg, nil, strings.Join(s.names, "."))
+}
+
+// extension the custom option declared in the extend block of the proto files
+type extension struct {
+	pkg      string
+	file     *File
+	decl     *lang.ValueDecl
+	extendee string // the full name of the options extended
+}
+
+func (e *extension) fullName() string {
+	return lang.GetFullName(e.pkg, nil, e.decl.Name)
+}
+
+func (c *Converter) registerSymbols(file *File) {
+	var registerStruct func(decl *lang.StructDecl, enclosing []string)
+	registerEnum := func(decl *lang.EnumDecl, enclosing []string) {
+		s := &symbol{pkg: file.Package, names: append(append([]string{}, enclosing...), decl.Name), file: file, enumDecl: decl}
+		c.symbols[s.fullName()] = s
+	}
+	registerStruct = func(decl *lang.StructDecl, enclosing []string) {
+		s := &symbol{pkg: file.Package, names: append(append([]string{}, enclosing...), decl.Name), file: file, structDecl: decl}
+		c.symbols[s.fullName()] = s
+		for _, enum := range decl.EnumDecls {
+			registerEnum(enum, s.names)
+		}
+		for _, nested := range decl.StructDecls {
+			if !isExtend(nested) {
+				registerStruct(nested, s.names)
+			}
+		}
+	}
+
+	for _, statement := range file.Source.Statements {
+		if decl := statement.GetDeclaration(); decl != nil {
+			if structDecl := decl.GetStructDecl(); isExtend(structDecl) {
+				c.registerExtensions(file, structDecl)
+			} else if structDecl != nil {
+				registerStruct(structDecl, nil)
+			} else if enumDecl := decl.GetEnumDecl(); enumDecl != nil {
+				registerEnum(enumDecl, nil)
+			}
+		}
+	}
+}
+
+// registerExtensions register the scalar custom options extending the options which have the mojo
+// attribute targets, the others are dropped as no mojo counterpart
+func (c *Converter) registerExtensions(file *File, decl *lang.StructDecl) {
+	if len(decl.GetType().GetInherits()) == 0 {
+		return
+	}
+
+	extendee := strings.TrimPrefix(reference(decl.GetType().GetInherits()[0]), ".")
+	for _, field := range decl.GetType().GetFields() {
+		e := &extension{pkg: file.Package, file: file, decl: field, extendee: extendee}
+		if _, ok := optionTargets[extendee]; !ok {
+			logs.Debugw("drop the custom option extending the unsupported options", "option", e.fullName(), "extendee", extendee)
+			continue
+		}
+		if _, ok := scalarTypes[field.GetType().GetName()]; !ok || field.GetType().GetEnclosing() != nil {
+			logs.Debugw("drop the custom option not in the scalar type", "option", e.fullName())
+			continue
+		}
+		c.extensions[e.fullName()] = e
+	}
+}
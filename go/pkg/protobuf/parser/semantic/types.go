@@ -0,0 +1,65 @@
+package semantic
+
+import (
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+)
+
+// scalarTypes the proto scalar types to the mojo.core types
+var scalarTypes = map[string]string{
+	"double":   core.Float64TypeName,
+	"float":    core.Float32TypeName,
+	"int32":    core.Int32TypeName,
+	"int64":    core.Int64TypeName,
+	"uint32":   core.UInt32TypeName,
+	"uint64":   core.UInt64TypeName,
+	"sint32":   core.Int32TypeName,
+	"sint64":   core.Int64TypeName,
+	"fixed32":  core.UInt32TypeName,
+	"fixed64":  core.UInt64TypeName,
+	"sfixed32": core.Int32TypeName,
+	"sfixed64": core.Int64TypeName,
+	"bool":     core.BoolTypeName,
+	"string":   core.StringTypeName,
+	"bytes":    core.BytesTypeName,
+}
+
+// wellKnownTypes the protobuf well-known types to the mojo.core types
+var wellKnownTypes = map[string]string{
+	"google.protobuf.Any":         core.AnyTypeName,
+	"google.protobuf.Duration":    core.DurationTypeName,
+	"google.protobuf.Empty":       core.NullTypeName,
+	"google.protobuf.FieldMask":   core.FieldMaskTypeName,
+	"google.protobuf.ListValue":   core.ValuesTypeName,
+	"google.protobuf.NullValue":   core.NullTypeName,
+	"google.protobuf.Struct":      core.ObjectTypeName,
+	"google.protobuf.Timestamp":   core.TimestampTypeName,
+	"google.protobuf.Value":       core.ValueTypeName,
+	"google.protobuf.BoolValue":   core.BoolValueTypeName,
+	"google.protobuf.BytesValue":  core.BytesValueTypeName,
+	"google.protobuf.DoubleValue": core.Float64ValueTypeName,
+	"google.protobuf.FloatValue":  core.Float32ValueTypeName,
+	"google.protobuf.Int32Value":  core.Int32ValueTypeName,
+	"google.protobuf.Int64Value":  core.Int64ValueTypeName,
+	"google.protobuf.StringValue": core.StringValueTypeName,
+	"google.protobuf.UInt32Value": core.UInt32ValueTypeName,
+	"google.protobuf.UInt64Value": core.UInt64ValueTypeName,
+}
+
+// IsScalarType the proto scalar type, like `int64`, `sint32` and `string`
+func IsScalarType(name string) bool {
+	_, ok := scalarTypes[name]
+	return ok
+}
+
+const (
+	corePackageName   = "mojo.core"
+	emptyTypeFullName = "google.protobuf.Empty"
+)
+
+// IsWellKnownFile the proto files of the well-known types and the google apis, which are mapped to
+// the mojo types and attributes instead of being imported
+func IsWellKnownFile(name string) bool {
+	return strings.HasPrefix(name, "google/protobuf/") || strings.HasPrefix(name, "google/api/")
+}
@@ -0,0 +1,292 @@
+package syntax
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+)
+
+// comment the consecutive line comments or a block comment in the proto file,
+// the lexer of the proto grammar skips the comments, so they are scanned from the content
+type comment struct {
+	startLine int64
+	endLine   int64
+	trailing  bool // following some code in the same line
+	lines     []string
+}
+
+// scanComments scans the comments and groups the consecutive line comments in the content
+func scanComments(content string) []*comment {
+	var comments []*comment
+	var line int64 = 1
+	code := false // has code before in the current line
+
+	appendComment := func(c *comment) {
+		if len(comments) > 0 {
+			last := comments[len(comments)-1]
+			if !last.trailing && !c.trailing && last.endLine+1 == c.startLine && last.endLine == last.startLine+int64(len(last.lines))-1 {
+				last.endLine = c.endLine
+				last.lines = append(last.lines, c.lines...)
+				return
+			}
+		}
+		comments = append(comments, c)
+	}
+
+	for i := 0; i < len(content); i++ {
+		switch ch := content[i]; {
+		case ch == '\n':
+			line++
+			code = false
+		case ch == '"' || ch == '\'':
+			for i++; i < len(content) && content[i] != ch && content[i] != '\n'; i++ {
+				if content[i] == '\\' {
+					i++
+				}
+			}
+			code = true
+		case ch == '/' && i+1 < len(content) && content[i+1] == '/':
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				end = len(content) - i
+			}
+			appendComment(&comment{
+				startLine: line,
+				endLine:   line,
+				trailing:  code,
+				lines:     []string{trimCommentLine(content[i+2 : i+end])},
+			})
+			i += end - 1
+		case ch == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				end = len(content) - i - 2
+			}
+			text := content[i+2 : i+2+end]
+			c := &comment{startLine: line, trailing: code}
+			for _, l := range strings.Split(text, "\n") {
+				l = strings.TrimSpace(l)
+				l = strings.TrimPrefix(strings.TrimPrefix(l, "*"), " ")
+				c.lines = append(c.lines, l)
+			}
+			line += int64(strings.Count(text, "\n"))
+			c.endLine = line
+			c.lines = trimEmptyLines(c.lines)
+			comments = append(comments, c)
+			i += end + 3
+		case ch != ' ' && ch != '\t' && ch != '\r':
+			code = true
+		}
+	}
+	return comments
+}
+
+func trimCommentLine(line string) string {
+	return strings.TrimRight(strings.TrimPrefix(strings.TrimPrefix(line, "/"), " "), " \t\r")
+}
+
+func trimEmptyLines(lines []string) []string {
+	for len(lines) > 0 && len(lines[0]) == 0 {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func newDocument(c *comment, following bool) *lang.Document {
+	document := &lang.Document{
+		StartPosition: &lang.Position{Line: c.startLine},
+		EndPosition:   &lang.Position{Line: c.endLine},
+		Following:     following,
+	}
+	for _, line := range c.lines {
+		document.Lines = append(document.Lines, &lang.Document_Line{Content: line})
+	}
+	return document
+}
+
+// documentAttacher attaches the comments to the declarations as the documents like the protoc does,
+// the leading comments directly before the declaration, or the trailing comment after it in the same line.
+// The other free comments are kept in the positions of the declarations, so that they could be printed back
+type documentAttacher struct {
+	leading  map[int64]*comment // the end line to the comment
+	trailing map[int64]*comment // the start line to the comment
+	attached map[*comment]bool
+
+	anchors []*lang.Position         // the positions taking the free comments before them
+	ends    map[int64]*lang.Position // the end line to the last position ending in the line
+}
+
+func attachDocuments(file *lang.SourceFile, content string) {
+	comments := scanComments(content)
+	attacher := &documentAttacher{
+		leading:  make(map[int64]*comment),
+		trailing: make(map[int64]*comment),
+		attached: make(map[*comment]bool),
+		ends:     make(map[int64]*lang.Position),
+	}
+	for _, c := range comments {
+		if c.trailing {
+			attacher.trailing[c.startLine] = c
+		} else {
+			attacher.leading[c.endLine] = c
+		}
+	}
+
+	for _, attribute := range file.Attributes {
+		attacher.attachAttribute(attribute)
+	}
+	for _, statement := range file.Statements {
+		if decl := statement.GetDeclaration(); decl != nil {
+			switch {
+			case decl.GetPackageDecl() != nil:
+				pkg := decl.GetPackageDecl()
+				pkg.Document = attacher.document(pkg.StartPosition, pkg.EndPosition)
+			case decl.GetImportDecl() != nil:
+				imp := decl.GetImportDecl()
+				imp.Document = attacher.document(imp.StartPosition, imp.EndPosition)
+			case decl.GetStructDecl() != nil:
+				attacher.attachStruct(decl.GetStructDecl())
+			case decl.GetEnumDecl() != nil:
+				attacher.attachEnum(decl.GetEnumDecl())
+			case decl.GetInterfaceDecl() != nil:
+				attacher.attachInterface(decl.GetInterfaceDecl())
+			}
+		}
+	}
+
+	attacher.attachFreeComments(file, comments)
+}
+
+func (a *documentAttacher) document(start *lang.Position, end *lang.Position) *lang.Document {
+	if start == nil {
+		return nil
+	}
+	a.anchor(start, end)
+
+	if c := a.leading[start.Line-1]; c != nil && len(c.lines) > 0 && !a.attached[c] {
+		a.attached[c] = true
+		return newDocument(c, false)
+	}
+	if end != nil {
+		if c := a.trailing[end.Line]; c != nil && len(c.lines) > 0 && !a.attached[c] {
+			a.attached[c] = true
+			return newDocument(c, true)
+		}
+	}
+	return nil
+}
+
+// anchor register the positions of the declaration for the free comments
+func (a *documentAttacher) anchor(start *lang.Position, end *lang.Position) {
+	if start != nil {
+		a.anchors = append(a.anchors, start)
+	}
+	if end != nil {
+		if last := a.ends[end.Line]; last == nil || last.Column <= end.Column {
+			a.ends[end.Line] = end
+		}
+	}
+}
+
+// attachFreeComments attaches the comments not attached as the documents to the leading comments of
+// the next declaration, or the trailing comments of the declaration ending in the same line
+func (a *documentAttacher) attachFreeComments(file *lang.SourceFile, comments []*comment) {
+	sort.SliceStable(a.anchors, func(i, j int) bool {
+		return a.anchors[i].Line < a.anchors[j].Line ||
+			(a.anchors[i].Line == a.anchors[j].Line && a.anchors[i].Column < a.anchors[j].Column)
+	})
+
+	for _, c := range comments {
+		if a.attached[c] || len(c.lines) == 0 {
+			continue
+		}
+
+		if end := a.ends[c.startLine]; c.trailing && end != nil {
+			end.AppendTailingComment(lang.NewDocumentComment(newDocument(c, true)))
+			continue
+		}
+
+		// the trailing comment not following any declaration, like the one after the '{', is before the next line
+		line := c.endLine
+		if c.trailing {
+			line++
+		}
+		index := sort.Search(len(a.anchors), func(i int) bool { return a.anchors[i].Line >= line })
+		if index < len(a.anchors) {
+			a.anchors[index].AppendLeadingComment(lang.NewDocumentComment(newDocument(c, false)))
+		} else {
+			file.TailingComments = append(file.TailingComments, lang.NewDocumentComment(newDocument(c, false)))
+		}
+	}
+}
+
+func (a *documentAttacher) attachAttribute(attribute *lang.Attribute) {
+	if attribute.StartPosition != nil {
+		attribute.Document = a.document(attribute.StartPosition, attribute.EndPosition)
+	}
+}
+
+func (a *documentAttacher) attachStruct(decl *lang.StructDecl) {
+	// the trailing comment of the message is after the '{' which is not following the message
+	decl.Document = a.document(decl.StartPosition, nil)
+	a.anchor(nil, decl.EndPosition)
+
+	for _, attribute := range decl.Attributes {
+		a.attachAttribute(attribute)
+	}
+	for _, enum := range decl.EnumDecls {
+		a.attachEnum(enum)
+	}
+	for _, nested := range decl.StructDecls {
+		a.attachStruct(nested)
+	}
+	for _, field := range decl.GetType().GetFields() {
+		field.Document = a.document(field.StartPosition, field.EndPosition)
+
+		// the fields of the oneof
+		if typ := field.GetType(); typ.GetFullName() == core.UnionTypeFullName {
+			for _, attribute := range typ.Attributes {
+				a.attachAttribute(attribute)
+			}
+			for _, argument := range typ.GenericArguments {
+				argument.Document = a.document(argument.StartPosition, argument.EndPosition)
+			}
+			a.anchor(typ.EndPosition, nil)
+		}
+	}
+	a.anchor(decl.GetType().GetEndPosition(), nil)
+}
+
+func (a *documentAttacher) attachEnum(decl *lang.EnumDecl) {
+	decl.Document = a.document(decl.StartPosition, nil)
+	a.anchor(nil, decl.EndPosition)
+
+	for _, attribute := range decl.Attributes {
+		a.attachAttribute(attribute)
+	}
+	for _, enumerator := range decl.GetType().GetEnumerators() {
+		enumerator.Document = a.document(enumerator.StartPosition, enumerator.EndPosition)
+	}
+	a.anchor(decl.GetType().GetEndPosition(), nil)
+}
+
+func (a *documentAttacher) attachInterface(decl *lang.InterfaceDecl) {
+	decl.Document = a.document(decl.StartPosition, nil)
+	a.anchor(nil, decl.EndPosition)
+
+	for _, attribute := range decl.Attributes {
+		a.attachAttribute(attribute)
+	}
+	for _, method := range decl.GetType().GetMethods() {
+		method.Document = a.document(method.StartPosition, method.EndPosition)
+		for _, attribute := range method.Attributes {
+			a.attachAttribute(attribute)
+		}
+	}
+	a.anchor(decl.GetType().GetEndPosition(), nil)
+}
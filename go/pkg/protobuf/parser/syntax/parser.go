@@ -26,12 +26,25 @@ func New(options core.Options) *Parser {
 
 var proto3Regex = regexp.MustCompile(`syntax[ \t\r\n]*=[ \t\r\n]*['"]proto3['"]`)
 
+// IsProto3 the content of the proto file is in the proto3 syntax, otherwise in the proto2
+func IsProto3(content string) bool {
+	return proto3Regex.MatchString(content)
+}
+
 func (p *Parser) ParseString(ctx context.Context, content string) (*lang.SourceFile, error) {
-	if proto3Regex.MatchString(content) {
-		return p.Proto3.ParseString(ctx, content)
+	var file *lang.SourceFile
+	var err error
+	if IsProto3(content) {
+		file, err = p.Proto3.ParseString(ctx, content)
 	} else {
-		return p.Proto2.ParseString(ctx, content)
+		file, err = p.Proto2.ParseString(ctx, content)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	attachDocuments(file, content)
+	return file, nil
 }
 
 func (p *Parser) ParseFile(ctx context.Context, filename string) (*lang.SourceFile, error) {
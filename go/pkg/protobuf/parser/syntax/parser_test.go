@@ -21,3 +21,43 @@ func TestParser_ParseString2(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, file)
 }
+
+func TestParser_ParseString_Documents(t *testing.T) {
+	const content = `syntax = "proto3";
+
+package test;
+
+// Person the person
+// in the address book
+message Person {
+  /* the name */
+  string name = 1;
+  int32 id = 2; // the unique id
+
+  // the phone type
+  enum PhoneType {
+    MOBILE = 0; // the mobile phone
+  }
+}
+`
+	file, err := New(nil).ParseString(context.Empty(), content)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	decl := file.Statements[len(file.Statements)-1].GetDeclaration().GetStructDecl()
+	if !assert.NotNil(t, decl) {
+		t.FailNow()
+	}
+	assert.Equal(t, "Person the person\nin the address book", decl.Document.GetContent())
+	assert.False(t, decl.Document.Following)
+
+	fields := decl.Type.Fields
+	assert.Equal(t, "the name", fields[0].Document.GetContent())
+	assert.Equal(t, "the unique id", fields[1].Document.GetContent())
+	assert.True(t, fields[1].Document.Following)
+
+	enum := decl.EnumDecls[0]
+	assert.Equal(t, "the phone type", enum.Document.GetContent())
+	assert.Equal(t, "the mobile phone", enum.Type.Enumerators[0].Document.GetContent())
+}
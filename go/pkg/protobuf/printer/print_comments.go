@@ -0,0 +1,112 @@
+package printer
+
+import (
+	"strings"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+)
+
+// printCommentLines prints the lines of the document as the line comments
+func (p *Printer) printCommentLines(document *lang.Document) *Printer {
+	for _, line := range document.GetLines() {
+		p.PrintLine(commentLine(line.Content))
+	}
+	return p
+}
+
+func commentLine(content string) string {
+	if len(content) == 0 {
+		return "//"
+	}
+	return "// " + content
+}
+
+// printFreeComments prints the comments not attached as the documents, the blank line between the
+// comment and the next line in the proto file is kept
+func (p *Printer) printFreeComments(comments []*lang.Comment, next *lang.Position) *Printer {
+	for i, comment := range comments {
+		document := comment.GetDocument()
+		p.printCommentLines(document)
+
+		line := next.GetLine()
+		if i+1 < len(comments) {
+			line = comments[i+1].GetStartPosition().GetLine()
+		}
+		if line > document.GetEndPosition().GetLine()+1 {
+			p.PrintBlankLine()
+		}
+	}
+	return p
+}
+
+// printLeadingComments prints the free comments before the element and its leading document
+func (p *Printer) printLeadingComments(start *lang.Position, document *lang.Document) *Printer {
+	if document != nil && !document.Following {
+		p.printFreeComments(start.GetLeadingComments(), document.GetStartPosition())
+		return p.printCommentLines(document)
+	}
+	return p.printFreeComments(start.GetLeadingComments(), start)
+}
+
+// printTrailingComments prints the following document and the trailing comments of the element
+// after it in the same line
+func (p *Printer) printTrailingComments(end *lang.Position, document *lang.Document) *Printer {
+	var lines []string
+	if document != nil && document.Following {
+		for _, line := range document.Lines {
+			lines = append(lines, line.Content)
+		}
+	}
+	for _, comment := range end.GetTailingComments() {
+		for _, line := range comment.GetDocument().GetLines() {
+			lines = append(lines, line.Content)
+		}
+	}
+
+	column := p.Cursor.Column + 1
+	for i, line := range lines {
+		if i > 0 {
+			p.BreakLine()
+			p.PrintRaw(strings.Repeat(" ", column))
+		} else {
+			p.PrintRaw(" ")
+		}
+		p.PrintRaw(commentLine(line))
+	}
+	return p
+}
+
+func hasTrailingComments(end *lang.Position, document *lang.Document) bool {
+	return (document != nil && document.Following) || len(end.GetTailingComments()) > 0
+}
+
+// firstLine the first line of the element including its leading comments in the proto file
+func firstLine(start *lang.Position, document *lang.Document) int64 {
+	line := start.GetLine()
+	if document != nil && !document.Following && document.GetStartPosition().GetLine() > 0 {
+		line = document.GetStartPosition().GetLine()
+	}
+	if comments := start.GetLeadingComments(); len(comments) > 0 && comments[0].GetStartPosition().GetLine() > 0 {
+		line = comments[0].GetStartPosition().GetLine()
+	}
+	return line
+}
+
+// lastLine the last line of the element including its trailing comments in the proto file
+func lastLine(end *lang.Position) int64 {
+	line := end.GetLine()
+	for _, comment := range end.GetTailingComments() {
+		if l := comment.GetEndPosition().GetLine(); l > line {
+			line = l
+		}
+	}
+	return line
+}
+
+// hasBlankLine the blank line between the previous element and the next one in the proto file
+func hasBlankLine(end *lang.Position, start *lang.Position, document *lang.Document) bool {
+	if end.GetLine() == 0 || start.GetLine() == 0 {
+		return false
+	}
+	return firstLine(start, document) > lastLine(end)+1
+}
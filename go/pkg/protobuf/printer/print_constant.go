@@ -0,0 +1,106 @@
+package printer
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+)
+
+// constant the expression parsed from the proto constant formatted in one line
+func constant(expr *lang.Expression) string {
+	switch {
+	case expr.GetStringLiteralExpr() != nil:
+		return quote(expr.GetStringLiteralExpr().Value)
+	case expr.GetIntegerLiteralExpr() != nil:
+		integer := expr.GetIntegerLiteralExpr()
+		if integer.IsNegative {
+			return "-" + strconv.FormatUint(integer.Value, 10)
+		}
+		return strconv.FormatUint(integer.Value, 10)
+	case expr.GetFloatLiteralExpr() != nil:
+		float := expr.GetFloatLiteralExpr()
+		var value string
+		switch {
+		case math.IsNaN(float.Value):
+			return "nan"
+		case math.IsInf(float.Value, 0):
+			// the sign of the -inf parsed is kept in the IsNegative
+			value = "inf"
+			if math.IsInf(float.Value, -1) != float.IsNegative {
+				return "-" + value
+			}
+			return value
+		default:
+			value = strconv.FormatFloat(float.Value, 'g', -1, 64)
+		}
+		if !strings.ContainsAny(value, ".eE") {
+			value += ".0"
+		}
+		if float.IsNegative {
+			return "-" + value
+		}
+		return value
+	case expr.GetBoolLiteralExpr() != nil:
+		return strconv.FormatBool(expr.GetBoolLiteralExpr().Value)
+	case expr.GetIdentifierExpr() != nil:
+		return identifierName(expr.GetIdentifierExpr().GetIdentifier())
+	case expr.GetObjectLiteralExpr() != nil:
+		var fields []string
+		for _, field := range expr.GetObjectLiteralExpr().Fields {
+			fields = append(fields, field.Name+": "+constant(field.Value))
+		}
+		return "{" + strings.Join(fields, " ") + "}"
+	}
+	return ""
+}
+
+// quote the string literal in the double quotes, the escapes in the proto file are kept as they are
+func quote(value string) string {
+	builder := strings.Builder{}
+	builder.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		switch ch := value[i]; ch {
+		case '\\':
+			builder.WriteByte(ch)
+			if i+1 < len(value) {
+				i++
+				builder.WriteByte(value[i])
+			}
+		case '"':
+			builder.WriteString(`\"`)
+		default:
+			builder.WriteByte(ch)
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
+
+func identifierName(identifier *lang.Identifier) string {
+	var names []string
+	for id := identifier; id != nil; id = id.Enclosing {
+		names = append([]string{id.Name}, names...)
+	}
+	return strings.Join(names, ".")
+}
+
+// printConstant prints the constant of the option statement, the message literal is printed in lines
+func (p *Printer) printConstant(expr *lang.Expression) *Printer {
+	object := expr.GetObjectLiteralExpr()
+	if object == nil || len(object.Fields) == 0 {
+		p.PrintRaw(constant(expr))
+		return p
+	}
+
+	p.PrintRaw("{")
+	p.Indent()
+	for _, field := range object.Fields {
+		p.PrintLine(field.Name, ": ")
+		p.printConstant(field.Value)
+	}
+	p.Outdent()
+	p.PrintLine("}")
+	return p
+}
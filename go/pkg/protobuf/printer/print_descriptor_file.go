@@ -34,6 +34,8 @@ func (p *Printer) PrintDescriptorFile(ctx context.Context, file *descriptor.File
 		p.PrintDescriptorService(ctx, service)
 	}
 
+	p.PrintDescriptorExtensions(ctx, file)
+
 	// Run the plugins before the imports so we know which imports are necessary.
 	// p.runPlugins(file)
 
@@ -93,6 +95,9 @@ func (p *Printer) PrintDescriptorFileHeader(ctx context.Context, file *descripto
 	if !file.IsProto3() {
 		syntax = descriptor.Proto3Syntax
 	}
+	if isOptionsFile(file) {
+		syntax = descriptor.Proto2Syntax
+	}
 	p.PrintBlankLine()
 	p.PrintLine("syntax = \"", syntax, "\";")
 
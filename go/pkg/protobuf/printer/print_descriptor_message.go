@@ -16,6 +16,10 @@ func (p *Printer) PrintDescriptorMessage(ctx context.Context, message *descripto
 	p.Indent()
 
 	firstItem := true
+	if options := customOptions(message.Proto.Options); len(options) > 0 {
+		p.PrintDescriptorOptions(ctx, message.Proto.Options)
+		firstItem = false
+	}
 
 	// Build a structure more suitable for generating the text in one pass
 	for _, enum := range message.Enums {
@@ -101,6 +105,13 @@ func (p *Printer) PrintDescriptorMessage(ctx context.Context, message *descripto
 					first = false
 				}
 			}
+			for _, option := range customOptions(field.Proto.Options) {
+				if !first {
+					buffer.WriteString(", ")
+				}
+				buffer.WriteString(fmt.Sprint("(", option.name, ")=", option.value))
+				first = false
+			}
 			if buffer.Len() > 0 {
 				p.PrintRaw(" [", buffer.String(), "];")
 			} else {
@@ -0,0 +1,119 @@
+package printer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/mojo"
+	"github.com/mojo-lang/protobuf/go/pkg/mojo/protobuf/descriptor"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+// mojoOptionsFile the proto file declaring the mojo options, which are printed as the known extensions
+var mojoOptionsFile = mojo.E_Alias.TypeDescriptor().ParentFile().Path()
+
+// customOption the custom option set in the options, with the value formatted as the proto constant
+type customOption struct {
+	name  string
+	value string
+}
+
+// customOptions the custom options set in the options except the mojo ones, ordered by the field number
+func customOptions(options proto.Message) []*customOption {
+	if options == nil || !options.ProtoReflect().IsValid() {
+		return nil
+	}
+
+	var fields []protoreflect.FieldDescriptor
+	values := make(map[protoreflect.FieldDescriptor]protoreflect.Value)
+	options.ProtoReflect().Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if field.IsExtension() && !field.IsList() && field.ParentFile().Path() != mojoOptionsFile {
+			fields = append(fields, field)
+			values[field] = value
+		}
+		return true
+	})
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Number() < fields[j].Number() })
+
+	var opts []*customOption
+	for _, field := range fields {
+		value := values[field]
+		option := &customOption{name: string(field.FullName())}
+		switch field.Kind() {
+		case protoreflect.BoolKind:
+			option.value = strconv.FormatBool(value.Bool())
+		case protoreflect.StringKind:
+			option.value = strconv.Quote(value.String())
+		case protoreflect.EnumKind:
+			if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+				option.value = string(enumValue.Name())
+			} else {
+				option.value = fmt.Sprint(value.Enum())
+			}
+		case protoreflect.MessageKind, protoreflect.GroupKind, protoreflect.BytesKind:
+			continue
+		default:
+			option.value = fmt.Sprint(value.Interface())
+		}
+		opts = append(opts, option)
+	}
+	return opts
+}
+
+// PrintDescriptorOptions print the custom options as the option statements
+func (p *Printer) PrintDescriptorOptions(ctx context.Context, options proto.Message) *Printer {
+	_ = ctx
+	for _, option := range customOptions(options) {
+		p.PrintLine("option (", option.name, ") = ", option.value, ";")
+	}
+	return p
+}
+
+// isOptionsFile the file declaring only the custom options is printed in proto2, so that it can be
+// parsed back by the proto2 grammar, as the proto3 one has no extend blocks
+func isOptionsFile(file *descriptor.File) bool {
+	return len(file.Proto.GetExtension()) > 0 &&
+		len(file.Messages) == 0 && len(file.Enums) == 0 && len(file.Services) == 0
+}
+
+// PrintDescriptorExtensions print the custom options declared in the file, grouped by the options extended
+func (p *Printer) PrintDescriptorExtensions(ctx context.Context, file *descriptor.File) *Printer {
+	_ = ctx
+
+	label := ""
+	if isOptionsFile(file) {
+		label = "optional "
+	}
+
+	var extendees []string
+	fields := make(map[string][]*descriptorpb.FieldDescriptorProto)
+	for _, field := range file.Proto.GetExtension() {
+		extendee := strings.TrimPrefix(field.GetExtendee(), ".")
+		if _, ok := fields[extendee]; !ok {
+			extendees = append(extendees, extendee)
+		}
+		fields[extendee] = append(fields[extendee], field)
+	}
+
+	for _, extendee := range extendees {
+		p.PrintBlankLine()
+		p.PrintLine("extend ", extendee, " {")
+		p.Indent()
+		for _, field := range fields[extendee] {
+			typeName := strings.TrimPrefix(field.GetTypeName(), ".")
+			if len(typeName) == 0 {
+				typeName = strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+			}
+			p.PrintLine(label, typeName, " ", field.GetName(), " = ", field.GetNumber(), ";")
+		}
+		p.Outdent()
+		p.PrintLine("}")
+	}
+	return p
+}
@@ -7,8 +7,6 @@ import (
 )
 
 func (p *Printer) PrintDescriptorService(ctx context.Context, service *descriptor.Service) *Printer {
-	_ = ctx
-
 	p.PrintLine("service ", service.GetName(), " {")
 	p.Indent()
 
@@ -24,7 +22,21 @@ func (p *Printer) PrintDescriptorService(ctx context.Context, service *descripto
 		if output.GetPackageName() != pkg {
 			outputName = output.GetFullName()
 		}
-		p.PrintLine("rpc ", method.GetName(), "(", inputName, ") returns (", outputName, ");")
+		if method.Proto.GetClientStreaming() {
+			inputName = "stream " + inputName
+		}
+		if method.Proto.GetServerStreaming() {
+			outputName = "stream " + outputName
+		}
+		if options := customOptions(method.Proto.Options); len(options) > 0 {
+			p.PrintLine("rpc ", method.GetName(), "(", inputName, ") returns (", outputName, ") {")
+			p.Indent()
+			p.PrintDescriptorOptions(ctx, method.Proto.Options)
+			p.Outdent()
+			p.PrintLine("}")
+		} else {
+			p.PrintLine("rpc ", method.GetName(), "(", inputName, ") returns (", outputName, ");")
+		}
 	}
 
 	p.Outdent()
@@ -6,6 +6,48 @@ import (
 	"github.com/mojo-lang/mojo/go/pkg/context"
 )
 
+// PrintEnumDecl prints the enum parsed from the proto file
 func (p *Printer) PrintEnumDecl(ctx context.Context, decl *lang.EnumDecl) *Printer {
+	_ = ctx
+	if decl == nil || p.Error != nil {
+		return p
+	}
+
+	p.printLeadingComments(decl.StartPosition, decl.Document)
+	p.PrintLine("enum ", decl.Name, " {")
+	p.Indent()
+
+	blocks := newBlocks(p)
+	if options := getOptions(decl.Attributes); len(options) > 0 {
+		blocks.Next()
+		p.printOptions(options, enumOptions)
+	}
+
+	var rows []*row
+	for _, enumerator := range decl.GetType().GetEnumerators() {
+		rows = append(rows, &row{
+			start:    enumerator.StartPosition,
+			end:      enumerator.EndPosition,
+			document: enumerator.Document,
+			columns: []string{enumerator.Name, "= " + constant(enumerator.GetInitializer().GetValue()) +
+				compactOptions(enumerator.Attributes, enumValueOptions) + ";"},
+		})
+	}
+	if len(rows) > 0 {
+		blocks.Next()
+		p.printRows(rows)
+	}
+	if comments := decl.GetType().GetEndPosition().GetLeadingComments(); len(comments) > 0 {
+		blocks.Next()
+		p.printFreeComments(comments, nil)
+	}
+
+	p.Outdent()
+	if blocks.IsEmpty() {
+		p.PrintRaw("}")
+	} else {
+		p.PrintLine("}")
+	}
+	p.printTrailingComments(decl.EndPosition, nil)
 	return p
 }
@@ -6,6 +6,75 @@ import (
 	"github.com/mojo-lang/mojo/go/pkg/context"
 )
 
+// PrintInterfaceDecl prints the service parsed from the proto file
 func (p *Printer) PrintInterfaceDecl(ctx context.Context, decl *lang.InterfaceDecl) *Printer {
+	_ = ctx
+	if decl == nil || p.Error != nil {
+		return p
+	}
+
+	p.printLeadingComments(decl.StartPosition, decl.Document)
+	p.PrintLine("service ", decl.Name, " {")
+	p.Indent()
+
+	blocks := newBlocks(p)
+	if options := getOptions(decl.Attributes); len(options) > 0 {
+		blocks.Next()
+		p.printOptions(options, serviceOptions)
+	}
+
+	methods := decl.GetType().GetMethods()
+	for i, method := range methods {
+		if i == 0 {
+			blocks.Next()
+		} else if options := getOptions(method.Attributes); len(options) > 0 ||
+			len(getOptions(methods[i-1].Attributes)) > 0 ||
+			hasBlankLine(methods[i-1].EndPosition, method.StartPosition, method.Document) {
+			// the methods with the options are separated by the blank lines
+			p.PrintBlankLine()
+		}
+		p.printMethod(method)
+	}
+	if comments := decl.GetType().GetEndPosition().GetLeadingComments(); len(comments) > 0 {
+		blocks.Next()
+		p.printFreeComments(comments, nil)
+	}
+
+	p.Outdent()
+	if blocks.IsEmpty() {
+		p.PrintRaw("}")
+	} else {
+		p.PrintLine("}")
+	}
+	p.printTrailingComments(decl.EndPosition, nil)
 	return p
 }
+
+func (p *Printer) printMethod(method *lang.FunctionDecl) {
+	p.printLeadingComments(method.StartPosition, method.Document)
+
+	var request *lang.NominalType
+	if decls := method.GetSignature().GetParameter().GetDecls(); len(decls) > 0 {
+		request = decls[0].GetType()
+	}
+	response := method.GetSignature().GetResult().GetType()
+	p.PrintLine("rpc ", method.Name, "(", streamType(request), ") returns (", streamType(response), ")")
+
+	if options := getOptions(method.Attributes); len(options) > 0 {
+		p.PrintRaw(" {")
+		p.Indent()
+		p.printOptions(options, methodOptions)
+		p.Outdent()
+		p.PrintLine("}")
+	} else {
+		p.PrintRaw(";")
+	}
+	p.printTrailingComments(method.EndPosition, method.Document)
+}
+
+func streamType(typ *lang.NominalType) string {
+	if hasSyntaxAttribute(typ.GetAttributes(), "stream") {
+		return "stream " + typeName(typ)
+	}
+	return typeName(typ)
+}
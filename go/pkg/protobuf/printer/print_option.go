@@ -0,0 +1,141 @@
+package printer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	protobufPackageName = "protobuf"
+	corePackageName     = "mojo.core"
+)
+
+// syntaxAttributes the attributes parsed from the proto syntax instead of the options
+var syntaxAttributes = map[string]bool{
+	"syntax":     true,
+	"number":     true,
+	"required":   true,
+	"stream":     true,
+	"extend":     true,
+	"reserved":   true,
+	"extensions": true,
+	"group":      true,
+}
+
+// the options of the declarations, the custom options are which not the fields of them
+var (
+	fileOptions      = (&descriptorpb.FileOptions{}).ProtoReflect().Descriptor()
+	messageOptions   = (&descriptorpb.MessageOptions{}).ProtoReflect().Descriptor()
+	fieldOptions     = (&descriptorpb.FieldOptions{}).ProtoReflect().Descriptor()
+	oneofOptions     = (&descriptorpb.OneofOptions{}).ProtoReflect().Descriptor()
+	enumOptions      = (&descriptorpb.EnumOptions{}).ProtoReflect().Descriptor()
+	enumValueOptions = (&descriptorpb.EnumValueOptions{}).ProtoReflect().Descriptor()
+	serviceOptions   = (&descriptorpb.ServiceOptions{}).ProtoReflect().Descriptor()
+	methodOptions    = (&descriptorpb.MethodOptions{}).ProtoReflect().Descriptor()
+)
+
+// fieldPseudoOptions the options of the field which are set to the FieldDescriptorProto
+var fieldPseudoOptions = map[string]bool{
+	"default":   true,
+	"json_name": true,
+}
+
+func isSyntaxAttribute(attribute *lang.Attribute, name string) bool {
+	return attribute.PackageName == protobufPackageName && attribute.Name == name
+}
+
+// getOptions the attributes which are the options, except the ones parsed from the proto syntax
+func getOptions(attributes []*lang.Attribute) []*lang.Attribute {
+	var options []*lang.Attribute
+	for _, attribute := range attributes {
+		if attribute.PackageName == protobufPackageName && syntaxAttributes[attribute.Name] {
+			continue
+		}
+		options = append(options, attribute)
+	}
+	return options
+}
+
+// optionName the name of the option, the custom option is in the parentheses
+func optionName(attribute *lang.Attribute, options protoreflect.MessageDescriptor) string {
+	name := attribute.Name
+	if len(attribute.PackageName) > 0 || (options.Fields().ByName(protoreflect.Name(name)) == nil &&
+		!(options == fieldOptions && fieldPseudoOptions[name])) {
+		name = "(" + lang.GetFullName(attribute.PackageName, nil, attribute.Name) + ")"
+	}
+	if len(attribute.Fields) > 0 {
+		name += "." + strings.Join(attribute.Fields, ".")
+	}
+	return name
+}
+
+func optionValue(attribute *lang.Attribute) *lang.Expression {
+	if len(attribute.Arguments) > 0 {
+		return attribute.Arguments[0].GetValue()
+	}
+	return lang.NewBoolLiteralExpressionFrom(true)
+}
+
+// printOptions prints the option statements, the blank lines between them in the proto file are kept
+func (p *Printer) printOptions(attributes []*lang.Attribute, options protoreflect.MessageDescriptor) *Printer {
+	for i, attribute := range attributes {
+		if i > 0 && hasBlankLine(attributes[i-1].EndPosition, attribute.StartPosition, attribute.Document) {
+			p.PrintBlankLine()
+		}
+
+		p.printLeadingComments(attribute.StartPosition, attribute.Document)
+		p.PrintLine("option ", optionName(attribute, options), " = ")
+		p.printConstant(optionValue(attribute))
+		p.PrintRaw(";")
+		p.printTrailingComments(attribute.EndPosition, attribute.Document)
+	}
+	return p
+}
+
+// compactOptions the options in the brackets following the field or the enumerator
+func compactOptions(attributes []*lang.Attribute, options protoreflect.MessageDescriptor) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+
+	var values []string
+	for _, attribute := range attributes {
+		values = append(values, optionName(attribute, options)+" = "+constant(optionValue(attribute)))
+	}
+	return " [" + strings.Join(values, ", ") + "]"
+}
+
+// rangeRows the reserved and extensions statements of the message
+func rangeRows(attributes []*lang.Attribute) []*row {
+	var rows []*row
+	for _, attribute := range attributes {
+		if !isSyntaxAttribute(attribute, "reserved") && !isSyntaxAttribute(attribute, "extensions") {
+			continue
+		}
+
+		var ranges []string
+		for _, argument := range attribute.Arguments {
+			if rng := argument.GetValue().GetRangeLiteralExpr().GetValue(); rng != nil {
+				if rng.End == 0 && !rng.EndIncluded {
+					ranges = append(ranges, strconv.FormatInt(rng.Start, 10)+" to max")
+				} else {
+					ranges = append(ranges, strconv.FormatInt(rng.Start, 10)+" to "+strconv.FormatInt(rng.End, 10))
+				}
+			} else {
+				ranges = append(ranges, constant(argument.GetValue()))
+			}
+		}
+
+		rows = append(rows, &row{
+			start:    attribute.StartPosition,
+			end:      attribute.EndPosition,
+			document: attribute.Document,
+			columns:  []string{attribute.Name + " " + strings.Join(ranges, ", ") + ";"},
+		})
+	}
+	return rows
+}
@@ -0,0 +1,71 @@
+package printer
+
+import (
+	"strings"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+)
+
+// row the single line element, like the field and the enumerator, whose columns are aligned with
+// the rows around it
+type row struct {
+	start    *lang.Position
+	end      *lang.Position
+	document *lang.Document
+	columns  []string
+}
+
+// printRows prints the rows with the columns and the trailing comments aligned, the rows separated
+// by the blank lines in the proto file are aligned separately
+func (p *Printer) printRows(rows []*row) *Printer {
+	for len(rows) > 0 {
+		n := 1
+		for n < len(rows) && !hasBlankLine(rows[n-1].end, rows[n].start, rows[n].document) {
+			n++
+		}
+
+		p.printAlignedRows(rows[:n])
+		if rows = rows[n:]; len(rows) > 0 {
+			p.PrintBlankLine()
+		}
+	}
+	return p
+}
+
+func (p *Printer) printAlignedRows(rows []*row) {
+	var widths []int
+	for _, r := range rows {
+		for i, column := range r.columns {
+			if i >= len(widths) {
+				widths = append(widths, len(column))
+			} else if len(column) > widths[i] {
+				widths[i] = len(column)
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(rows))
+	width := 0
+	for _, r := range rows {
+		line := strings.Builder{}
+		for i, column := range r.columns {
+			line.WriteString(column)
+			if i < len(r.columns)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-len(column)+1))
+			}
+		}
+		lines = append(lines, line.String())
+		if line.Len() > width {
+			width = line.Len()
+		}
+	}
+
+	for i, r := range rows {
+		p.printLeadingComments(r.start, r.document)
+		p.PrintLine(lines[i])
+		if hasTrailingComments(r.end, r.document) {
+			p.PrintRaw(strings.Repeat(" ", width-len(lines[i])))
+			p.printTrailingComments(r.end, r.document)
+		}
+	}
+}
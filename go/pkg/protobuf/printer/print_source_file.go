@@ -6,6 +6,127 @@ import (
 	"github.com/mojo-lang/mojo/go/pkg/context"
 )
 
-func (p *Printer) PrintSourceFile(ctx context.Context, decl *lang.SourceFile) *Printer {
+const syntaxKey = "protobuf-syntax"
+
+// isProto2 the proto2 fields are printed with the labels
+func isProto2(ctx context.Context) bool {
+	syntax, _ := ctx.Value(syntaxKey).(string)
+	return syntax == "proto2"
+}
+
+// PrintSourceFile prints the source file parsed from the proto file, in the order of the syntax, package,
+// imports, options and the top level declarations, with the comments kept
+func (p *Printer) PrintSourceFile(ctx context.Context, file *lang.SourceFile) *Printer {
+	if file == nil || p.Error != nil {
+		return p
+	}
+
+	var syntax *lang.Attribute
+	var options []*lang.Attribute
+	for _, attribute := range file.Attributes {
+		if isSyntaxAttribute(attribute, "syntax") {
+			syntax = attribute
+		} else {
+			options = append(options, attribute)
+		}
+	}
+
+	var packages []*row
+	var imports []*row
+	var decls []*lang.Declaration
+	for _, statement := range file.Statements {
+		decl := statement.GetDeclaration()
+		switch {
+		case decl.GetPackageDecl() != nil:
+			pkg := decl.GetPackageDecl()
+			packages = append(packages, &row{
+				start:    pkg.StartPosition,
+				end:      pkg.EndPosition,
+				document: pkg.Document,
+				columns:  []string{"package " + lang.GetFullName(pkg.PackageName, nil, pkg.Name) + ";"},
+			})
+		case decl.GetImportDecl() != nil:
+			imp := decl.GetImportDecl()
+			filter := ""
+			if len(imp.Filter) > 0 {
+				filter = imp.Filter + " "
+			}
+			imports = append(imports, &row{
+				start:    imp.StartPosition,
+				end:      imp.EndPosition,
+				document: imp.Document,
+				columns:  []string{"import " + filter + quote(imp.ImportFileName) + ";"},
+			})
+		case decl != nil:
+			decls = append(decls, decl)
+		}
+	}
+
+	blocks := newBlocks(p)
+	if syntax != nil {
+		value, _ := syntax.GetString()
+		ctx = context.WithValues(ctx, syntaxKey, value)
+
+		blocks.Next()
+		p.printRows([]*row{{
+			start:    syntax.StartPosition,
+			end:      syntax.EndPosition,
+			document: syntax.Document,
+			columns:  []string{"syntax = " + quote(value) + ";"},
+		}})
+	}
+	if len(packages) > 0 {
+		blocks.Next()
+		p.printRows(packages)
+	}
+	if len(imports) > 0 {
+		blocks.Next()
+		p.printRows(imports)
+	}
+	if len(options) > 0 {
+		blocks.Next()
+		p.printOptions(options, fileOptions)
+	}
+	for _, decl := range decls {
+		blocks.Next()
+		switch {
+		case decl.GetStructDecl() != nil:
+			p.PrintStructDecl(ctx, decl.GetStructDecl())
+		case decl.GetEnumDecl() != nil:
+			p.PrintEnumDecl(ctx, decl.GetEnumDecl())
+		case decl.GetInterfaceDecl() != nil:
+			p.PrintInterfaceDecl(ctx, decl.GetInterfaceDecl())
+		}
+	}
+	if len(file.TailingComments) > 0 {
+		blocks.Next()
+		p.printFreeComments(file.TailingComments, nil)
+	}
+
+	if !blocks.IsEmpty() {
+		p.BreakLine()
+	}
 	return p
 }
+
+// blocks the blocks of the statements in the file or the declaration body, separated by the blank line
+type blocks struct {
+	printer *Printer
+	count   int
+}
+
+func newBlocks(p *Printer) *blocks {
+	return &blocks{printer: p}
+}
+
+// Next starts the next block
+func (b *blocks) Next() {
+	if b.count > 0 {
+		b.printer.PrintBlankLine()
+	}
+	b.count++
+}
+
+func (b *blocks) IsEmpty() bool {
+	return b.count == 0
+}
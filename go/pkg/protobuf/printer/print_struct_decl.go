@@ -1,11 +1,227 @@
 package printer
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
 
 	"github.com/mojo-lang/mojo/go/pkg/context"
 )
 
+// PrintStructDecl prints the message, or the extend block, parsed from the proto file
 func (p *Printer) PrintStructDecl(ctx context.Context, decl *lang.StructDecl) *Printer {
+	if decl == nil || p.Error != nil {
+		return p
+	}
+
+	p.printLeadingComments(decl.StartPosition, decl.Document)
+
+	if hasSyntaxAttribute(decl.Attributes, "extend") && len(decl.GetType().GetInherits()) > 0 {
+		p.PrintLine("extend ", typeName(decl.Type.Inherits[0]), " {")
+	} else {
+		p.PrintLine("message ", decl.Name, " {")
+	}
+	p.printStructBody(ctx, decl)
+	p.printTrailingComments(decl.EndPosition, nil)
 	return p
 }
+
+// printStructBody prints the body of the message, the extend block or the group, and the closing brace
+func (p *Printer) printStructBody(ctx context.Context, decl *lang.StructDecl) {
+	p.Indent()
+
+	blocks := newBlocks(p)
+	if options := getOptions(decl.Attributes); len(options) > 0 {
+		blocks.Next()
+		p.printOptions(options, messageOptions)
+	}
+	if rows := rangeRows(decl.Attributes); len(rows) > 0 {
+		blocks.Next()
+		p.printRows(rows)
+	}
+	for _, enum := range decl.EnumDecls {
+		blocks.Next()
+		p.PrintEnumDecl(ctx, enum)
+	}
+	groups := make(map[string]*lang.StructDecl)
+	for _, nested := range decl.StructDecls {
+		if hasSyntaxAttribute(nested.Attributes, "group") {
+			groups[nested.Name] = nested
+			continue
+		}
+		blocks.Next()
+		p.PrintStructDecl(ctx, nested)
+	}
+	if fields := decl.GetType().GetFields(); len(fields) > 0 {
+		blocks.Next()
+		p.printFields(ctx, fields, groups)
+	}
+	if comments := decl.GetType().GetEndPosition().GetLeadingComments(); len(comments) > 0 {
+		blocks.Next()
+		p.printFreeComments(comments, nil)
+	}
+
+	p.Outdent()
+	if blocks.IsEmpty() {
+		p.PrintRaw("}")
+	} else {
+		p.PrintLine("}")
+	}
+}
+
+// printFields prints the fields aligned, the oneof fields are aligned in the oneof block, and the groups
+// are printed with their messages
+func (p *Printer) printFields(ctx context.Context, fields []*lang.ValueDecl, groups map[string]*lang.StructDecl) {
+	var rows []*row
+	for i, field := range fields {
+		oneof := isContainerType(field.Type, core.UnionTypeName)
+		group := groups[groupName(field.Type)]
+		if oneof || group != nil || len(rows) == 0 {
+			p.printRows(rows)
+			rows = nil
+			if i > 0 && hasBlankLine(fields[i-1].EndPosition, field.StartPosition, field.Document) {
+				p.PrintBlankLine()
+			}
+		}
+
+		if oneof {
+			p.printOneof(field)
+		} else if group != nil {
+			p.printGroup(ctx, field, group)
+		} else {
+			rows = append(rows, p.fieldRow(ctx, field))
+		}
+	}
+	p.printRows(rows)
+}
+
+// groupName the name of the group message of the group field, or empty for the other fields
+func groupName(typ *lang.NominalType) string {
+	if !hasSyntaxAttribute(typ.Attributes, "group") {
+		return ""
+	}
+	if isContainerType(typ, core.ArrayTypeName) && len(typ.GenericArguments) > 0 {
+		return typ.GenericArguments[0].Name
+	}
+	return typ.Name
+}
+
+// printGroup prints the proto2 group field with the body of the group message
+func (p *Printer) printGroup(ctx context.Context, field *lang.ValueDecl, group *lang.StructDecl) {
+	typ := field.Type
+	label := "optional"
+	switch {
+	case isContainerType(typ, core.ArrayTypeName):
+		label = "repeated"
+	case hasSyntaxAttribute(typ.Attributes, "required"):
+		label = "required"
+	}
+
+	p.printLeadingComments(field.StartPosition, field.Document)
+	p.PrintLine(label, " group ", group.Name, " ", fieldNumber(typ), " {")
+	p.printStructBody(ctx, group)
+	p.printTrailingComments(field.EndPosition, field.Document)
+}
+
+func (p *Printer) fieldRow(ctx context.Context, field *lang.ValueDecl) *row {
+	typ := field.Type
+	var name string
+	switch {
+	case isContainerType(typ, core.ArrayTypeName) && len(typ.GenericArguments) > 0:
+		name = "repeated " + typeName(typ.GenericArguments[0])
+	case isContainerType(typ, core.MapTypeName) && len(typ.GenericArguments) > 1:
+		name = "map<" + typeName(typ.GenericArguments[0]) + ", " + typeName(typ.GenericArguments[1]) + ">"
+	case hasSyntaxAttribute(typ.Attributes, "required"):
+		name = "required " + typeName(typ)
+	case isProto2(ctx):
+		name = "optional " + typeName(typ)
+	default:
+		name = typeName(typ)
+	}
+
+	return &row{
+		start:    field.StartPosition,
+		end:      field.EndPosition,
+		document: field.Document,
+		columns:  []string{name, field.Name, fieldNumber(typ) + compactOptions(getOptions(typ.Attributes), fieldOptions) + ";"},
+	}
+}
+
+func (p *Printer) printOneof(field *lang.ValueDecl) {
+	p.printLeadingComments(field.StartPosition, field.Document)
+	p.PrintLine("oneof ", field.Name, " {")
+	p.Indent()
+
+	blocks := newBlocks(p)
+	if options := getOptions(field.Type.Attributes); len(options) > 0 {
+		blocks.Next()
+		p.printOptions(options, oneofOptions)
+	}
+
+	var rows []*row
+	for _, typ := range field.Type.GenericArguments {
+		// the field name of the oneof is kept in the label attribute
+		var label string
+		var options []*lang.Attribute
+		for _, option := range getOptions(typ.Attributes) {
+			if len(option.PackageName) == 0 && option.Name == "label" {
+				label, _ = option.GetString()
+			} else {
+				options = append(options, option)
+			}
+		}
+
+		rows = append(rows, &row{
+			start:    typ.StartPosition,
+			end:      typ.EndPosition,
+			document: typ.Document,
+			columns:  []string{typeName(typ), label, fieldNumber(typ) + compactOptions(options, fieldOptions) + ";"},
+		})
+	}
+	if len(rows) > 0 {
+		blocks.Next()
+		p.printRows(rows)
+	}
+	if comments := field.Type.GetEndPosition().GetLeadingComments(); len(comments) > 0 {
+		blocks.Next()
+		p.printFreeComments(comments, nil)
+	}
+
+	p.Outdent()
+	if blocks.IsEmpty() {
+		p.PrintRaw("}")
+	} else {
+		p.PrintLine("}")
+	}
+	p.printTrailingComments(field.EndPosition, field.Document)
+}
+
+func fieldNumber(typ *lang.NominalType) string {
+	number, _ := lang.GetIntegerAttribute(typ.Attributes, "protobuf.number")
+	return "= " + strconv.FormatInt(number, 10)
+}
+
+// isContainerType the repeated, map or oneof field type
+func isContainerType(typ *lang.NominalType, name string) bool {
+	return typ.GetPackageName() == corePackageName && typ.GetName() == name && typ.GetEnclosing() == nil
+}
+
+// typeName the type name referenced in the proto file, the fully qualified one is with the leading dot
+func typeName(typ *lang.NominalType) string {
+	var names []string
+	for t := typ; t != nil; t = t.Enclosing {
+		names = append([]string{t.Name}, names...)
+	}
+	return strings.Join(names, ".")
+}
+
+func hasSyntaxAttribute(attributes []*lang.Attribute, name string) bool {
+	for _, attribute := range attributes {
+		if isSyntaxAttribute(attribute, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -20,6 +20,8 @@ func (v *EnumDefVisitor) VisitEnumDef(ctx *EnumDefContext) interface{} {
 		if body := ctx.EnumBody(); body != nil {
 			if decl, ok := body.Accept(v).(*lang.EnumDecl); ok {
 				decl.Name = name.GetText()
+				decl.StartPosition = GetPosition(ctx.GetStart())
+				decl.EndPosition = GetPosition(ctx.GetStop())
 				return decl
 			}
 		}
@@ -30,12 +32,18 @@ func (v *EnumDefVisitor) VisitEnumDef(ctx *EnumDefContext) interface{} {
 func (v *EnumDefVisitor) VisitEnumBody(ctx *EnumBodyContext) interface{} {
 	elements := ctx.AllEnumElement()
 
-	decl := &lang.EnumDecl{Type: &lang.EnumType{}}
+	decl := &lang.EnumDecl{Type: &lang.EnumType{
+		StartPosition: GetPosition(ctx.GetStart()),
+		EndPosition:   GetPosition(ctx.GetStop()),
+	}}
 	for _, element := range elements {
 		ele := element.Accept(v)
 		if valueDecl, ok := ele.(*lang.ValueDecl); ok {
 			decl.Type.Enumerators = append(decl.Type.Enumerators, valueDecl)
 		}
+		if attr, ok := ele.(*lang.Attribute); ok {
+			decl.Attributes = append(decl.Attributes, attr)
+		}
 	}
 	return decl
 }
@@ -44,14 +52,21 @@ func (v *EnumDefVisitor) VisitEnumElement(ctx *EnumElementContext) interface{} {
 	if field := ctx.EnumField(); field != nil {
 		return field.Accept(v)
 	}
+	if option := ctx.OptionStatement(); option != nil {
+		return option.Accept(NewOptionStatementVisitor())
+	}
 	return nil
 }
 
 func (v *EnumDefVisitor) VisitEnumField(ctx *EnumFieldContext) interface{} {
-	decl := &lang.ValueDecl{Name: ctx.Ident().GetText()}
+	decl := &lang.ValueDecl{
+		StartPosition: GetPosition(ctx.GetStart()),
+		EndPosition:   GetPosition(ctx.GetStop()),
+		Name:          ctx.Ident().GetText(),
+	}
 
 	lit := ctx.IntLit().GetText()
-	value, _ := strconv.ParseInt(lit, 10, 64)
+	value, _ := strconv.ParseInt(lit, 0, 64)
 	if ctx.MINUS() != nil {
 		value = -value
 	}
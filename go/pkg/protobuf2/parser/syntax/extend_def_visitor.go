@@ -15,14 +15,23 @@ func (v *ExtendDefVisitor) VisitExtendDef(ctx *ExtendDefContext) interface{} {
 	if messageType := ctx.MessageType(); messageType != nil {
 		if typ, ok := messageType.Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
 			decl := &lang.StructDecl{
-				Type: &lang.StructType{},
+				StartPosition: GetPosition(ctx.GetStart()),
+				EndPosition:   GetPosition(ctx.GetStop()),
+				Type: &lang.StructType{
+					StartPosition: GetPosition(ctx.LC().GetSymbol()),
+					EndPosition:   GetPosition(ctx.RC().GetSymbol()),
+				},
 			}
 			decl.Type.Inherits = append(decl.Type.Inherits, typ)
 
 			allElements := ctx.AllExtendElement()
 			for _, element := range allElements {
-				if value, ok := element.Accept(v).(*lang.ValueDecl); ok {
+				switch value := element.Accept(v).(type) {
+				case *lang.ValueDecl:
 					decl.Type.Fields = append(decl.Type.Fields, value)
+				case *group:
+					decl.StructDecls = append(decl.StructDecls, value.message)
+					decl.Type.Fields = append(decl.Type.Fields, value.field)
 				}
 			}
 
@@ -37,5 +46,8 @@ func (v *ExtendDefVisitor) VisitExtendElement(ctx *ExtendElementContext) interfa
 	if field := ctx.Field(); field != nil {
 		return field.Accept(NewMessageDefVisitor())
 	}
+	if g := ctx.Group(); g != nil {
+		return g.Accept(NewMessageDefVisitor())
+	}
 	return nil
 }
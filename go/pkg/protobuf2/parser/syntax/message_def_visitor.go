@@ -2,6 +2,7 @@ package syntax
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/mojo-lang/core/go/pkg/mojo/core"
 	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
@@ -11,6 +12,13 @@ type MessageDefVisitor struct {
 	*BaseProtobuf2Visitor
 }
 
+// group the field and the nested message declared by the group, the message is marked with
+// the protobuf.group attribute to be printed in the group field
+type group struct {
+	field   *lang.ValueDecl
+	message *lang.StructDecl
+}
+
 func NewMessageDefVisitor() *MessageDefVisitor {
 	visitor := &MessageDefVisitor{}
 	return visitor
@@ -21,6 +29,8 @@ func (v *MessageDefVisitor) VisitMessageDef(ctx *MessageDefContext) interface{}
 		if body := ctx.MessageBody(); body != nil {
 			if decl, ok := body.Accept(v).(*lang.StructDecl); ok {
 				decl.Name = name.GetText()
+				decl.StartPosition = GetPosition(ctx.GetStart())
+				decl.EndPosition = GetPosition(ctx.GetStop())
 				return decl
 			}
 		}
@@ -31,7 +41,10 @@ func (v *MessageDefVisitor) VisitMessageDef(ctx *MessageDefContext) interface{}
 func (v *MessageDefVisitor) VisitMessageBody(ctx *MessageBodyContext) interface{} {
 	elements := ctx.AllMessageElement()
 
-	decl := &lang.StructDecl{Type: &lang.StructType{}}
+	decl := &lang.StructDecl{Type: &lang.StructType{
+		StartPosition: GetPosition(ctx.GetStart()),
+		EndPosition:   GetPosition(ctx.GetStop()),
+	}}
 	for _, element := range elements {
 		ele := element.Accept(v)
 		if valueDecl, ok := ele.(*lang.ValueDecl); ok {
@@ -46,6 +59,10 @@ func (v *MessageDefVisitor) VisitMessageBody(ctx *MessageBodyContext) interface{
 		if attr, ok := ele.(*lang.Attribute); ok {
 			decl.Attributes = append(decl.Attributes, attr)
 		}
+		if g, ok := ele.(*group); ok {
+			decl.StructDecls = append(decl.StructDecls, g.message)
+			decl.Type.Fields = append(decl.Type.Fields, g.field)
+		}
 	}
 
 	return decl
@@ -71,7 +88,11 @@ func (v *MessageDefVisitor) VisitMessageElement(ctx *MessageElementContext) inte
 		return message.Accept(NewMessageDefVisitor())
 	}
 	if extend := ctx.ExtendDef(); extend != nil {
-		return extend.Accept(NewExtendDefVisitor())
+		if decl, ok := extend.Accept(NewExtendDefVisitor()).(*lang.StructDecl); ok {
+			decl.Attributes = append(decl.Attributes, lang.NewBoolAttribute("protobuf", "extend"))
+			return decl
+		}
+		return nil
 	}
 	if extensions := ctx.Extensions(); extensions != nil {
 		return extensions.Accept(v)
@@ -79,32 +100,66 @@ func (v *MessageDefVisitor) VisitMessageElement(ctx *MessageElementContext) inte
 	if option := ctx.OptionStatement(); option != nil {
 		return option.Accept(NewOptionStatementVisitor())
 	}
+	if g := ctx.Group(); g != nil {
+		return g.Accept(v)
+	}
 	return nil
 }
 
+// VisitGroup returns the *group, the field is named in the lower case of the group name as protoc does
+func (v *MessageDefVisitor) VisitGroup(ctx *GroupContext) interface{} {
+	name := ctx.GroupName().GetText()
+	body, ok := ctx.MessageBody().Accept(v).(*lang.StructDecl)
+	if !ok {
+		return nil
+	}
+
+	body.Name = name
+	body.StartPosition = GetPosition(ctx.GetStart())
+	body.EndPosition = GetPosition(ctx.GetStop())
+	body.Attributes = append(body.Attributes, lang.NewBoolAttribute("protobuf", "group"))
+
+	field := &lang.ValueDecl{
+		StartPosition: GetPosition(ctx.GetStart()),
+		EndPosition:   GetPosition(ctx.GetStop()),
+		Name:          strings.ToLower(name),
+		Type:          withFieldLabel(&lang.NominalType{Name: name}, ctx.FieldLabel().GetText()),
+	}
+
+	number, _ := strconv.ParseInt(ctx.FieldNumber().GetText(), 0, 64)
+	field.Type.Attributes = append(field.Type.Attributes,
+		lang.NewIntegerAttribute("protobuf", "number", number),
+		lang.NewBoolAttribute("protobuf", "group"))
+	return &group{field: field, message: body}
+}
+
+func withFieldLabel(typ *lang.NominalType, label string) *lang.NominalType {
+	switch label {
+	case "repeated":
+		return lang.NewArrayNominalType(typ)
+	case "required":
+		typ.Attributes = append(typ.Attributes, lang.NewBoolAttribute("protobuf", "required"))
+	}
+	return typ
+}
+
 func (v *MessageDefVisitor) VisitField(ctx *FieldContext) interface{} {
 	if typ := ctx.Type_(); typ != nil {
 		decl := &lang.ValueDecl{
-			Name: ctx.FieldName().GetText(),
-			Type: &lang.NominalType{},
+			StartPosition: GetPosition(ctx.GetStart()),
+			EndPosition:   GetPosition(ctx.GetStop()),
+			Name:          ctx.FieldName().GetText(),
+			Type:          &lang.NominalType{},
 		}
 
 		if t, ok := typ.Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
 			decl.Type = t
 		}
 
-		if label := ctx.FieldLabel().GetText(); len(label) > 0 {
-			switch label {
-			case "repeated":
-				decl.Type = lang.NewArrayNominalType(decl.Type)
-			case "optional":
-			case "required":
-				decl.Type.Attributes = append(decl.Type.Attributes, lang.NewBoolAttribute("protobuf", "required"))
-			}
-		}
+		decl.Type = withFieldLabel(decl.Type, ctx.FieldLabel().GetText())
 
 		number := ctx.FieldNumber().GetText()
-		value, _ := strconv.ParseInt(number, 10, 64)
+		value, _ := strconv.ParseInt(number, 0, 64)
 		decl.Type.Attributes = append(decl.Type.Attributes, lang.NewIntegerAttribute("protobuf", "number", value))
 
 		if options := ctx.FieldOptions(); options != nil {
@@ -146,17 +201,26 @@ func (v *MessageDefVisitor) VisitFieldOption(ctx *FieldOptionContext) interface{
 }
 
 func (v *MessageDefVisitor) VisitOneof(ctx *OneofContext) interface{} {
-	oneof := &lang.ValueDecl{Name: ctx.OneofName().GetText()}
+	oneof := &lang.ValueDecl{
+		StartPosition: GetPosition(ctx.GetStart()),
+		EndPosition:   GetPosition(ctx.GetStop()),
+		Name:          ctx.OneofName().GetText(),
+	}
 
 	allFields := ctx.AllOneofField()
 	var types []*lang.NominalType
 	for _, field := range allFields {
 		if decl, ok := field.Accept(v).(*lang.ValueDecl); ok {
 			decl.Type.Attributes = append(decl.Type.Attributes, lang.NewStringAttribute("", "label", decl.Name))
+			// keep the positions of the field for the comments
+			decl.Type.StartPosition = decl.StartPosition
+			decl.Type.EndPosition = decl.EndPosition
 			types = append(types, decl.Type)
 		}
 	}
 	oneof.Type = lang.NewUnionNominalType(types...)
+	oneof.Type.StartPosition = GetPosition(ctx.LC().GetSymbol())
+	oneof.Type.EndPosition = GetPosition(ctx.RC().GetSymbol())
 
 	allOptions := ctx.AllOptionStatement()
 	for _, option := range allOptions {
@@ -171,7 +235,9 @@ func (v *MessageDefVisitor) VisitOneof(ctx *OneofContext) interface{} {
 func (v *MessageDefVisitor) VisitOneofField(ctx *OneofFieldContext) interface{} {
 	if typ := ctx.Type_(); typ != nil {
 		decl := &lang.ValueDecl{
-			Name: ctx.FieldName().GetText(),
+			StartPosition: GetPosition(ctx.GetStart()),
+			EndPosition:   GetPosition(ctx.GetStop()),
+			Name:          ctx.FieldName().GetText(),
 		}
 
 		if t, ok := typ.Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
@@ -179,7 +245,7 @@ func (v *MessageDefVisitor) VisitOneofField(ctx *OneofFieldContext) interface{}
 		}
 
 		number := ctx.FieldNumber().GetText()
-		value, _ := strconv.ParseInt(number, 10, 64)
+		value, _ := strconv.ParseInt(number, 0, 64)
 		decl.Type.Attributes = append(decl.Type.Attributes, lang.NewIntegerAttribute("protobuf", "number", value))
 
 		if options := ctx.FieldOptions(); options != nil {
@@ -195,18 +261,20 @@ func (v *MessageDefVisitor) VisitOneofField(ctx *OneofFieldContext) interface{}
 func (v *MessageDefVisitor) VisitMapField(ctx *MapFieldContext) interface{} {
 	if typ := ctx.Type_(); typ != nil {
 		decl := &lang.ValueDecl{
-			Name: ctx.MapName().GetText(),
-			Type: &lang.NominalType{},
+			StartPosition: GetPosition(ctx.GetStart()),
+			EndPosition:   GetPosition(ctx.GetStop()),
+			Name:          ctx.MapName().GetText(),
+			Type:          &lang.NominalType{},
 		}
 
-		if keyType, ok := typ.Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
+		if keyType, ok := ctx.KeyType().Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
 			if valueType, ok := typ.Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
 				decl.Type = lang.NewMapNominalType(keyType, valueType)
 			}
 		}
 
 		number := ctx.FieldNumber().GetText()
-		value, _ := strconv.ParseInt(number, 10, 64)
+		value, _ := strconv.ParseInt(number, 0, 64)
 		decl.Type.Attributes = append(decl.Type.Attributes, lang.NewIntegerAttribute("protobuf", "number", value))
 
 		if options := ctx.FieldOptions(); options != nil {
@@ -223,18 +291,22 @@ func (v *MessageDefVisitor) VisitReserved(ctx *ReservedContext) interface{} {
 	if ranges := ctx.Ranges(); ranges != nil {
 		if exprs, ok := ranges.Accept(v).([]*lang.Expression); ok {
 			return &lang.Attribute{
-				PackageName: "protobuf",
-				Name:        "reserved",
-				Arguments:   lang.NewArguments(exprs...),
+				StartPosition: GetPosition(ctx.GetStart()),
+				EndPosition:   GetPosition(ctx.GetStop()),
+				PackageName:   "protobuf",
+				Name:          "reserved",
+				Arguments:     lang.NewArguments(exprs...),
 			}
 		}
 	}
 	if fieldNames := ctx.ReservedFieldNames(); fieldNames != nil {
 		if exprs, ok := fieldNames.Accept(v).([]*lang.Expression); ok {
 			return &lang.Attribute{
-				PackageName: "protobuf",
-				Name:        "reserved",
-				Arguments:   lang.NewArguments(exprs...),
+				StartPosition: GetPosition(ctx.GetStart()),
+				EndPosition:   GetPosition(ctx.GetStop()),
+				PackageName:   "protobuf",
+				Name:          "reserved",
+				Arguments:     lang.NewArguments(exprs...),
 			}
 		}
 	}
@@ -291,9 +363,11 @@ func (v *MessageDefVisitor) VisitExtensions(ctx *ExtensionsContext) interface{}
 	if ranges := ctx.Ranges(); ranges != nil {
 		if exprs, ok := ranges.Accept(v).([]*lang.Expression); ok {
 			return &lang.Attribute{
-				PackageName: "protobuf",
-				Name:        "extensions",
-				Arguments:   lang.NewArguments(exprs...),
+				StartPosition: GetPosition(ctx.GetStart()),
+				EndPosition:   GetPosition(ctx.GetStop()),
+				PackageName:   "protobuf",
+				Name:          "extensions",
+				Arguments:     lang.NewArguments(exprs...),
 			}
 		}
 	}
@@ -0,0 +1,36 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+func TestMessageDefVisitor_VisitGroup(t *testing.T) {
+	group := `syntax = "proto2";
+message SearchResponse {
+  repeated group Result = 1 {
+    required string url = 2;
+  }
+}`
+	file, err := New(nil).ParseString(context.Empty(), group)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	decl := file.Statements[0].GetDeclaration().GetStructDecl()
+	if assert.Equal(t, 1, len(decl.StructDecls)) {
+		message := decl.StructDecls[0]
+		assert.Equal(t, "Result", message.Name)
+		assert.Equal(t, "url", message.Type.Fields[0].Name)
+	}
+	if assert.Equal(t, 1, len(decl.Type.Fields)) {
+		field := decl.Type.Fields[0]
+		assert.Equal(t, "result", field.Name)
+		assert.Equal(t, core.ArrayTypeName, field.Type.Name)
+		assert.Equal(t, "Result", field.Type.GenericArguments[0].Name)
+	}
+}
@@ -23,6 +23,8 @@ func (v *OptionStatementVisitor) VisitOptionStatement(ctx *OptionStatementContex
 					attribute.Arguments = append(attribute.Arguments, &lang.Argument{
 						Value: expr,
 					})
+					attribute.StartPosition = GetPosition(ctx.GetStart())
+					attribute.EndPosition = GetPosition(ctx.GetStop())
 
 					return attribute
 				}
@@ -38,7 +40,7 @@ func (v *OptionStatementVisitor) VisitOptionName(ctx *OptionNameContext) interfa
 		attr := &lang.Attribute{}
 
 		if identifier, ok := idents[0].Accept(NewIdentifierVisitor()).(*lang.Identifier); ok {
-			names := identifier.FullNames()
+			names := identifierNames(identifier)
 
 			attr.Name = names[len(names)-1]
 			if len(names) > 1 {
@@ -48,13 +50,13 @@ func (v *OptionStatementVisitor) VisitOptionName(ctx *OptionNameContext) interfa
 
 		if len(idents) > 1 {
 			if identifier, ok := idents[1].Accept(NewIdentifierVisitor()).(*lang.Identifier); ok {
-				attr.Fields = identifier.FullNames()
+				attr.Fields = identifierNames(identifier)
 			}
 		}
 		return attr
 	} else { // system options
 		if identifier, ok := idents[0].Accept(NewIdentifierVisitor()).(*lang.Identifier); ok {
-			names := identifier.FullNames()
+			names := identifierNames(identifier)
 
 			attr := &lang.Attribute{
 				Name: names[0],
@@ -69,3 +71,13 @@ func (v *OptionStatementVisitor) VisitOptionName(ctx *OptionNameContext) interfa
 
 	return nil
 }
+
+// identifierNames the dotted names of the identifier in order, as the lang.Identifier.FullNames sorts
+// the enclosing names
+func identifierNames(identifier *lang.Identifier) []string {
+	var names []string
+	for id := identifier; id != nil; id = id.Enclosing {
+		names = append([]string{id.Name}, names...)
+	}
+	return names
+}
@@ -31,10 +31,9 @@ func (p *Parser) ParseStream(fileName string, input antlr.CharStream) (*lang.Sou
 	errorListener := util.NewErrorListener(fileName, false)
 
 	parser := NewProtobuf2Parser(stream)
-	parser.AddErrorListener(errorListener)
 	parser.BuildParseTrees = true
 
-	tree := parser.Proto()
+	tree := util.ParseTwoStage(parser, errorListener, parser.Proto)
 	if sourceFile, ok := tree.Accept(NewProtoVisitor()).(*lang.SourceFile); ok {
 		if len(errorListener.Errors) == 0 {
 			return sourceFile, nil
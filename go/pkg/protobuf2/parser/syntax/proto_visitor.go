@@ -55,23 +55,27 @@ func (v *ProtoVisitor) VisitProto(ctx *ProtoContext) interface{} {
 }
 
 func (v *ProtoVisitor) VisitSyntax(ctx *SyntaxContext) interface{} {
-	syntax := ctx.PROTO2_LIT_DOBULE()
-	if syntax != nil {
-		return lang.NewStringAttribute("protobuf", "syntax", core.RemoveDoubleQuote(syntax.GetText()))
+	var attribute *lang.Attribute
+	if syntax := ctx.PROTO2_LIT_DOBULE(); syntax != nil {
+		attribute = lang.NewStringAttribute("protobuf", "syntax", core.RemoveDoubleQuote(syntax.GetText()))
+	} else if syntax = ctx.PROTO2_LIT_SINGLE(); syntax != nil {
+		attribute = lang.NewStringAttribute("protobuf", "syntax", core.RemoveSingleQuote(syntax.GetText()))
+	} else {
+		return nil
 	}
 
-	syntax = ctx.PROTO2_LIT_SINGLE()
-	if syntax != nil {
-		return lang.NewStringAttribute("protobuf", "syntax", core.RemoveSingleQuote(syntax.GetText()))
-	}
-
-	return nil
+	attribute.StartPosition = GetPosition(ctx.GetStart())
+	attribute.EndPosition = GetPosition(ctx.GetStop())
+	return attribute
 }
 
 func (v *ProtoVisitor) VisitImportStatement(ctx *ImportStatementContext) interface{} {
-	str := ctx.StrLit().GetText()
 	decl := &lang.ImportDecl{
-		ImportFileName: str,
+		StartPosition: GetPosition(ctx.GetStart()),
+		EndPosition:   GetPosition(ctx.GetStop()),
+	}
+	if str, ok := ctx.StrLit().Accept(NewConstantVisitor()).(*lang.StringLiteralExpr); ok {
+		decl.ImportFileName = str.Value
 	}
 	if pub := ctx.PUBLIC(); pub != nil {
 		decl.Filter = "public"
@@ -85,8 +89,10 @@ func (v *ProtoVisitor) VisitImportStatement(ctx *ImportStatementContext) interfa
 func (v *ProtoVisitor) VisitPackageStatement(ctx *PackageStatementContext) interface{} {
 	pkg, name := lang.ParseIdentifierName(ctx.FullIdent().GetText())
 	return &lang.PackageDecl{
-		PackageName: pkg,
-		Name:        name,
+		StartPosition: GetPosition(ctx.GetStart()),
+		EndPosition:   GetPosition(ctx.GetStop()),
+		PackageName:   pkg,
+		Name:          name,
 	}
 }
 
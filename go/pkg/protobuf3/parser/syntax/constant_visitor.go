@@ -19,8 +19,12 @@ func NewConstantVisitor() *ConstantVisitor {
 // VisitConstant returns the *lang.Expression
 func (v *ConstantVisitor) VisitConstant(ctx *ConstantContext) interface{} {
 	if fullIdent := ctx.FullIdent(); fullIdent != nil {
-		if expr, ok := fullIdent.Accept(NewIdentifierVisitor()).(*lang.IdentifierExpr); ok {
-			return lang.NewIdentifierExpression(expr)
+		if identifier, ok := fullIdent.Accept(NewIdentifierVisitor()).(*lang.Identifier); ok {
+			// the bool literals are also the keywords could be used as the identifiers
+			if names := identifier.FullNames(); len(names) == 1 && (names[0] == "true" || names[0] == "false") {
+				return lang.NewBoolLiteralExpressionFrom(names[0] == "true")
+			}
+			return lang.NewIdentifierExpression(&lang.IdentifierExpr{Identifier: identifier})
 		}
 	}
 
@@ -65,7 +69,7 @@ func (v *ConstantVisitor) VisitConstant(ctx *ConstantContext) interface{} {
 
 func (v *ConstantVisitor) VisitIntLit(ctx *IntLitContext) interface{} {
 	lit := ctx.GetText()
-	value, _ := strconv.ParseInt(lit, 10, 64)
+	value, _ := strconv.ParseInt(lit, 0, 64)
 	return lang.NewIntegerLiteralExpr(value)
 }
 
@@ -58,6 +58,11 @@ func (v *IdentifierVisitor) VisitMessageType(ctx *MessageTypeContext) interface{
 			cur.Enclosing = t
 			cur = t
 		}
+
+		// the fully qualified reference with the leading dot is enclosed by the unnamed root
+		if len(ctx.AllDOT()) > len(idents) {
+			cur.Enclosing = &lang.NominalType{}
+		}
 		return typ
 	}
 	return nil
@@ -78,6 +83,11 @@ func (v *IdentifierVisitor) VisitEnumType(ctx *EnumTypeContext) interface{} {
 			cur.Enclosing = t
 			cur = t
 		}
+
+		// the fully qualified reference with the leading dot is enclosed by the unnamed root
+		if len(ctx.AllDOT()) > len(idents) {
+			cur.Enclosing = &lang.NominalType{}
+		}
 		return typ
 	}
 	return nil
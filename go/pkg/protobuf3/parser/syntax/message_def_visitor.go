@@ -21,6 +21,8 @@ func (v *MessageDefVisitor) VisitMessageDef(ctx *MessageDefContext) interface{}
 		if body := ctx.MessageBody(); body != nil {
 			if decl, ok := body.Accept(v).(*lang.StructDecl); ok {
 				decl.Name = name.GetText()
+				decl.StartPosition = GetPosition(ctx.GetStart())
+				decl.EndPosition = GetPosition(ctx.GetStop())
 				return decl
 			}
 		}
@@ -31,7 +33,10 @@ func (v *MessageDefVisitor) VisitMessageDef(ctx *MessageDefContext) interface{}
 func (v *MessageDefVisitor) VisitMessageBody(ctx *MessageBodyContext) interface{} {
 	elements := ctx.AllMessageElement()
 
-	decl := &lang.StructDecl{Type: &lang.StructType{}}
+	decl := &lang.StructDecl{Type: &lang.StructType{
+		StartPosition: GetPosition(ctx.GetStart()),
+		EndPosition:   GetPosition(ctx.GetStop()),
+	}}
 	for _, element := range elements {
 		ele := element.Accept(v)
 		if valueDecl, ok := ele.(*lang.ValueDecl); ok {
@@ -79,8 +84,10 @@ func (v *MessageDefVisitor) VisitMessageElement(ctx *MessageElementContext) inte
 func (v *MessageDefVisitor) VisitField(ctx *FieldContext) interface{} {
 	if typ := ctx.Type_(); typ != nil {
 		decl := &lang.ValueDecl{
-			Name: ctx.FieldName().GetText(),
-			Type: &lang.NominalType{},
+			StartPosition: GetPosition(ctx.GetStart()),
+			EndPosition:   GetPosition(ctx.GetStop()),
+			Name:          ctx.FieldName().GetText(),
+			Type:          &lang.NominalType{},
 		}
 
 		if t, ok := typ.Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
@@ -92,7 +99,7 @@ func (v *MessageDefVisitor) VisitField(ctx *FieldContext) interface{} {
 		}
 
 		number := ctx.FieldNumber().GetText()
-		value, _ := strconv.ParseInt(number, 10, 64)
+		value, _ := strconv.ParseInt(number, 0, 64)
 		decl.Type.Attributes = append(decl.Type.Attributes, lang.NewIntegerAttribute("protobuf", "number", value))
 
 		if options := ctx.FieldOptions(); options != nil {
@@ -134,17 +141,26 @@ func (v *MessageDefVisitor) VisitFieldOption(ctx *FieldOptionContext) interface{
 }
 
 func (v *MessageDefVisitor) VisitOneof(ctx *OneofContext) interface{} {
-	oneof := &lang.ValueDecl{Name: ctx.OneofName().GetText()}
+	oneof := &lang.ValueDecl{
+		StartPosition: GetPosition(ctx.GetStart()),
+		EndPosition:   GetPosition(ctx.GetStop()),
+		Name:          ctx.OneofName().GetText(),
+	}
 
 	allFields := ctx.AllOneofField()
 	var types []*lang.NominalType
 	for _, field := range allFields {
 		if decl, ok := field.Accept(v).(*lang.ValueDecl); ok {
 			decl.Type.Attributes = append(decl.Type.Attributes, lang.NewStringAttribute("", "label", decl.Name))
+			// keep the positions of the field for the comments
+			decl.Type.StartPosition = decl.StartPosition
+			decl.Type.EndPosition = decl.EndPosition
 			types = append(types, decl.Type)
 		}
 	}
 	oneof.Type = lang.NewUnionNominalType(types...)
+	oneof.Type.StartPosition = GetPosition(ctx.LC().GetSymbol())
+	oneof.Type.EndPosition = GetPosition(ctx.RC().GetSymbol())
 
 	allOptions := ctx.AllOptionStatement()
 	for _, option := range allOptions {
@@ -159,7 +175,9 @@ func (v *MessageDefVisitor) VisitOneof(ctx *OneofContext) interface{} {
 func (v *MessageDefVisitor) VisitOneofField(ctx *OneofFieldContext) interface{} {
 	if typ := ctx.Type_(); typ != nil {
 		decl := &lang.ValueDecl{
-			Name: ctx.FieldName().GetText(),
+			StartPosition: GetPosition(ctx.GetStart()),
+			EndPosition:   GetPosition(ctx.GetStop()),
+			Name:          ctx.FieldName().GetText(),
 		}
 
 		if t, ok := typ.Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
@@ -167,7 +185,7 @@ func (v *MessageDefVisitor) VisitOneofField(ctx *OneofFieldContext) interface{}
 		}
 
 		number := ctx.FieldNumber().GetText()
-		value, _ := strconv.ParseInt(number, 10, 64)
+		value, _ := strconv.ParseInt(number, 0, 64)
 		decl.Type.Attributes = append(decl.Type.Attributes, lang.NewIntegerAttribute("protobuf", "number", value))
 
 		if options := ctx.FieldOptions(); options != nil {
@@ -183,18 +201,20 @@ func (v *MessageDefVisitor) VisitOneofField(ctx *OneofFieldContext) interface{}
 func (v *MessageDefVisitor) VisitMapField(ctx *MapFieldContext) interface{} {
 	if typ := ctx.Type_(); typ != nil {
 		decl := &lang.ValueDecl{
-			Name: ctx.MapName().GetText(),
-			Type: &lang.NominalType{},
+			StartPosition: GetPosition(ctx.GetStart()),
+			EndPosition:   GetPosition(ctx.GetStop()),
+			Name:          ctx.MapName().GetText(),
+			Type:          &lang.NominalType{},
 		}
 
-		if keyType, ok := typ.Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
+		if keyType, ok := ctx.KeyType().Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
 			if valueType, ok := typ.Accept(NewIdentifierVisitor()).(*lang.NominalType); ok {
 				decl.Type = lang.NewMapNominalType(keyType, valueType)
 			}
 		}
 
 		number := ctx.FieldNumber().GetText()
-		value, _ := strconv.ParseInt(number, 10, 64)
+		value, _ := strconv.ParseInt(number, 0, 64)
 		decl.Type.Attributes = append(decl.Type.Attributes, lang.NewIntegerAttribute("protobuf", "number", value))
 
 		if options := ctx.FieldOptions(); options != nil {
@@ -211,18 +231,22 @@ func (v *MessageDefVisitor) VisitReserved(ctx *ReservedContext) interface{} {
 	if ranges := ctx.Ranges(); ranges != nil {
 		if exprs, ok := ranges.Accept(v).([]*lang.Expression); ok {
 			return &lang.Attribute{
-				PackageName: "protobuf",
-				Name:        "reserved",
-				Arguments:   lang.NewArguments(exprs...),
+				StartPosition: GetPosition(ctx.GetStart()),
+				EndPosition:   GetPosition(ctx.GetStop()),
+				PackageName:   "protobuf",
+				Name:          "reserved",
+				Arguments:     lang.NewArguments(exprs...),
 			}
 		}
 	}
 	if fieldNames := ctx.ReservedFieldNames(); fieldNames != nil {
 		if exprs, ok := fieldNames.Accept(v).([]*lang.Expression); ok {
 			return &lang.Attribute{
-				PackageName: "protobuf",
-				Name:        "reserved",
-				Arguments:   lang.NewArguments(exprs...),
+				StartPosition: GetPosition(ctx.GetStart()),
+				EndPosition:   GetPosition(ctx.GetStop()),
+				PackageName:   "protobuf",
+				Name:          "reserved",
+				Arguments:     lang.NewArguments(exprs...),
 			}
 		}
 	}
@@ -14,8 +14,13 @@ func NewServiceDefVisitor() *ServiceDefVisitor {
 func (v *ServiceDefVisitor) VisitServiceDef(ctx *ServiceDefContext) interface{} {
 	if name := ctx.ServiceName(); name != nil {
 		decl := &lang.InterfaceDecl{
-			Name: name.GetText(),
-			Type: &lang.InterfaceType{},
+			StartPosition: GetPosition(ctx.GetStart()),
+			EndPosition:   GetPosition(ctx.GetStop()),
+			Name:          name.GetText(),
+			Type: &lang.InterfaceType{
+				StartPosition: GetPosition(ctx.LC().GetSymbol()),
+				EndPosition:   GetPosition(ctx.RC().GetSymbol()),
+			},
 		}
 
 		elements := ctx.AllServiceElement()
@@ -24,6 +29,9 @@ func (v *ServiceDefVisitor) VisitServiceDef(ctx *ServiceDefContext) interface{}
 			if funcDecl, ok := ele.(*lang.FunctionDecl); ok {
 				decl.Type.Methods = append(decl.Type.Methods, funcDecl)
 			}
+			if attr, ok := ele.(*lang.Attribute); ok {
+				decl.Attributes = append(decl.Attributes, attr)
+			}
 		}
 		return decl
 	}
@@ -34,14 +42,19 @@ func (v *ServiceDefVisitor) VisitServiceElement(ctx *ServiceElementContext) inte
 	if rpc := ctx.Rpc(); rpc != nil {
 		return rpc.Accept(v)
 	}
+	if option := ctx.OptionStatement(); option != nil {
+		return option.Accept(NewOptionStatementVisitor())
+	}
 	return nil
 }
 
 func (v *ServiceDefVisitor) VisitRpc(ctx *RpcContext) interface{} {
 	if name := ctx.RpcName(); name != nil {
 		decl := &lang.FunctionDecl{
-			Name:      name.GetText(),
-			Signature: &lang.FunctionSignature{},
+			StartPosition: GetPosition(ctx.GetStart()),
+			EndPosition:   GetPosition(ctx.GetStop()),
+			Name:          name.GetText(),
+			Signature:     &lang.FunctionSignature{},
 		}
 
 		allMessages := ctx.AllMessageType()
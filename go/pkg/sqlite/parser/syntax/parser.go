@@ -32,10 +32,9 @@ func (p Parser) ParseStream(fileName string, input antlr.CharStream) (*sql.Sourc
 	errorListener := util.NewErrorListener(fileName, false)
 
 	parser := NewSQLiteParser(stream)
-	parser.AddErrorListener(errorListener)
 	parser.BuildParseTrees = true
 
-	tree := parser.Parse()
+	tree := util.ParseTwoStage(parser, errorListener, parser.Parse)
 	if sourceFile, ok := tree.Accept(NewSqlSmtVisitor()).(*sql.SourceFile); ok {
 		if len(errorListener.Errors) == 0 {
 			return sourceFile, nil
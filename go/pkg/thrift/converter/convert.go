@@ -0,0 +1,138 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+// Converter converts the mojo package to the thrift files, each mojo source file to a thrift file in the
+// same path, the nested types are flattened with the names joined by the underscore, like `Book_Format`
+type Converter struct {
+	Files []*descriptor.File
+}
+
+func New() *Converter {
+	return &Converter{}
+}
+
+// ConvertPackage converts the source files of the package and its children
+func (c *Converter) ConvertPackage(ctx context.Context, pkg *lang.Package) error {
+	for _, sourceFile := range pkg.SourceFiles {
+		file, err := c.ConvertFile(ctx, sourceFile)
+		if err != nil {
+			return err
+		}
+		if !file.IsEmpty() {
+			c.Files = append(c.Files, file)
+		}
+	}
+
+	for _, child := range pkg.Children {
+		if err := c.ConvertPackage(ctx, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertFile converts the mojo source file to the thrift file, nil if it is a generic instantiated one
+func (c *Converter) ConvertFile(ctx context.Context, sourceFile *lang.SourceFile) (*descriptor.File, error) {
+	if sourceFile.IsGenericInstantiated() {
+		return nil, nil
+	}
+
+	f := &fileConverter{
+		source: sourceFile,
+		file:   descriptor.NewFile(FileName(sourceFile.FullName)),
+	}
+	f.file.SetNamespace("*", sourceFile.PackageName)
+
+	for _, statement := range sourceFile.Statements {
+		decl := statement.GetDeclaration()
+		var err error
+		switch {
+		case decl.GetStructDecl() != nil:
+			err = f.convertStruct(decl.GetStructDecl(), nil)
+		case decl.GetEnumDecl() != nil:
+			err = f.convertEnum(decl.GetEnumDecl(), nil)
+		case decl.GetTypeAliasDecl() != nil:
+			err = f.convertTypeAlias(decl.GetTypeAliasDecl())
+		case decl.GetInterfaceDecl() != nil:
+			err = f.convertInterface(decl.GetInterfaceDecl())
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sortStructs(f.file)
+	return f.file, nil
+}
+
+// FileName the thrift file converted from the mojo source file
+func FileName(sourceFileName string) string {
+	return strings.TrimSuffix(sourceFileName, ".mojo") + ".thrift"
+}
+
+type fileConverter struct {
+	source *lang.SourceFile
+	file   *descriptor.File
+}
+
+func document(doc *lang.Document) []string {
+	var lines []string
+	for _, line := range doc.GetLines() {
+		lines = append(lines, line.Content)
+	}
+	return lines
+}
+
+func isDisabled(attributes []*lang.Attribute) bool {
+	options, _ := lang.GetDisableGenerateAttribute(attributes)
+	return options.Including("thrift", "")
+}
+
+// flattenName the name of the nested type joined with the enclosing names
+func flattenName(names []string, name string) string {
+	return strings.Join(append(append([]string{}, names...), name), "_")
+}
+
+// sortStructs place the structs before the ones referencing them in the same file, as the thrift
+// compiler requires the types defined before being used
+func sortStructs(file *descriptor.File) {
+	visited := make(map[string]bool)
+	var sorted []*descriptor.Struct
+
+	var visit func(s *descriptor.Struct)
+	var visitType func(t *descriptor.Type)
+	visitType = func(t *descriptor.Type) {
+		if t == nil {
+			return
+		}
+		if t.IsContainer() {
+			visitType(t.Key)
+			visitType(t.Value)
+		} else if s := file.GetStruct(t.Name); s != nil {
+			visit(s)
+		}
+	}
+	visit = func(s *descriptor.Struct) {
+		if visited[s.Name] {
+			return
+		}
+		visited[s.Name] = true
+		for _, field := range s.Fields {
+			visitType(field.Type)
+		}
+		sorted = append(sorted, s)
+	}
+
+	for _, s := range file.Structs {
+		visit(s)
+	}
+	file.Structs = sorted
+}
@@ -0,0 +1,108 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/printer"
+)
+
+func convertTestPackage(t *testing.T) string {
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), "./testdata/mojo-test")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	c := New()
+	if !assert.NoError(t, c.ConvertPackage(context.Empty(), pkg)) || !assert.Equal(t, 1, len(c.Files)) {
+		t.FailNow()
+	}
+	assert.Equal(t, "test/book.thrift", c.Files[0].Name)
+
+	p := printer.New(nil).PrintFile(context.Empty(), c.Files[0])
+	assert.NoError(t, p.Error)
+	return p.Buffer.String()
+}
+
+func TestConverter_ConvertPackage(t *testing.T) {
+	content := convertTestPackage(t)
+
+	assert.Contains(t, content, "namespace * test")
+	assert.Contains(t, content, "enum Book_Format {\n    PAPER = 1\n    E_BOOK = 2\n}")
+	assert.Contains(t, content, "union Book_Source {\n    3: string isbn\n    4: string url\n}")
+	assert.Contains(t, content, "/** the book in the library */\nstruct Book {")
+	assert.Contains(t, content, "1: required string name")
+	assert.Contains(t, content, "2: Book_Format format")
+	assert.Contains(t, content, "3: Book_Source source")
+	assert.Contains(t, content, "5: list<Author> authors")
+	assert.Contains(t, content, "6: double price")
+	assert.Contains(t, content, "7: string create_time")
+	assert.NotContains(t, content, "include")
+	assert.Less(t, strings.Index(content, "struct Author {"), strings.Index(content, "struct Book {"))
+	assert.Contains(t, content, "Book getBook(1: string name)")
+	assert.Contains(t, content, "void deleteBook(2: string name)")
+}
+
+func TestConverter_ConvertPackage_TypeMappings(t *testing.T) {
+	config.Get().SetTypeMapping(config.ThriftTypeMappingTarget, "mojo.core.Timestamp", &config.TypeMapping{Type: "common.Timestamp", Import: "common.thrift"})
+	t.Cleanup(func() { delete(config.Get().Types, config.ThriftTypeMappingTarget) })
+
+	content := convertTestPackage(t)
+	assert.Contains(t, content, `include "common.thrift"`)
+	assert.Contains(t, content, "7: common.Timestamp create_time")
+}
+
+func TestFieldIDs_Check(t *testing.T) {
+	ids := make(fieldIDs)
+	id, err := ids.check("Book", "name", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), id)
+
+	_, err = ids.check("Book", "title", 1)
+	assert.Error(t, err)
+	_, err = ids.check("Book", "title", 19000)
+	assert.Error(t, err)
+	_, err = ids.check("Book", "title", 32768)
+	assert.Error(t, err)
+	_, err = ids.check("Book", "title", 0)
+	assert.Error(t, err)
+}
+
+func TestConverter_ConvertType_MojoPackage(t *testing.T) {
+	f := &fileConverter{
+		source: &lang.SourceFile{FullName: "test/place.mojo", PackageName: "test"},
+		file:   descriptor.NewFile("test/place.thrift"),
+	}
+	lngLat := &lang.NominalType{
+		PackageName:     "mojo.geom",
+		Name:            "LngLat",
+		TypeDeclaration: lang.NewStructTypeDeclaration(&lang.StructDecl{Name: "LngLat", SourceFileName: "mojo/geom/lng_lat.mojo"}),
+	}
+	_, err := f.convertType(lngLat)
+	assert.EqualError(t, err, "the type mojo.geom.LngLat is not supported by the thrift, map it by the types.thrift in the mojo.yaml")
+	assert.Empty(t, f.file.Includes)
+
+	config.Get().SetTypeMapping(config.ThriftTypeMappingTarget, "mojo.geom.LngLat", &config.TypeMapping{Type: "geom.LngLat", Import: "geom.thrift"})
+	t.Cleanup(func() { delete(config.Get().Types, config.ThriftTypeMappingTarget) })
+	typ, err := f.convertType(lngLat)
+	assert.NoError(t, err)
+	assert.Equal(t, "geom.LngLat", typ.Name)
+
+	// the types of the mojo packages are included when building the mojo packages themselves
+	f.source = &lang.SourceFile{FullName: "mojo/geom/polygon.mojo", PackageName: "mojo.geom"}
+	delete(config.Get().Types, config.ThriftTypeMappingTarget)
+	typ, err = f.convertType(lngLat)
+	assert.NoError(t, err)
+	assert.Equal(t, "lng_lat.LngLat", typ.Name)
+}
@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/core/go/pkg/mojo/core/strcase"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+// convertEnum converts the enum, the enumerators are in the screaming snake case, and the values are
+// the `@number` or the index, the same as the protobuf
+func (f *fileConverter) convertEnum(decl *lang.EnumDecl, enclosing []string) error {
+	if isDisabled(decl.Attributes) {
+		return nil
+	}
+
+	enum := descriptor.NewEnum(flattenName(enclosing, decl.Name))
+	enum.Document = document(decl.Document)
+	for i, enumerator := range decl.GetType().GetEnumerators() {
+		number, err := lang.GetIntegerAttribute(enumerator.Attributes, core.NumberAttributeName)
+		if err != nil {
+			number = int64(i)
+		}
+		value := enum.AppendValue(strcase.ToScreamingSnake(enumerator.Name), number)
+		value.Document = document(enumerator.Document)
+	}
+
+	f.file.Enums = append(f.file.Enums, enum)
+	return nil
+}
@@ -0,0 +1,81 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+func (f *fileConverter) convertInterface(decl *lang.InterfaceDecl) error {
+	if len(decl.GenericParameters) > 0 || isDisabled(decl.Attributes) {
+		return nil
+	}
+
+	service := descriptor.NewService(decl.Name)
+	service.Document = document(decl.Document)
+	for _, method := range decl.GetType().GetMethods() {
+		if compiler.IsClientStreamingMethod(method) || compiler.IsServerStreamingMethod(method) {
+			return fmt.Errorf("the streaming method %s.%s is not supported by the thrift", decl.Name, method.Name)
+		}
+
+		function, err := f.convertMethod(decl.Name, method)
+		if err != nil {
+			return err
+		}
+		service.Functions = append(service.Functions, function)
+	}
+
+	f.file.Services = append(f.file.Services, service)
+	return nil
+}
+
+// convertMethod converts the method to the function, the parameters are numbered by the sequence if
+// none of them has the `@number`, the same as the protobuf request message
+func (f *fileConverter) convertMethod(service string, method *lang.FunctionDecl) (*descriptor.Function, error) {
+	function := &descriptor.Function{
+		Document: document(method.Document),
+		Name:     method.Name,
+	}
+
+	scope := service + "." + method.Name
+	parameters := method.GetSignature().GetParameters()
+	numbered := false
+	for _, param := range parameters {
+		if param.HasAttribute(core.NumberAttributeName) {
+			numbered = true
+		}
+	}
+
+	ids := make(fieldIDs)
+	for i, param := range parameters {
+		var id int32
+		var err error
+		if numbered {
+			id, err = ids.Check(scope, param.Name, param.Type.Attributes)
+		} else {
+			id, err = ids.check(scope, param.Name, int64(i+1))
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		typ, err := f.convertType(param.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the parameter %s of %s: %w", param.Name, scope, err)
+		}
+		function.Params = append(function.Params, &descriptor.Field{ID: id, Type: typ, Name: param.Name})
+	}
+
+	if result := method.GetSignature().GetResultType(); result != nil && result.GetFullName() != core.NullTypeFullName {
+		typ, err := f.convertType(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the result of %s: %w", scope, err)
+		}
+		function.Result = typ
+	}
+	return function, nil
+}
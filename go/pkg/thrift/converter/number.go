@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+)
+
+// the field ids follow the numbering rules of the protobuf, so the same mojo types could be converted
+// to both, and limited to the i16 by the thrift
+const (
+	maxFieldID           = 32767
+	reservedFieldIDStart = 19000
+	reservedFieldIDEnd   = 19999
+)
+
+// fieldIDs checks the ids of the fields in the struct or the parameters of the function are valid and unique
+type fieldIDs map[int64]string
+
+// Check the `@number` of the field
+func (ids fieldIDs) Check(scope string, name string, attributes []*lang.Attribute) (int32, error) {
+	number, err := lang.GetIntegerAttribute(attributes, core.NumberAttributeName)
+	if err != nil {
+		return 0, fmt.Errorf("has not set the number of the field %s in %s", name, scope)
+	}
+	return ids.check(scope, name, number)
+}
+
+func (ids fieldIDs) check(scope string, name string, number int64) (int32, error) {
+	if number <= 0 || number > maxFieldID {
+		return 0, fmt.Errorf("the number %d of the field %s in %s should be in the range [1, %d]", number, name, scope, maxFieldID)
+	}
+	if number >= reservedFieldIDStart && number <= reservedFieldIDEnd {
+		return 0, fmt.Errorf("the number %d of the field %s in %s is reserved", number, name, scope)
+	}
+	if exist, ok := ids[number]; ok {
+		return 0, fmt.Errorf("the number %d of the field %s in %s is already used by the field %s", number, name, scope, exist)
+	}
+	ids[number] = name
+	return int32(number), nil
+}
@@ -0,0 +1,131 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/core/go/pkg/mojo/core/strcase"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+func (f *fileConverter) convertStruct(decl *lang.StructDecl, enclosing []string) error {
+	if len(decl.GenericParameters) > 0 || isDisabled(decl.Attributes) {
+		return nil
+	}
+
+	names := append(append([]string{}, enclosing...), decl.Name)
+	for _, enum := range decl.EnumDecls {
+		if err := f.convertEnum(enum, names); err != nil {
+			return err
+		}
+	}
+	for _, nested := range decl.StructDecls {
+		if err := f.convertStruct(nested, names); err != nil {
+			return err
+		}
+	}
+
+	s := descriptor.NewStruct(descriptor.StructKind, flattenName(enclosing, decl.Name))
+	s.Document = document(decl.Document)
+	ids := make(fieldIDs)
+	for _, field := range structFields(decl) {
+		if isUnion(field.Type) && !lang.HasAttribute(field.Type.Attributes, core.NumberAttributeName) {
+			// the inline union is converted to the union named with the field like `Book_Source`, and the
+			// field takes the least id of the union members, which is unique in the struct as the protobuf oneof
+			union, err := f.convertUnion(flattenName(names, strcase.ToCamel(field.Name)), field.Type, s.Name, ids)
+			if err != nil {
+				return err
+			}
+			s.AppendField(&descriptor.Field{
+				Document: document(field.Document),
+				ID:       leastID(union),
+				Type:     descriptor.NewType(union.Name),
+				Name:     field.Name,
+			})
+			continue
+		}
+
+		id, err := ids.Check(s.Name, field.Name, field.Type.Attributes)
+		if err != nil {
+			return err
+		}
+		typ, err := f.convertType(field.Type)
+		if err != nil {
+			return fmt.Errorf("failed to convert the field %s in %s: %w", field.Name, s.Name, err)
+		}
+		s.AppendField(&descriptor.Field{
+			Document:     document(field.Document),
+			ID:           id,
+			Requiredness: requiredness(field),
+			Type:         typ,
+			Name:         field.Name,
+		})
+	}
+
+	f.file.Structs = append(f.file.Structs, s)
+	return nil
+}
+
+// structFields the fields of the struct, including the ones inherited, which are placed first
+func structFields(decl *lang.StructDecl) []*lang.ValueDecl {
+	var fields []*lang.ValueDecl
+	for _, inherit := range decl.GetType().GetInherits() {
+		if s := inherit.GetTypeDeclaration().GetStructDecl(); s != nil {
+			fields = append(fields, structFields(s)...)
+		}
+	}
+	return append(fields, decl.GetType().GetFields()...)
+}
+
+func requiredness(field *lang.ValueDecl) string {
+	if field.HasAttribute(core.RequiredAttributeName) {
+		return descriptor.Required
+	}
+	return ""
+}
+
+func isUnion(typ *lang.NominalType) bool {
+	return typ.GetFullName() == core.UnionTypeFullName
+}
+
+// convertUnion converts the union type to the thrift union, the members are named by the `@label`,
+// or the snake case of the type name
+func (f *fileConverter) convertUnion(name string, typ *lang.NominalType, scope string, ids fieldIDs) (*descriptor.Struct, error) {
+	union := descriptor.NewStruct(descriptor.UnionKind, name)
+	for _, argument := range typ.GenericArguments {
+		label, _ := lang.GetStringAttribute(argument.Attributes, core.LabelAttributeName)
+		if len(label) == 0 {
+			label = strcase.ToSnake(argument.Name)
+		}
+
+		id, err := ids.Check(scope, label, argument.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		t, err := f.convertType(argument)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the union member %s in %s: %w", label, name, err)
+		}
+		union.AppendField(&descriptor.Field{
+			Document: document(argument.Document),
+			ID:       id,
+			Type:     t,
+			Name:     label,
+		})
+	}
+
+	f.file.Structs = append(f.file.Structs, union)
+	return union, nil
+}
+
+func leastID(s *descriptor.Struct) int32 {
+	var id int32
+	for _, field := range s.Fields {
+		if id == 0 || field.ID < id {
+			id = field.ID
+		}
+	}
+	return id
+}
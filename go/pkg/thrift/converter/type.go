@@ -0,0 +1,94 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+// convertType converts the mojo type to the thrift type, the types declared in the other files are
+// included and prefixed with the include name
+func (f *fileConverter) convertType(typ *lang.NominalType) (*descriptor.Type, error) {
+	fullName := typ.GetFullName()
+	if mapping := mappedType(fullName); mapping != nil {
+		if len(mapping.Import) > 0 {
+			f.file.AppendInclude(mapping.Import)
+		}
+		return descriptor.NewType(mapping.Type), nil
+	}
+	if base, ok := baseTypes[fullName]; ok {
+		return descriptor.NewType(base), nil
+	}
+	if base, ok := coreTypes[fullName]; ok {
+		return descriptor.NewType(base), nil
+	}
+
+	switch fullName {
+	case core.ArrayTypeFullName:
+		if len(typ.GenericArguments) != 1 {
+			return nil, fmt.Errorf("the array type should have one generic argument")
+		}
+		element, err := f.convertType(typ.GenericArguments[0])
+		if err != nil {
+			return nil, err
+		}
+		if lang.HasAttribute(typ.Attributes, core.UniqueAttributeName) {
+			return descriptor.NewSetType(element), nil
+		}
+		return descriptor.NewListType(element), nil
+	case core.MapTypeFullName:
+		if len(typ.GenericArguments) != 2 {
+			return nil, fmt.Errorf("the map type should have two generic arguments")
+		}
+		key, err := f.convertType(typ.GenericArguments[0])
+		if err != nil {
+			return nil, err
+		}
+		value, err := f.convertType(typ.GenericArguments[1])
+		if err != nil {
+			return nil, err
+		}
+		return descriptor.NewMapType(key, value), nil
+	case core.UnionTypeFullName:
+		return nil, fmt.Errorf("the union type should be the type of the field or the type alias")
+	}
+
+	if isMojoPackage(typ.GetPackageName()) && !isMojoPackage(f.source.PackageName) {
+		return nil, fmt.Errorf("the type %s is not supported by the thrift, map it by the types.thrift in the mojo.yaml", fullName)
+	}
+
+	sourceFileName := declarationFileName(typ.TypeDeclaration)
+	if len(sourceFileName) == 0 {
+		return nil, fmt.Errorf("the type %s is not resolved", fullName)
+	}
+
+	name := flattenName(typ.GetEnclosingNames(), typ.Name)
+	if sourceFileName != f.source.FullName {
+		fileName := FileName(sourceFileName)
+		f.file.AppendInclude(fileName)
+		name = descriptor.IncludeName(fileName) + "." + name
+	}
+	return descriptor.NewType(name), nil
+}
+
+func declarationFileName(decl *lang.TypeDeclaration) string {
+	switch {
+	case decl.GetStructDecl() != nil:
+		return decl.GetStructDecl().SourceFileName
+	case decl.GetEnumDecl() != nil:
+		return decl.GetEnumDecl().SourceFileName
+	case decl.GetTypeAliasDecl() != nil:
+		return decl.GetTypeAliasDecl().SourceFileName
+	}
+	return ""
+}
+
+// isMojoPackage the mojo.* packages are not generated to the thrift files except building themselves,
+// so their types must be mapped by the types.thrift
+func isMojoPackage(name string) bool {
+	return name == mojoPackageName || strings.HasPrefix(name, mojoPackageName+".")
+}
@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+// convertTypeAlias converts the type alias to the typedef, or the union if it is an alias of the union
+func (f *fileConverter) convertTypeAlias(decl *lang.TypeAliasDecl) error {
+	if len(decl.GenericParameters) > 0 || isDisabled(decl.Attributes) {
+		return nil
+	}
+
+	if isUnion(decl.Type) {
+		union, err := f.convertUnion(decl.Name, decl.Type, decl.Name, make(fieldIDs))
+		if err != nil {
+			return err
+		}
+		union.Document = document(decl.Document)
+		return nil
+	}
+
+	typ, err := f.convertType(decl.Type)
+	if err != nil {
+		return err
+	}
+	f.file.Typedefs = append(f.file.Typedefs, &descriptor.Typedef{
+		Document: document(decl.Document),
+		Name:     decl.Name,
+		Type:     typ,
+	})
+	return nil
+}
@@ -0,0 +1,74 @@
+package converter
+
+import (
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+const mojoPackageName = "mojo"
+
+// baseTypes the mojo.core types to the thrift base types, the unsigned integers are widened to the
+// signed ones as the thrift has no unsigned integer, and the wrapper types are the optional base types
+var baseTypes = map[string]string{
+	core.BoolTypeFullName:     descriptor.BoolType,
+	core.Int8TypeFullName:     descriptor.I8Type,
+	core.ByteTypeFullName:     descriptor.I8Type,
+	core.Int16TypeFullName:    descriptor.I16Type,
+	core.Int32TypeFullName:    descriptor.I32Type,
+	core.Int64TypeFullName:    descriptor.I64Type,
+	core.IntTypeFullName:      descriptor.I64Type,
+	core.UInt8TypeFullName:    descriptor.I16Type,
+	core.UInt16TypeFullName:   descriptor.I32Type,
+	core.UInt32TypeFullName:   descriptor.I64Type,
+	core.UInt64TypeFullName:   descriptor.I64Type,
+	core.UIntTypeFullName:     descriptor.I64Type,
+	core.SizeTypeFullName:     descriptor.I64Type,
+	core.PositiveTypeFullName: descriptor.I64Type,
+	core.NegativeTypeFullName: descriptor.I64Type,
+	core.Float32TypeFullName:  descriptor.DoubleType,
+	core.FloatTypeFullName:    descriptor.DoubleType,
+	core.Float64TypeFullName:  descriptor.DoubleType,
+	core.DoubleTypeFullName:   descriptor.DoubleType,
+	core.StringTypeFullName:   descriptor.StringType,
+	core.BytesTypeFullName:    descriptor.BinaryType,
+
+	core.BoolValueTypeFullName:    descriptor.BoolType,
+	core.Int32ValueTypeFullName:   descriptor.I32Type,
+	core.Int64ValueTypeFullName:   descriptor.I64Type,
+	core.IntValueTypeFullName:     descriptor.I64Type,
+	core.UInt32ValueTypeFullName:  descriptor.I64Type,
+	core.UInt64ValueTypeFullName:  descriptor.I64Type,
+	core.UIntValueTypeFullName:    descriptor.I64Type,
+	core.Float32ValueTypeFullName: descriptor.DoubleType,
+	core.FloatValueTypeFullName:   descriptor.DoubleType,
+	core.Float64ValueTypeFullName: descriptor.DoubleType,
+	core.DoubleValueTypeFullName:  descriptor.DoubleType,
+	core.StringValueTypeFullName:  descriptor.StringType,
+	core.BytesValueTypeFullName:   descriptor.BinaryType,
+}
+
+// coreTypes the mojo.core types converted to the thrift string in their JSON formats by default, like
+// the timestamp in RFC 3339 and the duration like `1.5s`, for no thrift file is generated for the mojo.core,
+// the other mojo.core types should be mapped by the `types.thrift` in the mojo.yaml
+var coreTypes = map[string]string{
+	core.TimestampTypeFullName:    descriptor.StringType,
+	core.DurationTypeFullName:     descriptor.StringType,
+	core.DateTypeFullName:         descriptor.StringType,
+	core.DateTimeTypeFullName:     descriptor.StringType,
+	core.TimeOfDayTypeFullName:    descriptor.StringType,
+	core.UrlTypeFullName:          descriptor.StringType,
+	core.UuidTypeFullName:         descriptor.StringType,
+	core.EmailAddressTypeFullName: descriptor.StringType,
+	core.DomainTypeFullName:       descriptor.StringType,
+	core.VersionTypeFullName:      descriptor.StringType,
+	core.MediaTypeTypeFullName:    descriptor.StringType,
+	core.RegexTypeFullName:        descriptor.StringType,
+	core.FieldMaskTypeFullName:    descriptor.StringType,
+}
+
+// mappedType the thrift type which the mojo type is mapped to by the `types.thrift` in the mojo.yaml
+func mappedType(fullName string) *config.TypeMapping {
+	return config.Get().GetTypeMapping(config.ThriftTypeMappingTarget, fullName)
+}
@@ -0,0 +1,25 @@
+package descriptor
+
+type Enum struct {
+	Document []string
+	Name     string
+	Values   []*EnumValue
+}
+
+type EnumValue struct {
+	Document []string
+	Name     string
+	Value    int64
+}
+
+func NewEnum(name string) *Enum {
+	return &Enum{Name: name}
+}
+
+func (e *Enum) AppendValue(name string, value int64) *EnumValue {
+	v := &EnumValue{Name: name, Value: value}
+	if e != nil {
+		e.Values = append(e.Values, v)
+	}
+	return v
+}
@@ -0,0 +1,98 @@
+package descriptor
+
+import (
+	"sort"
+	"strings"
+)
+
+// File the thrift IDL file, the definitions are kept in the kinds, and printed in the order of
+// the enums, typedefs, structs and services
+type File struct {
+	// the path of the file, like `acme/library/book.thrift`, which is also the path included by the others
+	Name     string
+	Document []string
+
+	Includes   []string
+	Namespaces []*Namespace
+
+	Typedefs []*Typedef
+	Enums    []*Enum
+	Structs  []*Struct
+	Services []*Service
+}
+
+// Namespace the namespace of the generated code in the language, the scope `*` for all the languages
+type Namespace struct {
+	Scope string
+	Name  string
+}
+
+func NewFile(name string) *File {
+	return &File{Name: name}
+}
+
+// IncludeName the prefix of the definitions referenced in the including files, which is the base name of the file
+func IncludeName(fileName string) string {
+	name := fileName[strings.LastIndex(fileName, "/")+1:]
+	return strings.TrimSuffix(name, ".thrift")
+}
+
+func (f *File) AppendInclude(fileName string) *File {
+	if f != nil && fileName != f.Name {
+		for _, include := range f.Includes {
+			if include == fileName {
+				return f
+			}
+		}
+		f.Includes = append(f.Includes, fileName)
+		sort.Strings(f.Includes)
+	}
+	return f
+}
+
+func (f *File) SetNamespace(scope string, name string) *File {
+	if f != nil {
+		for _, namespace := range f.Namespaces {
+			if namespace.Scope == scope {
+				namespace.Name = name
+				return f
+			}
+		}
+		f.Namespaces = append(f.Namespaces, &Namespace{Scope: scope, Name: name})
+	}
+	return f
+}
+
+// GetNamespace the namespace of the scope, or the one of the `*` scope
+func (f *File) GetNamespace(scope string) string {
+	if f != nil {
+		name := ""
+		for _, namespace := range f.Namespaces {
+			if namespace.Scope == scope {
+				return namespace.Name
+			}
+			if namespace.Scope == "*" {
+				name = namespace.Name
+			}
+		}
+		return name
+	}
+	return ""
+}
+
+// IsEmpty the file has no definition
+func (f *File) IsEmpty() bool {
+	return f == nil || len(f.Typedefs)+len(f.Enums)+len(f.Structs)+len(f.Services) == 0
+}
+
+// GetStruct the struct, union or exception defined in the file
+func (f *File) GetStruct(name string) *Struct {
+	if f != nil {
+		for _, s := range f.Structs {
+			if s.Name == name {
+				return s
+			}
+		}
+	}
+	return nil
+}
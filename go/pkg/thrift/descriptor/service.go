@@ -0,0 +1,24 @@
+package descriptor
+
+type Service struct {
+	Document []string
+	Name     string
+
+	// the service extended, prefixed with the include name if defined in the included file
+	Extends   string
+	Functions []*Function
+}
+
+func NewService(name string) *Service {
+	return &Service{Name: name}
+}
+
+// Function the function of the service, the result is nil for the void function
+type Function struct {
+	Document []string
+	Oneway   bool
+	Result   *Type
+	Name     string
+	Params   []*Field
+	Throws   []*Field
+}
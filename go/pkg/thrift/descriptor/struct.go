@@ -0,0 +1,42 @@
+package descriptor
+
+// the kinds of the struct like definitions
+const (
+	StructKind    = "struct"
+	UnionKind     = "union"
+	ExceptionKind = "exception"
+)
+
+// the requiredness of the field, the default one is neither required nor optional
+const (
+	Required = "required"
+	Optional = "optional"
+)
+
+// Struct the struct, union or exception
+type Struct struct {
+	Document []string
+	Kind     string
+	Name     string
+	Fields   []*Field
+}
+
+func NewStruct(kind string, name string) *Struct {
+	return &Struct{Kind: kind, Name: name}
+}
+
+func (s *Struct) AppendField(field *Field) *Struct {
+	if s != nil && field != nil {
+		s.Fields = append(s.Fields, field)
+	}
+	return s
+}
+
+// Field the field of the struct, or the parameter and the exception of the function
+type Field struct {
+	Document     []string
+	ID           int32
+	Requiredness string
+	Type         *Type
+	Name         string
+}
@@ -0,0 +1,86 @@
+package descriptor
+
+// the base types of the thrift
+const (
+	BoolType   = "bool"
+	ByteType   = "byte"
+	I8Type     = "i8"
+	I16Type    = "i16"
+	I32Type    = "i32"
+	I64Type    = "i64"
+	DoubleType = "double"
+	StringType = "string"
+	BinaryType = "binary"
+
+	ListType = "list"
+	SetType  = "set"
+	MapType  = "map"
+)
+
+var baseTypes = map[string]bool{
+	BoolType:   true,
+	ByteType:   true,
+	I8Type:     true,
+	I16Type:    true,
+	I32Type:    true,
+	I64Type:    true,
+	DoubleType: true,
+	StringType: true,
+	BinaryType: true,
+}
+
+// IsBaseType the thrift base type, like `i32` and `string`
+func IsBaseType(name string) bool {
+	return baseTypes[name]
+}
+
+// Type the field type, the base type, the container type, or the name of the definition which is
+// prefixed with the include name if defined in the included file, like `common.Money`
+type Type struct {
+	Name string
+
+	// the key type of the map
+	Key *Type
+	// the element type of the list and set, or the value type of the map
+	Value *Type
+}
+
+func NewType(name string) *Type {
+	return &Type{Name: name}
+}
+
+func NewListType(element *Type) *Type {
+	return &Type{Name: ListType, Value: element}
+}
+
+func NewSetType(element *Type) *Type {
+	return &Type{Name: SetType, Value: element}
+}
+
+func NewMapType(key *Type, value *Type) *Type {
+	return &Type{Name: MapType, Key: key, Value: value}
+}
+
+func (t *Type) IsContainer() bool {
+	return t != nil && (t.Name == ListType || t.Name == SetType || t.Name == MapType)
+}
+
+func (t *Type) String() string {
+	if t == nil {
+		return "void"
+	}
+	switch t.Name {
+	case ListType, SetType:
+		return t.Name + "<" + t.Value.String() + ">"
+	case MapType:
+		return t.Name + "<" + t.Key.String() + ", " + t.Value.String() + ">"
+	}
+	return t.Name
+}
+
+// Typedef the alias of the type, like `typedef string Url`
+type Typedef struct {
+	Document []string
+	Name     string
+	Type     *Type
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/converter"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/printer"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+type Generator struct {
+}
+
+func New() *Generator {
+	return &Generator{}
+}
+
+func (g *Generator) GeneratePackageTo(pkg *lang.Package, out string) error {
+	outs, err := g.GeneratePackage(pkg)
+	if err != nil {
+		return err
+	}
+	return g.writeGeneratedFiles(outs, out)
+}
+
+func (g *Generator) GenerateFilesTo(files []*descriptor.File, out string) error {
+	outs, err := g.GenerateFiles(files)
+	if err != nil {
+		return err
+	}
+	return g.writeGeneratedFiles(outs, out)
+}
+
+func (g *Generator) GeneratePackage(pkg *lang.Package) ([]*util.GeneratedFile, error) {
+	c := converter.New()
+	if err := c.ConvertPackage(context.Empty(), pkg); err != nil {
+		return nil, err
+	}
+	return g.GenerateFiles(c.Files)
+}
+
+func (g *Generator) GenerateFiles(files []*descriptor.File) ([]*util.GeneratedFile, error) {
+	var out []*util.GeneratedFile
+	for _, file := range files {
+		p := printer.New(nil)
+		p.PrintFile(context.Empty(), file)
+		if p.Printer.Error != nil {
+			return nil, p.Printer.Error
+		}
+
+		if content := p.Buffer.String(); len(content) > 0 {
+			out = append(out, &util.GeneratedFile{
+				Name:    file.Name,
+				Content: content,
+			})
+		} else {
+			logs.Infow("generate an empty file", "name", file.Name)
+		}
+	}
+	return out, nil
+}
+
+func (g *Generator) writeGeneratedFiles(files []*util.GeneratedFile, out string) error {
+	guard := &util.PathGuard{
+		OnlyClearGenerated: true,
+		Suffixes:           []string{".thrift"},
+		Target:             "thrift",
+	}
+	// record the output even without any file, so the stale files are pruned
+	if err := guard.Record(out); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := file.WriteTo(out, guard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package importer
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/mojo/printer"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/parser/semantic"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/parser/syntax"
+	"github.com/mojo-lang/mojo/go/pkg/util"
+)
+
+const defaultVersion = "0.1.0"
+
+// Importer imports a tree of the thrift files into a mojo package, the `namespace *` of the thrift
+// files become the sub packages of the mojo package, which lies in the `mojo/<package path>` directories
+type Importer struct {
+	// the directory of the thrift files
+	Path string
+
+	// the mojo package name, the common prefix of the thrift namespaces if not specified
+	PackageName string
+
+	// the version in the package.mojo, 0.1.0 if not specified
+	Version string
+
+	// the type mappings between the mojo and the thrift types, keyed by the full name of the mojo type
+	TypeMappings map[string]*config.TypeMapping
+
+	files []*semantic.File
+}
+
+func New(path string) *Importer {
+	return &Importer{Path: path}
+}
+
+// Import parse the thrift files and convert them to the mojo source files, the full name of the
+// source file is the path relative to the package root, including the `package.mojo`
+func (i *Importer) Import(ctx context.Context) ([]*lang.SourceFile, error) {
+	if err := i.load(ctx); err != nil {
+		return nil, err
+	}
+	if err := i.checkIncludes(); err != nil {
+		return nil, err
+	}
+
+	pkgName, err := i.packageName()
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := semantic.NewConverter(i.files...).WithTypeMappings(i.TypeMappings).Convert()
+	if err != nil {
+		return nil, err
+	}
+
+	sourceFiles := []*lang.SourceFile{i.packageFile(pkgName)}
+	for index, sourceFile := range converted {
+		if len(sourceFile.Statements) > 0 {
+			sourceFile.FullName = sourceFileName(i.files[index])
+			sourceFiles = append(sourceFiles, sourceFile)
+		}
+	}
+	return sourceFiles, nil
+}
+
+// Generate import the thrift files and print them to the mojo source files
+func (i *Importer) Generate(ctx context.Context) (util.GeneratedFiles, error) {
+	sourceFiles, err := i.Import(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files util.GeneratedFiles
+	for _, sourceFile := range sourceFiles {
+		p := printer.New(&printer.Config{}).PrintSourceFile(context.WithType(ctx, sourceFile), sourceFile)
+		if err = p.GetError(); err != nil {
+			logs.Errorw("failed to print the mojo file", "file", sourceFile.FullName, "error", err.Error())
+			return nil, err
+		}
+
+		content := p.Buffer.String()
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		files = append(files, &util.GeneratedFile{Name: sourceFile.FullName, Content: content})
+	}
+	return files, nil
+}
+
+func (i *Importer) load(ctx context.Context) error {
+	parser := syntax.New()
+	err := filepath.WalkDir(i.Path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != i.Path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(p) != ".thrift" {
+			return nil
+		}
+
+		name, err := filepath.Rel(i.Path, p)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+
+		file, err := parser.ParseFile(p)
+		if err != nil {
+			return fmt.Errorf("failed to parse the thrift file %s: %w", name, err)
+		}
+		i.files = append(i.files, semantic.NewFile(name, file))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(i.files) == 0 {
+		return fmt.Errorf("no thrift file found in %s", i.Path)
+	}
+	sort.Slice(i.files, func(x, y int) bool {
+		return i.files[x].Name < i.files[y].Name
+	})
+	return nil
+}
+
+func (i *Importer) checkIncludes() error {
+	names := make(map[string]bool)
+	for _, file := range i.files {
+		names[file.Name] = true
+	}
+
+	for _, file := range i.files {
+		for _, include := range file.Descriptor.Includes {
+			resolved := false
+			for _, p := range file.IncludePaths(include) {
+				resolved = resolved || names[p]
+			}
+			if !resolved {
+				return fmt.Errorf("failed to resolve the thrift file %s included by %s", include, file.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// packageName the specified package name, or the common prefix of the thrift namespaces,
+// the thrift files without the `namespace *` will be placed in the mojo package
+func (i *Importer) packageName() (string, error) {
+	name := i.PackageName
+	if len(name) > 0 {
+		for _, file := range i.files {
+			if len(file.Package) > 0 && file.Package != name && !strings.HasPrefix(file.Package, name+".") {
+				return "", fmt.Errorf("the package %s of the thrift file %s is not in the package %s", file.Package, file.Name, name)
+			}
+		}
+	} else {
+		var prefix []string
+		for _, file := range i.files {
+			if len(file.Package) == 0 {
+				continue
+			}
+			segments := strings.Split(file.Package, ".")
+			if prefix == nil {
+				prefix = segments
+				continue
+			}
+			l := 0
+			for l < len(prefix) && l < len(segments) && prefix[l] == segments[l] {
+				l++
+			}
+			prefix = prefix[:l]
+		}
+
+		name = strings.Join(prefix, ".")
+		if len(name) == 0 {
+			return "", fmt.Errorf("the thrift namespaces in %s have no common prefix, please specify the mojo package name", i.Path)
+		}
+	}
+
+	for _, file := range i.files {
+		if len(file.Package) == 0 {
+			file.Package = name
+		}
+	}
+	return name, nil
+}
+
+func (i *Importer) packageFile(name string) *lang.SourceFile {
+	version := i.Version
+	if len(version) == 0 {
+		version = defaultVersion
+	}
+
+	decl := &lang.PackageDecl{
+		Name: name,
+		PackageLiteralExpr: &lang.ObjectLiteralExpr{
+			Fields: []*lang.ObjectLiteralExpr_Field{{
+				Name:  "version",
+				Value: lang.NewStringLiteralExpressionFrom(version),
+			}},
+		},
+	}
+	return &lang.SourceFile{
+		Name:       "package.mojo",
+		FullName:   "package.mojo",
+		Statements: []*lang.Statement{lang.NewPackageDeclStatement(decl)},
+	}
+}
+
+// sourceFileName the mojo source file for the thrift file, in the directory of its package
+func sourceFileName(file *semantic.File) string {
+	name := strings.TrimSuffix(path.Base(file.Name), ".thrift") + ".mojo"
+	return path.Join("mojo", strings.ReplaceAll(file.Package, ".", "/"), name)
+}
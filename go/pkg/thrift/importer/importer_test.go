@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/compiler"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/mpm"
+	_ "github.com/mojo-lang/mojo/go/pkg/mojo/parser"
+	"github.com/mojo-lang/mojo/go/pkg/plugin"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/generator"
+)
+
+func TestImporter_Generate(t *testing.T) {
+	files, err := New("./testdata/library").Generate(context.Empty())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	dir := t.TempDir()
+	contents := make(map[string]string)
+	for _, file := range files {
+		contents[file.Name] = file.Content
+		name := filepath.Join(dir, file.Name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		assert.NoError(t, os.WriteFile(name, []byte(file.Content), 0o644))
+	}
+	for name, content := range contents {
+		t.Log(name, "\n", content)
+	}
+
+	assert.Contains(t, contents["package.mojo"], "package acme {")
+	assert.Contains(t, contents["mojo/acme/common/money.mojo"], "/// Money the amount of the money\ntype Money {")
+	assert.Contains(t, contents["mojo/acme/common/money.mojo"], "currency_code: String! @1")
+
+	book := contents["mojo/acme/library/book.mojo"]
+	assert.Contains(t, book, "type BookName = String")
+	assert.Contains(t, book, `type Source = String @8 @label("isbn") | String @9 @label("url")`)
+	assert.Contains(t, book, "e_book      @2")
+	assert.Contains(t, book, "price: acme.common.Money @4")
+	assert.Contains(t, book, "labels: {String: String} @5")
+	assert.Contains(t, book, "tags: [String] @7 @unique")
+	assert.Contains(t, book, "    /// get the book by the name\n    getBook(name BookName @1) -> Book")
+	assert.Contains(t, book, "list_books(page_size Int32 @1, page_token String @2) -> [Book]")
+	assert.Contains(t, book, "deleteBook(name BookName @1)\n")
+	assert.NotContains(t, book, "MAX_PAGE_SIZE")
+
+	// the package path is relative to the working directory
+	wd, _ := os.Getwd()
+	rel, err := filepath.Rel(wd, dir)
+	assert.NoError(t, err)
+
+	plugins := plugin.NewPlugins("mpm", "syntax", "semantic", "compiler")
+	pkg, err := plugins.ParsePath(context.Empty(), rel)
+	if !assert.NoError(t, err) || !assert.NotNil(t, pkg) {
+		t.FailNow()
+	}
+
+	// the imported package is converted back to the thrift files
+	out, err := generator.New().GeneratePackage(pkg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	thrifts := make(map[string]string)
+	for _, file := range out {
+		thrifts[file.Name] = file.Content
+		t.Log(file.Name, "\n", file.Content)
+	}
+
+	money := thrifts["acme/common/money.thrift"]
+	assert.Contains(t, money, "namespace * acme.common")
+	assert.Contains(t, money, "1: required string currency_code")
+
+	library := thrifts["acme/library/book.thrift"]
+	assert.Contains(t, library, `include "acme/common/money.thrift"`)
+	assert.Contains(t, library, "typedef string BookName")
+	assert.Contains(t, library, "union Source {\n    8: string isbn\n    9: string url\n}")
+	assert.Contains(t, library, "4: money.Money price")
+	assert.Contains(t, library, "5: map<string, string> labels")
+	assert.Contains(t, library, "Book getBook(1: BookName name)")
+	assert.Contains(t, library, "7: set<string> tags")
+	assert.Contains(t, library, "list<Book> list_books(1: i32 page_size, 2: string page_token)")
+	assert.Contains(t, library, "void deleteBook(1: BookName name)")
+}
+
+func TestImporter_Generate_Unsupported(t *testing.T) {
+	for name, content := range map[string]string{
+		"the exception NotFound is not supported":               "exception NotFound {\n    1: string message\n}\n",
+		"the exceptions thrown by the function Library.getBook": "struct NotFound {}\nservice Library {\n    string getBook(1: string name) throws (1: NotFound not_found)\n}\n",
+		"the oneway function Library.ping is not supported":     "service Library {\n    oneway void ping()\n}\n",
+	} {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "library.thrift"), []byte("namespace * acme\n"+content), 0o644))
+
+		_, err := New(dir).Generate(context.Empty())
+		assert.ErrorContains(t, err, name)
+	}
+}
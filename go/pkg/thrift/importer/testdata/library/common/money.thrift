@@ -0,0 +1,9 @@
+namespace * acme.common
+namespace go acme.common
+
+/** Money the amount of the money */
+struct Money {
+    1: required string currency_code,
+    2: i64 units,
+    3: optional i32 nanos
+}
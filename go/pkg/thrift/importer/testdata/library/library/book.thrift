@@ -0,0 +1,46 @@
+namespace * acme.library
+namespace java com.acme.library
+
+include "../common/money.thrift"
+
+const i32 MAX_PAGE_SIZE = 100
+const list<string> FORMATS = ["paper", "e_book"]
+
+/**
+ * Format the format of the book
+ */
+enum Format {
+    UNSPECIFIED = 0,
+    PAPER = 1,
+    E_BOOK, # numbered after the previous one
+}
+
+// not a document
+typedef string BookName
+
+union Source {
+    8: string isbn
+    9: string url
+}
+
+/** Book the book in the library */
+struct Book {
+    1: BookName name
+    2: string title (go.tag = "json:\"title\"")
+    3: Format format = Format.PAPER
+    4: money.Money price
+    5: map<string, string> labels
+    6: list<string> authors
+    7: set<string> tags
+    10: Source source
+}
+
+/** the library service */
+service Library {
+    /** get the book by the name */
+    Book getBook(1: BookName name),
+
+    list<Book> list_books(1: i32 page_size, 2: string page_token);
+
+    void deleteBook(1: BookName name)
+}
@@ -0,0 +1,317 @@
+package semantic
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/mojo-lang/core/go/pkg/mojo/core/strcase"
+	"github.com/mojo-lang/lang/go/pkg/mojo/lang"
+
+	"github.com/mojo-lang/mojo/go/pkg/config"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+// Converter converts the parsed thrift files to the mojo source files, the types referenced are
+// resolved in the file or the included ones, the unions are converted to the type aliases of the
+// union type, the sets to the arrays with the `@unique`, and the field ids to the `@number`, the
+// exceptions and the oneway functions which have no counterpart in mojo are rejected
+type Converter struct {
+	files []*File
+	paths map[string]*File
+
+	// the thrift types mapped to the mojo types by the mojo.yaml
+	types map[string]string
+}
+
+func NewConverter(files ...*File) *Converter {
+	c := &Converter{files: files, paths: make(map[string]*File), types: mappedTypes(config.Get().GetTypeMappings(config.ThriftTypeMappingTarget))}
+	for _, file := range files {
+		c.paths[file.Name] = file
+	}
+	return c
+}
+
+// WithTypeMappings replaces the type mappings of the global config with the ones of the thrift files converting
+func (c *Converter) WithTypeMappings(mappings map[string]*config.TypeMapping) *Converter {
+	c.types = mappedTypes(mappings)
+	return c
+}
+
+// mappedTypes the thrift type references to the full names of the mojo types reversed from the type
+// mappings in the mojo.yaml
+func mappedTypes(mappings map[string]*config.TypeMapping) map[string]string {
+	return config.ReverseTypeMappings(mappings, descriptor.IsBaseType)
+}
+
+// Convert the thrift files to the mojo source files in order
+func (c *Converter) Convert() ([]*lang.SourceFile, error) {
+	var sourceFiles []*lang.SourceFile
+	for _, file := range c.files {
+		sourceFile, err := c.convertFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the thrift file %s: %w", file.Name, err)
+		}
+		sourceFiles = append(sourceFiles, sourceFile)
+	}
+	return sourceFiles, nil
+}
+
+func (c *Converter) convertFile(file *File) (*lang.SourceFile, error) {
+	sourceFile := &lang.SourceFile{
+		Name: strings.TrimSuffix(path.Base(file.Name), ".thrift") + ".mojo",
+	}
+
+	for _, typedef := range file.Descriptor.Typedefs {
+		typ, err := c.convertType(file, typedef.Type)
+		if err != nil {
+			return nil, err
+		}
+		sourceFile.Statements = append(sourceFile.Statements, lang.NewTypeAliasDeclStatement(&lang.TypeAliasDecl{
+			Document: document(typedef.Document),
+			Name:     typedef.Name,
+			Type:     typ,
+		}))
+	}
+	for _, enum := range file.Descriptor.Enums {
+		sourceFile.Statements = append(sourceFile.Statements, lang.NewEnumDeclStatement(convertEnum(enum)))
+	}
+	for _, s := range file.Descriptor.Structs {
+		var statement *lang.Statement
+		if s.Kind == descriptor.ExceptionKind {
+			return nil, fmt.Errorf("the exception %s is not supported, declare it as a struct", s.Name)
+		}
+		if s.Kind == descriptor.UnionKind {
+			decl, err := c.convertUnion(file, s)
+			if err != nil {
+				return nil, err
+			}
+			statement = lang.NewTypeAliasDeclStatement(decl)
+		} else {
+			decl, err := c.convertStruct(file, s)
+			if err != nil {
+				return nil, err
+			}
+			statement = lang.NewStructDeclStatement(decl)
+		}
+		sourceFile.Statements = append(sourceFile.Statements, statement)
+	}
+	for _, service := range file.Descriptor.Services {
+		decl, err := c.convertService(file, service)
+		if err != nil {
+			return nil, err
+		}
+		sourceFile.Statements = append(sourceFile.Statements, lang.NewInterfaceDeclStatement(decl))
+	}
+	return sourceFile, nil
+}
+
+func (c *Converter) convertStruct(file *File, s *descriptor.Struct) (*lang.StructDecl, error) {
+	decl := &lang.StructDecl{
+		Document: document(s.Document),
+		Name:     s.Name,
+		Type:     &lang.StructType{},
+	}
+	for _, field := range s.Fields {
+		f, err := c.convertField(file, s.Name, field)
+		if err != nil {
+			return nil, err
+		}
+		decl.Type.Fields = append(decl.Type.Fields, f)
+	}
+	return decl, nil
+}
+
+func (c *Converter) convertField(file *File, scope string, field *descriptor.Field) (*lang.ValueDecl, error) {
+	typ, err := c.convertType(file, field.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the field %s in %s: %w", field.Name, scope, err)
+	}
+
+	// the fields without the id are numbered negatively by the thrift compiler, left to be numbered in mojo
+	if field.ID > 0 {
+		typ.Attributes = append(typ.Attributes, lang.NewIntegerAttribute("", core.NumberAttributeName, int64(field.ID)))
+	}
+	if field.Requiredness == descriptor.Required {
+		typ.Attributes = append(typ.Attributes, lang.NewBoolAttribute("", core.RequiredAttributeName))
+	}
+	return &lang.ValueDecl{
+		Document: document(field.Document),
+		Name:     strcase.ToSnake(field.Name),
+		Type:     typ,
+	}, nil
+}
+
+// convertUnion converts the union to the type alias of the union type, the members are labeled by
+// the field names if they are not the default ones
+func (c *Converter) convertUnion(file *File, s *descriptor.Struct) (*lang.TypeAliasDecl, error) {
+	var types []*lang.NominalType
+	for _, field := range s.Fields {
+		typ, err := c.convertType(file, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the member %s in %s: %w", field.Name, s.Name, err)
+		}
+		if field.ID > 0 {
+			typ.Attributes = append(typ.Attributes, lang.NewIntegerAttribute("", core.NumberAttributeName, int64(field.ID)))
+		}
+		if name := strcase.ToSnake(field.Name); name != strcase.ToSnake(typ.Name) {
+			typ.Attributes = append(typ.Attributes, lang.NewStringAttribute("", core.LabelAttributeName, name))
+		}
+		types = append(types, typ)
+	}
+
+	return &lang.TypeAliasDecl{
+		Document: document(s.Document),
+		Name:     s.Name,
+		Type:     lang.NewUnionNominalType(types...),
+	}, nil
+}
+
+func convertEnum(enum *descriptor.Enum) *lang.EnumDecl {
+	decl := &lang.EnumDecl{
+		Document: document(enum.Document),
+		Name:     enum.Name,
+		Type:     &lang.EnumType{},
+	}
+	for _, value := range enum.Values {
+		decl.Type.Enumerators = append(decl.Type.Enumerators, &lang.ValueDecl{
+			Document:   document(value.Document),
+			Name:       strcase.ToSnake(value.Name),
+			Attributes: []*lang.Attribute{lang.NewIntegerAttribute("", core.NumberAttributeName, value.Value)},
+		})
+	}
+	return decl
+}
+
+func (c *Converter) convertService(file *File, service *descriptor.Service) (*lang.InterfaceDecl, error) {
+	decl := &lang.InterfaceDecl{
+		Document: document(service.Document),
+		Name:     service.Name,
+		Type:     &lang.InterfaceType{},
+	}
+	if len(service.Extends) > 0 {
+		typ, err := c.resolve(file, service.Extends)
+		if err != nil {
+			return nil, err
+		}
+		decl.Type.Inherits = append(decl.Type.Inherits, typ)
+	}
+
+	for _, function := range service.Functions {
+		method, err := c.convertFunction(file, service.Name, function)
+		if err != nil {
+			return nil, err
+		}
+		decl.Type.Methods = append(decl.Type.Methods, method)
+	}
+	return decl, nil
+}
+
+func (c *Converter) convertFunction(file *File, service string, function *descriptor.Function) (*lang.FunctionDecl, error) {
+	if function.Oneway {
+		return nil, fmt.Errorf("the oneway function %s.%s is not supported", service, function.Name)
+	}
+	if len(function.Throws) > 0 {
+		return nil, fmt.Errorf("the exceptions thrown by the function %s.%s are not supported", service, function.Name)
+	}
+
+	method := &lang.FunctionDecl{
+		Document:  document(function.Document),
+		Name:      function.Name,
+		Signature: &lang.FunctionSignature{Parameter: &lang.FunctionSignature_Parameter{}},
+	}
+	for _, param := range function.Params {
+		p, err := c.convertField(file, service+"."+function.Name, param)
+		if err != nil {
+			return nil, err
+		}
+		// the printer checks the following documents of all the parameters
+		if p.Document == nil {
+			p.Document = &lang.Document{}
+		}
+		method.Signature.AppendParameter(p)
+	}
+
+	if function.Result != nil {
+		typ, err := c.convertType(file, function.Result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the result of %s.%s: %w", service, function.Name, err)
+		}
+		method.Signature.Result = lang.NewFunctionResult(typ)
+	}
+	return method, nil
+}
+
+func (c *Converter) convertType(file *File, typ *descriptor.Type) (*lang.NominalType, error) {
+	switch typ.Name {
+	case descriptor.ListType, descriptor.SetType:
+		element, err := c.convertType(file, typ.Value)
+		if err != nil {
+			return nil, err
+		}
+		array := &lang.NominalType{PackageName: corePackageName, Name: core.ArrayTypeName, GenericArguments: []*lang.NominalType{element}}
+		if typ.Name == descriptor.SetType {
+			array.Attributes = append(array.Attributes, &lang.Attribute{Name: core.UniqueAttributeName})
+		}
+		return array, nil
+	case descriptor.MapType:
+		key, err := c.convertType(file, typ.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := c.convertType(file, typ.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &lang.NominalType{PackageName: corePackageName, Name: core.MapTypeName, GenericArguments: []*lang.NominalType{key, value}}, nil
+	}
+
+	if name, ok := baseTypes[typ.Name]; ok {
+		return &lang.NominalType{PackageName: corePackageName, Name: name}, nil
+	}
+	return c.resolve(file, typ.Name)
+}
+
+// resolve the type declared in the file, or in the included file referenced as `<include>.<Name>`
+func (c *Converter) resolve(file *File, ref string) (*lang.NominalType, error) {
+	if fullName, ok := c.types[ref]; ok {
+		return config.MojoType(file.Package, fullName), nil
+	}
+	if file.symbols[ref] {
+		return &lang.NominalType{Name: ref}, nil
+	}
+
+	if i := strings.LastIndex(ref, "."); i > 0 {
+		includeName, name := ref[:i], ref[i+1:]
+		for _, include := range file.Descriptor.Includes {
+			if descriptor.IncludeName(include) != includeName {
+				continue
+			}
+			if included := c.included(file, include); included != nil && included.symbols[name] {
+				return config.MojoType(file.Package, lang.GetFullName(included.Package, nil, name)), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("failed to resolve the type %s", ref)
+}
+
+func (c *Converter) included(file *File, include string) *File {
+	for _, p := range file.IncludePaths(include) {
+		if included, ok := c.paths[p]; ok {
+			return included
+		}
+	}
+	return nil
+}
+
+func document(lines []string) *lang.Document {
+	if len(lines) == 0 {
+		return nil
+	}
+	doc := &lang.Document{}
+	for _, line := range lines {
+		doc.Lines = append(doc.Lines, &lang.Document_Line{Content: line})
+	}
+	return doc
+}
@@ -0,0 +1,44 @@
+package semantic
+
+import (
+	"path"
+
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+// File the parsed thrift file to convert
+type File struct {
+	Name       string // the path of the thrift file, the includes are relative to its directory
+	Package    string
+	Descriptor *descriptor.File
+
+	// the names of the types declared in the file
+	symbols map[string]bool
+}
+
+// NewFile the package of the file is the `namespace *`, or left to be set by the importer
+func NewFile(name string, file *descriptor.File) *File {
+	f := &File{Name: name, Descriptor: file, symbols: make(map[string]bool)}
+	for _, namespace := range file.Namespaces {
+		if namespace.Scope == "*" {
+			f.Package = namespace.Name
+		}
+	}
+
+	for _, typedef := range file.Typedefs {
+		f.symbols[typedef.Name] = true
+	}
+	for _, enum := range file.Enums {
+		f.symbols[enum.Name] = true
+	}
+	for _, s := range file.Structs {
+		f.symbols[s.Name] = true
+	}
+	return f
+}
+
+// IncludePaths the candidate paths of the included file, relative to the directory of the file,
+// or to the root of the thrift files as the include directory
+func (f *File) IncludePaths(include string) []string {
+	return []string{path.Join(path.Dir(f.Name), include), path.Clean(include)}
+}
@@ -0,0 +1,22 @@
+package semantic
+
+import (
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+const corePackageName = "mojo.core"
+
+// baseTypes the thrift base types to the mojo.core types
+var baseTypes = map[string]string{
+	descriptor.BoolType:   core.BoolTypeName,
+	descriptor.ByteType:   core.Int8TypeName,
+	descriptor.I8Type:     core.Int8TypeName,
+	descriptor.I16Type:    core.Int16TypeName,
+	descriptor.I32Type:    core.Int32TypeName,
+	descriptor.I64Type:    core.Int64TypeName,
+	descriptor.DoubleType: core.Float64TypeName,
+	descriptor.StringType: core.StringTypeName,
+	descriptor.BinaryType: core.BytesTypeName,
+}
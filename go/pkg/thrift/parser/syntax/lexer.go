@@ -0,0 +1,166 @@
+package syntax
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	eofToken tokenKind = iota
+	identToken
+	numberToken
+	stringToken
+	punctToken
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	line   int
+	column int
+
+	// the lines of the `/** */` document comment just before the token
+	document []string
+}
+
+func (t *token) String() string {
+	if t.kind == eofToken {
+		return "EOF"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lexer splits the thrift IDL to the tokens, the comments are dropped except the document ones
+type lexer struct {
+	content string
+	offset  int
+	line    int
+	column  int
+}
+
+func newLexer(content string) *lexer {
+	return &lexer{content: content, line: 1, column: 1}
+}
+
+func (l *lexer) peekByte(n int) byte {
+	if l.offset+n < len(l.content) {
+		return l.content[l.offset+n]
+	}
+	return 0
+}
+
+func (l *lexer) advance(n int) string {
+	start := l.offset
+	for i := 0; i < n && l.offset < len(l.content); i++ {
+		if l.content[l.offset] == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+		l.offset++
+	}
+	return l.content[start:l.offset]
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%d:%d: %s", l.line, l.column, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) tokens() ([]*token, error) {
+	var tokens []*token
+	var document []string
+	for {
+		c := l.peekByte(0)
+		switch {
+		case c == 0:
+			tokens = append(tokens, &token{kind: eofToken, line: l.line, column: l.column, document: document})
+			return tokens, nil
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			l.advance(1)
+			continue
+		case c == '#' || (c == '/' && l.peekByte(1) == '/'):
+			for l.peekByte(0) != 0 && l.peekByte(0) != '\n' {
+				l.advance(1)
+			}
+			continue
+		case c == '/' && l.peekByte(1) == '*':
+			end := strings.Index(l.content[l.offset+2:], "*/")
+			if end < 0 {
+				return nil, l.errorf("unterminated comment")
+			}
+			comment := l.advance(end + 4)
+			if strings.HasPrefix(comment, "/**") && len(comment) > 4 {
+				document = documentLines(comment[3 : len(comment)-2])
+			}
+			continue
+		}
+
+		t := &token{line: l.line, column: l.column, document: document}
+		document = nil
+		switch {
+		case isIdentStart(c):
+			n := 1
+			for isIdentPart(l.peekByte(n)) {
+				n++
+			}
+			t.kind, t.text = identToken, l.advance(n)
+		case isDigit(c) || ((c == '-' || c == '+') && isDigit(l.peekByte(1))):
+			n := 1
+			for isIdentPart(l.peekByte(n)) || ((l.peekByte(n) == '-' || l.peekByte(n) == '+') && (l.peekByte(n-1) == 'e' || l.peekByte(n-1) == 'E')) {
+				n++
+			}
+			t.kind, t.text = numberToken, l.advance(n)
+		case c == '"' || c == '\'':
+			n := 1
+			for l.peekByte(n) != c {
+				if l.peekByte(n) == 0 {
+					return nil, l.errorf("unterminated string literal")
+				}
+				if l.peekByte(n) == '\\' {
+					n++
+				}
+				n++
+			}
+			literal := l.advance(n + 1)
+			t.kind, t.text = stringToken, literal[1:len(literal)-1]
+		case strings.IndexByte("{}()<>[],;:=*", c) >= 0:
+			t.kind, t.text = punctToken, l.advance(1)
+		default:
+			return nil, l.errorf("unexpected character %q", c)
+		}
+		tokens = append(tokens, t)
+	}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// documentLines the lines of the document comment without the leading `*`
+func documentLines(comment string) []string {
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimSpace(strings.TrimPrefix(line, "*"))
+		lines = append(lines, line)
+	}
+
+	for len(lines) > 0 && len(lines[0]) == 0 {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,458 @@
+package syntax
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+// Parser parses the thrift IDL to the descriptor file, the constants, the annotations and the
+// default values are skipped as they are not imported to mojo.
+//
+// Unlike the proto parsers generated by the ANTLR from the grammars in the `antlr` directory, the parser
+// is a hand-written recursive descent one. The thrift is only imported, never formatted or printed back
+// from the parse tree like the proto files, so the subset of the declarations is parsed into the
+// descriptor directly, and the values skipped are balanced by the brackets without a grammar of the
+// constant expressions. The `/** */` documents are also kept by the lexer with the following tokens,
+// instead of being re-attached by the positions as the ANTLR lexers skip the comments.
+type Parser struct {
+	tokens []*token
+	index  int
+}
+
+func New() *Parser {
+	return &Parser{}
+}
+
+func (p *Parser) ParseFile(fileName string) (*descriptor.File, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	file, err := p.ParseString(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fileName, err)
+	}
+	return file, nil
+}
+
+func (p *Parser) ParseString(content string) (*descriptor.File, error) {
+	tokens, err := newLexer(content).tokens()
+	if err != nil {
+		return nil, err
+	}
+	p.tokens, p.index = tokens, 0
+
+	file := &descriptor.File{}
+	for p.peek().kind != eofToken {
+		if err = p.parseDefinition(file); err != nil {
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+func (p *Parser) peek() *token {
+	return p.tokens[p.index]
+}
+
+func (p *Parser) next() *token {
+	t := p.tokens[p.index]
+	if t.kind != eofToken {
+		p.index++
+	}
+	return t
+}
+
+func (p *Parser) is(text string) bool {
+	t := p.peek()
+	return (t.kind == punctToken || t.kind == identToken) && t.text == text
+}
+
+func (p *Parser) accept(text string) bool {
+	if p.is(text) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *Parser) errorf(t *token, format string, args ...interface{}) error {
+	return fmt.Errorf("%d:%d: %s", t.line, t.column, fmt.Sprintf(format, args...))
+}
+
+func (p *Parser) expect(text string) error {
+	if t := p.next(); t.text != text || (t.kind != punctToken && t.kind != identToken) {
+		return p.errorf(t, "expect %q but found %s", text, t)
+	}
+	return nil
+}
+
+func (p *Parser) expectKind(kind tokenKind, what string) (*token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return nil, p.errorf(t, "expect %s but found %s", what, t)
+	}
+	return t, nil
+}
+
+func (p *Parser) identifier() (string, error) {
+	t, err := p.expectKind(identToken, "an identifier")
+	if err != nil {
+		return "", err
+	}
+	return t.text, nil
+}
+
+// separator skips the optional list separator
+func (p *Parser) separator() {
+	if !p.accept(",") {
+		p.accept(";")
+	}
+}
+
+// skipAnnotations skips the annotations like `(go.tag = "json:\"name\"")`
+func (p *Parser) skipAnnotations() error {
+	if p.is("(") {
+		return p.skipBalanced("(", ")")
+	}
+	return nil
+}
+
+func (p *Parser) skipBalanced(open string, close string) error {
+	start := p.peek()
+	depth := 0
+	for {
+		t := p.next()
+		switch {
+		case t.kind == eofToken:
+			return p.errorf(start, "unbalanced %q", open)
+		case t.kind == punctToken && t.text == open:
+			depth++
+		case t.kind == punctToken && t.text == close:
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// skipConstValue skips the value of the constant or the default value of the field
+func (p *Parser) skipConstValue() error {
+	switch {
+	case p.is("["):
+		return p.skipBalanced("[", "]")
+	case p.is("{"):
+		return p.skipBalanced("{", "}")
+	}
+	if t := p.next(); t.kind == eofToken || t.kind == punctToken {
+		return p.errorf(t, "expect a constant value but found %s", t)
+	}
+	return nil
+}
+
+func (p *Parser) parseDefinition(file *descriptor.File) error {
+	t := p.next()
+	if t.kind != identToken {
+		return p.errorf(t, "expect a definition but found %s", t)
+	}
+
+	switch t.text {
+	case "include":
+		include, err := p.expectKind(stringToken, "the include file")
+		if err != nil {
+			return err
+		}
+		file.Includes = append(file.Includes, include.text)
+	case "cpp_include":
+		if _, err := p.expectKind(stringToken, "the include file"); err != nil {
+			return err
+		}
+	case "namespace":
+		scope := p.next()
+		if scope.kind != identToken && !(scope.kind == punctToken && scope.text == "*") {
+			return p.errorf(scope, "expect the namespace scope but found %s", scope)
+		}
+		name, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		file.Namespaces = append(file.Namespaces, &descriptor.Namespace{Scope: scope.text, Name: name})
+		if err = p.skipAnnotations(); err != nil {
+			return err
+		}
+	case "typedef":
+		typ, err := p.parseType()
+		if err != nil {
+			return err
+		}
+		name, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		if err = p.skipAnnotations(); err != nil {
+			return err
+		}
+		file.Typedefs = append(file.Typedefs, &descriptor.Typedef{Document: t.document, Name: name, Type: typ})
+	case "const":
+		if _, err := p.parseType(); err != nil {
+			return err
+		}
+		if _, err := p.identifier(); err != nil {
+			return err
+		}
+		if err := p.expect("="); err != nil {
+			return err
+		}
+		if err := p.skipConstValue(); err != nil {
+			return err
+		}
+	case "enum":
+		enum, err := p.parseEnum()
+		if err != nil {
+			return err
+		}
+		enum.Document = t.document
+		file.Enums = append(file.Enums, enum)
+	case descriptor.StructKind, descriptor.UnionKind, descriptor.ExceptionKind:
+		s, err := p.parseStruct(t.text)
+		if err != nil {
+			return err
+		}
+		s.Document = t.document
+		file.Structs = append(file.Structs, s)
+	case "service":
+		service, err := p.parseService()
+		if err != nil {
+			return err
+		}
+		service.Document = t.document
+		file.Services = append(file.Services, service)
+	default:
+		return p.errorf(t, "unsupported definition %s", t)
+	}
+
+	p.separator()
+	return nil
+}
+
+func (p *Parser) parseEnum() (*descriptor.Enum, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	enum := descriptor.NewEnum(name)
+	next := int64(0)
+	for !p.accept("}") {
+		t, err := p.expectKind(identToken, "an enumerator")
+		if err != nil {
+			return nil, err
+		}
+		if p.accept("=") {
+			number, err := p.expectKind(numberToken, "the enum value")
+			if err != nil {
+				return nil, err
+			}
+			if next, err = strconv.ParseInt(number.text, 0, 64); err != nil {
+				return nil, p.errorf(number, "invalid enum value %s", number)
+			}
+		}
+		if err = p.skipAnnotations(); err != nil {
+			return nil, err
+		}
+		p.separator()
+
+		value := enum.AppendValue(t.text, next)
+		value.Document = t.document
+		next++
+	}
+	return enum, p.skipAnnotations()
+}
+
+func (p *Parser) parseStruct(kind string) (*descriptor.Struct, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	p.accept("xsd_all")
+	if err = p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	s := descriptor.NewStruct(kind, name)
+	if s.Fields, err = p.parseFields("}"); err != nil {
+		return nil, err
+	}
+	return s, p.skipAnnotations()
+}
+
+// parseFields parses the fields until the close token, the fields without the id are numbered
+// from -1 downwards as the thrift compiler does
+func (p *Parser) parseFields(close string) ([]*descriptor.Field, error) {
+	var fields []*descriptor.Field
+	autoID := int32(0)
+	for !p.accept(close) {
+		field := &descriptor.Field{Document: p.peek().document}
+		if p.peek().kind == numberToken {
+			t := p.next()
+			id, err := strconv.ParseInt(t.text, 0, 32)
+			if err != nil {
+				return nil, p.errorf(t, "invalid field id %s", t)
+			}
+			if err = p.expect(":"); err != nil {
+				return nil, err
+			}
+			field.ID = int32(id)
+		} else {
+			autoID--
+			field.ID = autoID
+		}
+
+		if p.accept(descriptor.Required) {
+			field.Requiredness = descriptor.Required
+		} else if p.accept(descriptor.Optional) {
+			field.Requiredness = descriptor.Optional
+		}
+
+		var err error
+		if field.Type, err = p.parseType(); err != nil {
+			return nil, err
+		}
+		if field.Name, err = p.identifier(); err != nil {
+			return nil, err
+		}
+		if p.accept("=") {
+			if err = p.skipConstValue(); err != nil {
+				return nil, err
+			}
+		}
+		if err = p.skipAnnotations(); err != nil {
+			return nil, err
+		}
+		p.separator()
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func (p *Parser) parseType() (*descriptor.Type, error) {
+	t, err := p.expectKind(identToken, "a type")
+	if err != nil {
+		return nil, err
+	}
+
+	var typ *descriptor.Type
+	switch t.text {
+	case descriptor.ListType, descriptor.SetType:
+		if err = p.expect("<"); err != nil {
+			return nil, err
+		}
+		element, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(">"); err != nil {
+			return nil, err
+		}
+		if t.text == descriptor.ListType {
+			typ = descriptor.NewListType(element)
+		} else {
+			typ = descriptor.NewSetType(element)
+		}
+	case descriptor.MapType:
+		if err = p.expect("<"); err != nil {
+			return nil, err
+		}
+		key, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(","); err != nil {
+			return nil, err
+		}
+		value, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(">"); err != nil {
+			return nil, err
+		}
+		typ = descriptor.NewMapType(key, value)
+	default:
+		typ = descriptor.NewType(t.text)
+	}
+
+	if p.accept("cpp_type") {
+		if _, err = p.expectKind(stringToken, "the cpp type"); err != nil {
+			return nil, err
+		}
+	}
+	return typ, p.skipAnnotations()
+}
+
+func (p *Parser) parseService() (*descriptor.Service, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+
+	service := descriptor.NewService(name)
+	if p.accept("extends") {
+		if service.Extends, err = p.identifier(); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for !p.accept("}") {
+		function, err := p.parseFunction()
+		if err != nil {
+			return nil, err
+		}
+		service.Functions = append(service.Functions, function)
+	}
+	return service, p.skipAnnotations()
+}
+
+func (p *Parser) parseFunction() (*descriptor.Function, error) {
+	function := &descriptor.Function{Document: p.peek().document}
+	function.Oneway = p.accept("oneway")
+
+	var err error
+	if !p.accept("void") {
+		if function.Result, err = p.parseType(); err != nil {
+			return nil, err
+		}
+	}
+	if function.Name, err = p.identifier(); err != nil {
+		return nil, err
+	}
+	if err = p.expect("("); err != nil {
+		return nil, err
+	}
+	if function.Params, err = p.parseFields(")"); err != nil {
+		return nil, err
+	}
+	if p.accept("throws") {
+		if err = p.expect("("); err != nil {
+			return nil, err
+		}
+		if function.Throws, err = p.parseFields(")"); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.skipAnnotations(); err != nil {
+		return nil, err
+	}
+	p.separator()
+	return function, nil
+}
@@ -0,0 +1,101 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+const testThrift = `
+namespace * acme.library
+namespace java com.acme.library
+include "common.thrift"
+cpp_include "<map>"
+
+const map<string, i32> LIMITS = {"page": 100}
+
+/** the format */
+enum Format {
+    PAPER = 1;
+    E_BOOK
+}
+
+typedef list<common.Id> Ids (annotation = "value")
+
+/**
+ * the book
+ * in the library
+ */
+struct Book {
+    1: required string name = "unknown",
+    // not a document
+    2: optional map<string, set<i64>> labels (go.tag = 'json:"labels"')
+    3: Format format
+    i32 pages
+} (table = "books")
+
+service Library extends common.Base {
+    /** get the book */
+    Book getBook(1: string name) throws (1: common.NotFound not_found);
+    oneway void ping(),
+}
+`
+
+func TestParser_ParseString(t *testing.T) {
+	file, err := New().ParseString(testThrift)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "acme.library", file.GetNamespace("*"))
+	assert.Equal(t, "com.acme.library", file.GetNamespace("java"))
+	assert.Equal(t, []string{"common.thrift"}, file.Includes)
+
+	if assert.Equal(t, 1, len(file.Enums)) {
+		enum := file.Enums[0]
+		assert.Equal(t, []string{"the format"}, enum.Document)
+		assert.Equal(t, int64(1), enum.Values[0].Value)
+		assert.Equal(t, "E_BOOK", enum.Values[1].Name)
+		assert.Equal(t, int64(2), enum.Values[1].Value)
+	}
+
+	if assert.Equal(t, 1, len(file.Typedefs)) {
+		assert.Equal(t, "Ids", file.Typedefs[0].Name)
+		assert.Equal(t, "list<common.Id>", file.Typedefs[0].Type.String())
+	}
+
+	if assert.Equal(t, 1, len(file.Structs)) {
+		book := file.Structs[0]
+		assert.Equal(t, []string{"the book", "in the library"}, book.Document)
+		assert.Equal(t, descriptor.StructKind, book.Kind)
+		if assert.Equal(t, 4, len(book.Fields)) {
+			assert.Equal(t, descriptor.Required, book.Fields[0].Requiredness)
+			assert.Equal(t, "map<string, set<i64>>", book.Fields[1].Type.String())
+			assert.Equal(t, descriptor.Optional, book.Fields[1].Requiredness)
+			assert.Nil(t, book.Fields[1].Document)
+			assert.Equal(t, int32(-1), book.Fields[3].ID)
+		}
+	}
+
+	if assert.Equal(t, 1, len(file.Services)) {
+		service := file.Services[0]
+		assert.Equal(t, "common.Base", service.Extends)
+		if assert.Equal(t, 2, len(service.Functions)) {
+			assert.Equal(t, []string{"get the book"}, service.Functions[0].Document)
+			assert.Equal(t, "Book", service.Functions[0].Result.String())
+			assert.Equal(t, 1, len(service.Functions[0].Throws))
+			assert.True(t, service.Functions[1].Oneway)
+			assert.Nil(t, service.Functions[1].Result)
+		}
+	}
+}
+
+func TestParser_ParseString_Error(t *testing.T) {
+	_, err := New().ParseString("struct Book {\n    1: string\n}")
+	assert.EqualError(t, err, `3:1: expect an identifier but found "}"`)
+
+	_, err = New().ParseString("struct Book {\n    1: string name\n")
+	assert.Error(t, err)
+}
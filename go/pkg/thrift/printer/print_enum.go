@@ -0,0 +1,25 @@
+package printer
+
+import (
+	"strconv"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+func (p *Printer) PrintEnum(ctx context.Context, enum *descriptor.Enum) *Printer {
+	if enum == nil || p.Error != nil {
+		return p
+	}
+
+	p.printDocument(enum.Document)
+	p.PrintLine("enum ", enum.Name, " {")
+	p.Indent()
+	for _, value := range enum.Values {
+		p.printDocument(value.Document)
+		p.PrintLine(value.Name, " = ", strconv.FormatInt(value.Value, 10))
+	}
+	p.Outdent()
+	p.PrintLine("}")
+	return p
+}
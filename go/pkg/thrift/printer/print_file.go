@@ -0,0 +1,75 @@
+package printer
+
+import (
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+// PrintFile prints the thrift file, the definitions are printed in the order of the enums, typedefs,
+// structs and services, a blank line between each of them
+func (p *Printer) PrintFile(ctx context.Context, file *descriptor.File) *Printer {
+	if file == nil || p.Error != nil {
+		return p
+	}
+
+	p.PrintLine("// Code generated by mojo. DO NOT EDIT.")
+	if len(file.Document) > 0 {
+		p.PrintBlankLine()
+		p.printDocument(file.Document)
+	}
+
+	if len(file.Namespaces) > 0 {
+		p.PrintBlankLine()
+		for _, namespace := range file.Namespaces {
+			p.PrintLine("namespace ", namespace.Scope, " ", namespace.Name)
+		}
+	}
+
+	if len(file.Includes) > 0 {
+		p.PrintBlankLine()
+		for _, include := range file.Includes {
+			p.PrintLine(`include "`, include, `"`)
+		}
+	}
+
+	for _, enum := range file.Enums {
+		p.PrintBlankLine()
+		p.PrintEnum(ctx, enum)
+	}
+	for _, typedef := range file.Typedefs {
+		p.PrintBlankLine()
+		p.printDocument(typedef.Document)
+		p.PrintLine("typedef ", typedef.Type.String(), " ", typedef.Name)
+	}
+	for _, s := range file.Structs {
+		p.PrintBlankLine()
+		p.PrintStruct(ctx, s)
+	}
+	for _, service := range file.Services {
+		p.PrintBlankLine()
+		p.PrintService(ctx, service)
+	}
+
+	p.BreakLine()
+	return p
+}
+
+// printDocument prints the document as the doc comment, which is kept by the thrift generators
+func (p *Printer) printDocument(document []string) *Printer {
+	switch len(document) {
+	case 0:
+	case 1:
+		p.PrintLine("/** ", document[0], " */")
+	default:
+		p.PrintLine("/**")
+		for _, line := range document {
+			if len(line) == 0 {
+				p.PrintLine(" *")
+			} else {
+				p.PrintLine(" * ", line)
+			}
+		}
+		p.PrintLine(" */")
+	}
+	return p
+}
@@ -0,0 +1,47 @@
+package printer
+
+import (
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+func (p *Printer) PrintService(ctx context.Context, service *descriptor.Service) *Printer {
+	if service == nil || p.Error != nil {
+		return p
+	}
+
+	p.printDocument(service.Document)
+	if len(service.Extends) > 0 {
+		p.PrintLine("service ", service.Name, " extends ", service.Extends, " {")
+	} else {
+		p.PrintLine("service ", service.Name, " {")
+	}
+	p.Indent()
+	for i, function := range service.Functions {
+		if i > 0 {
+			p.PrintBlankLine()
+		}
+		p.PrintFunction(ctx, function)
+	}
+	p.Outdent()
+	p.PrintLine("}")
+	return p
+}
+
+// PrintFunction prints the function in one line, like `Book getBook(1: string name) throws (1: NotFound notFound)`
+func (p *Printer) PrintFunction(ctx context.Context, function *descriptor.Function) *Printer {
+	if function == nil || p.Error != nil {
+		return p
+	}
+
+	p.printDocument(function.Document)
+	if function.Oneway {
+		p.PrintLine("oneway ", function.Result.String(), " ", function.Name, "(", fieldsString(function.Params), ")")
+	} else {
+		p.PrintLine(function.Result.String(), " ", function.Name, "(", fieldsString(function.Params), ")")
+	}
+	if len(function.Throws) > 0 {
+		p.PrintRaw(" throws (", fieldsString(function.Throws), ")")
+	}
+	return p
+}
@@ -0,0 +1,50 @@
+package printer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+	"github.com/mojo-lang/mojo/go/pkg/thrift/descriptor"
+)
+
+// PrintStruct prints the struct, union or exception
+func (p *Printer) PrintStruct(ctx context.Context, s *descriptor.Struct) *Printer {
+	if s == nil || p.Error != nil {
+		return p
+	}
+
+	p.printDocument(s.Document)
+	p.PrintLine(s.Kind, " ", s.Name, " {")
+	p.Indent()
+	for _, field := range s.Fields {
+		p.printDocument(field.Document)
+		p.PrintLine(fieldString(field))
+	}
+	p.Outdent()
+	p.PrintLine("}")
+	return p
+}
+
+// fieldString the field in the form of `1: required string name`
+func fieldString(field *descriptor.Field) string {
+	builder := strings.Builder{}
+	builder.WriteString(strconv.Itoa(int(field.ID)))
+	builder.WriteString(": ")
+	if len(field.Requiredness) > 0 {
+		builder.WriteString(field.Requiredness)
+		builder.WriteString(" ")
+	}
+	builder.WriteString(field.Type.String())
+	builder.WriteString(" ")
+	builder.WriteString(field.Name)
+	return builder.String()
+}
+
+func fieldsString(fields []*descriptor.Field) string {
+	var values []string
+	for _, field := range fields {
+		values = append(values, fieldString(field))
+	}
+	return strings.Join(values, ", ")
+}
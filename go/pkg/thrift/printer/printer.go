@@ -0,0 +1,28 @@
+package printer
+
+import (
+	"bytes"
+
+	"github.com/mojo-lang/mojo/go/pkg/printer"
+)
+
+// Printer prints the thrift IDL files
+type Printer struct {
+	*printer.Printer
+	Buffer *bytes.Buffer
+}
+
+// New creates a new printer for print thrift.
+func New(config *printer.Config) *Printer {
+	p := new(Printer)
+	p.Buffer = new(bytes.Buffer)
+	p.Printer = printer.New(config, p.Buffer)
+	return p
+}
+
+func (p *Printer) Reset() {
+	if p != nil {
+		p.Buffer = new(bytes.Buffer)
+		p.Printer.Reset(p.Buffer)
+	}
+}
@@ -0,0 +1,92 @@
+package util
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// HasCircularReference check whether the message refers itself, like the compiled circular types or entity relations,
+// which can't be serialized or cloned.
+func HasCircularReference(message proto.Message) bool {
+	return hasCircularReference(message.ProtoReflect(), make(map[protoreflect.Message]bool))
+}
+
+// hasCircularReference the visiting value is true when the message is in the current path, false when finished
+func hasCircularReference(m protoreflect.Message, visiting map[protoreflect.Message]bool) bool {
+	if inPath, visited := visiting[m]; visited {
+		return inPath
+	}
+	visiting[m] = true
+
+	circular := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len() && !circular; i++ {
+				circular = hasCircularReference(list.Get(i).Message(), visiting)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+				circular = hasCircularReference(value.Message(), visiting)
+				return !circular
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			circular = hasCircularReference(v.Message(), visiting)
+		}
+		return !circular
+	})
+
+	visiting[m] = false
+	return circular
+}
+
+// CopyWithoutCircularReference copies the message with the back references dropped, which refer the messages
+// in the path from the root, like the type declaration of a recursive type, so the copy could be serialized.
+// the message referred by several paths without a circle is copied for each of them, as it's serialized
+func CopyWithoutCircularReference(message proto.Message) proto.Message {
+	return copyWithoutCircularReference(message.ProtoReflect(), make(map[protoreflect.Message]bool)).Interface()
+}
+
+func copyWithoutCircularReference(m protoreflect.Message, inPath map[protoreflect.Message]bool) protoreflect.Message {
+	inPath[m] = true
+	defer delete(inPath, m)
+
+	copied := m.New()
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := copied.Mutable(fd).List()
+			for i := 0; i < v.List().Len(); i++ {
+				value := v.List().Get(i)
+				if fd.Message() != nil {
+					if inPath[value.Message()] {
+						continue
+					}
+					value = protoreflect.ValueOfMessage(copyWithoutCircularReference(value.Message(), inPath))
+				}
+				list.Append(value)
+			}
+		case fd.IsMap():
+			values := copied.Mutable(fd).Map()
+			v.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				if fd.MapValue().Message() != nil {
+					if inPath[value.Message()] {
+						return true
+					}
+					value = protoreflect.ValueOfMessage(copyWithoutCircularReference(value.Message(), inPath))
+				}
+				values.Set(key, value)
+				return true
+			})
+		case fd.Message() != nil:
+			if !inPath[v.Message()] {
+				copied.Set(fd, protoreflect.ValueOfMessage(copyWithoutCircularReference(v.Message(), inPath)))
+			}
+		default:
+			copied.Set(fd, v)
+		}
+		return true
+	})
+	return copied
+}
@@ -0,0 +1,537 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mojo-lang/core/go/pkg/mojo/core"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	FileCreated  = "create"
+	FileModified = "modify"
+	FileDeleted  = "delete"
+)
+
+// FileChange the change of one file which the build would make to the disk
+type FileChange struct {
+	Action string
+	Path   string
+
+	// the content on the disk, empty for the created file
+	Original string
+	// the content would be written, empty for the deleted file
+	Content string
+}
+
+// UnifiedDiff the diff from the content on the disk to the generated one, relative to the dir
+func (c *FileChange) UnifiedDiff(dir string) (string, error) {
+	name := c.Path
+	if rel, err := filepath.Rel(dir, c.Path); err == nil && !strings.HasPrefix(rel, "..") {
+		name = rel
+	}
+
+	from, to := "a/"+name, "b/"+name
+	switch c.Action {
+	case FileCreated:
+		from = "/dev/null"
+	case FileDeleted:
+		to = "/dev/null"
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(c.Original),
+		B:        splitLines(c.Content),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+}
+
+func splitLines(content string) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if last := lines[len(lines)-1]; len(last) == 0 {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] = last + "\n"
+	}
+	return lines
+}
+
+type overlayFile struct {
+	// the file exists on the disk before the build
+	existed  bool
+	original string
+
+	deleted bool
+	content string
+}
+
+// DryRun keeps the files written or deleted in the build in memory instead of the disk,
+// the later reads of the build see the overlay, so the changes are the same as a real build.
+type DryRun struct {
+	mutex sync.Mutex
+	files map[string]*overlayFile
+
+	// the temporary directories of RunInOverlay, which are read and written on the disk directly
+	passthrough map[string]bool
+}
+
+func NewDryRun() *DryRun {
+	return &DryRun{files: make(map[string]*overlayFile), passthrough: make(map[string]bool)}
+}
+
+var dryRun *DryRun
+var dryRunMutex sync.RWMutex
+
+// StartDryRun enable the process wide dry run, the generated files will not touch the disk until StopDryRun
+func StartDryRun() *DryRun {
+	dryRunMutex.Lock()
+	defer dryRunMutex.Unlock()
+	dryRun = NewDryRun()
+	return dryRun
+}
+
+// StopDryRun disable the process wide dry run and return it
+func StopDryRun() *DryRun {
+	dryRunMutex.Lock()
+	defer dryRunMutex.Unlock()
+	d := dryRun
+	dryRun = nil
+	return d
+}
+
+// IsDryRun returns true if the generated files are only recorded, the builders running the
+// external processes which write the disk directly should run them by RunInOverlay.
+func IsDryRun() bool {
+	return getDryRun() != nil
+}
+
+func getDryRun() *DryRun {
+	dryRunMutex.RLock()
+	defer dryRunMutex.RUnlock()
+	return dryRun
+}
+
+// getFileDryRun the dry run which the file is read or written in, nil if the file is on the disk
+func getFileDryRun(name string) *DryRun {
+	d := getDryRun()
+	if d != nil && d.isPassthrough(name) {
+		return nil
+	}
+	return d
+}
+
+// RunInOverlay run the fn which writes the files in the root directly, like the external processes.
+//
+// in the dry run, the fn runs in a temporary copy of the root with the overlay applied,
+// then the files it creates, modifies or deletes are written back to the overlay.
+// the hidden directories like `.git` are not copied.
+func RunInOverlay(root string, fn func(root string) error) error {
+	d := getDryRun()
+	if d == nil {
+		return fn(root)
+	}
+
+	tmp, err := os.MkdirTemp("", "mojo-dry-run-")
+	if err != nil {
+		return err
+	}
+	d.setPassthrough(tmp, true)
+	defer func() {
+		d.setPassthrough(tmp, false)
+		os.RemoveAll(tmp)
+	}()
+
+	before, err := d.listOverlay(root)
+	if err != nil {
+		return err
+	}
+	for rel, content := range before {
+		name := filepath.Join(tmp, rel)
+		if err = core.CreateDir(filepath.Dir(name)); err != nil {
+			return err
+		}
+		if err = os.WriteFile(name, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+
+	if err = fn(tmp); err != nil {
+		return err
+	}
+
+	after, err := readVisibleFiles(tmp)
+	if err != nil {
+		return err
+	}
+	for rel, content := range after {
+		if original, ok := before[rel]; !ok || original != content {
+			if err = d.write(filepath.Join(root, rel), []byte(content)); err != nil {
+				return err
+			}
+		}
+	}
+	for rel := range before {
+		if _, ok := after[rel]; !ok {
+			if err = d.remove(filepath.Join(root, rel)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *DryRun) setPassthrough(dir string, passthrough bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if passthrough {
+		d.passthrough[overlayKey(dir)] = true
+	} else {
+		delete(d.passthrough, overlayKey(dir))
+	}
+}
+
+func (d *DryRun) isPassthrough(name string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	name = overlayKey(name)
+	for dir := range d.passthrough {
+		if name == dir || strings.HasPrefix(name, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// listOverlay the contents of the files in the dir with the overlay applied, by the relative paths
+func (d *DryRun) listOverlay(dir string) (map[string]string, error) {
+	files, err := readVisibleFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	dir = overlayKey(dir)
+	for name, file := range d.files {
+		rel, err := filepath.Rel(dir, name)
+		if err != nil || strings.HasPrefix(rel, "..") || isHiddenPath(rel) {
+			continue
+		}
+		if file.deleted {
+			delete(files, rel)
+		} else {
+			files[rel] = file.content
+		}
+	}
+	return files, nil
+}
+
+// readVisibleFiles the contents of the files on the disk in the dir by the relative paths, except the hidden ones
+func readVisibleFiles(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if p != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[rel] = string(content)
+		return nil
+	})
+	return files, err
+}
+
+func isHiddenPath(rel string) bool {
+	for _, segment := range strings.Split(filepath.ToSlash(rel), "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Changes return the files which would be created, modified or deleted, sorted by the path
+func (d *DryRun) Changes() []*FileChange {
+	if d == nil {
+		return nil
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var changes []*FileChange
+	for name, file := range d.files {
+		change := &FileChange{Path: name, Original: file.original}
+		switch {
+		case file.deleted && file.existed:
+			change.Action = FileDeleted
+		case file.deleted:
+			continue
+		case !file.existed:
+			change.Action = FileCreated
+			change.Content = file.content
+		case file.original != file.content:
+			change.Action = FileModified
+			change.Content = file.content
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func (d *DryRun) file(name string) (*overlayFile, error) {
+	if file, ok := d.files[name]; ok {
+		return file, nil
+	}
+
+	file := &overlayFile{}
+	if core.IsExist(name) {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		file.existed = true
+		file.original = string(content)
+		file.content = file.original
+	} else {
+		file.deleted = true
+	}
+	d.files[name] = file
+	return file, nil
+}
+
+func (d *DryRun) exist(name string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	name = overlayKey(name)
+	if file, ok := d.files[name]; ok {
+		return !file.deleted
+	}
+	if core.IsExist(name) {
+		return true
+	}
+
+	// the directory only contains the files in the overlay
+	prefix := name + string(filepath.Separator)
+	for n, file := range d.files {
+		if !file.deleted && strings.HasPrefix(n, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DryRun) read(name string) ([]byte, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	file, err := d.file(overlayKey(name))
+	if err != nil {
+		return nil, err
+	}
+	if file.deleted {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return []byte(file.content), nil
+}
+
+func (d *DryRun) write(name string, content []byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	file, err := d.file(overlayKey(name))
+	if err != nil {
+		return err
+	}
+	file.deleted = false
+	file.content = string(content)
+	return nil
+}
+
+func (d *DryRun) remove(name string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	file, err := d.file(overlayKey(name))
+	if err != nil {
+		return err
+	}
+	if file.deleted {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	file.deleted = true
+	file.content = ""
+	return nil
+}
+
+// list the files (not recursive) or all the files in the dir (recursive), include the ones in the overlay
+func (d *DryRun) list(dir string, recursive bool) ([]string, error) {
+	files, err := listDiskFiles(dir, recursive)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	dir = overlayKey(dir)
+	listed := make(map[string]bool)
+	var result []string
+	for _, name := range files {
+		listed[overlayKey(name)] = true
+	}
+	for name, file := range d.files {
+		if strings.HasPrefix(name, dir+string(filepath.Separator)) && (recursive || filepath.Dir(name) == dir) {
+			listed[name] = !file.deleted
+		}
+	}
+	for name, ok := range listed {
+		if ok {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// overlayKey the absolute path of the file, the builders may use both the relative and absolute paths
+func overlayKey(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+	return filepath.Clean(name)
+}
+
+func listDiskFiles(dir string, recursive bool) ([]string, error) {
+	var files []string
+	if recursive {
+		err := filepath.Walk(dir, func(path string, f os.FileInfo, err error) error {
+			if f != nil && !f.IsDir() {
+				files = append(files, path)
+			}
+			return nil
+		})
+		return files, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// PrintChanges print the changes relative to the dir, with the unified diffs if diff is true
+func PrintChanges(w io.Writer, dir string, changes []*FileChange, diff bool) error {
+	for _, change := range changes {
+		name := change.Path
+		if rel, err := filepath.Rel(dir, change.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			name = rel
+		}
+		if _, err := fmt.Fprintf(w, "%-6s %s\n", change.Action, name); err != nil {
+			return err
+		}
+	}
+
+	if diff {
+		for _, change := range changes {
+			text, err := change.UnifiedDiff(dir)
+			if err != nil {
+				return err
+			}
+			if _, err = io.WriteString(w, text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func fileExist(name string) bool {
+	if d := getFileDryRun(name); d != nil {
+		return d.exist(name)
+	}
+	return core.IsExist(name)
+}
+
+// ReadFile read the file which may be generated in the dry run
+func ReadFile(name string) ([]byte, error) {
+	return readFile(name)
+}
+
+func readFile(name string) ([]byte, error) {
+	if d := getFileDryRun(name); d != nil {
+		return d.read(name)
+	}
+	return os.ReadFile(name)
+}
+
+func writeFile(name string, content []byte, perm os.FileMode) error {
+	if d := getFileDryRun(name); d != nil {
+		return d.write(name, content)
+	}
+	return os.WriteFile(name, content, perm)
+}
+
+func removeFile(name string) error {
+	if d := getFileDryRun(name); d != nil {
+		return d.remove(name)
+	}
+	return os.Remove(name)
+}
+
+func createDir(dir string) error {
+	if d := getFileDryRun(dir); d != nil {
+		return nil
+	}
+	return core.CreateDir(dir)
+}
+
+func listFiles(dir string, recursive bool) ([]string, error) {
+	if d := getFileDryRun(dir); d != nil {
+		return d.list(dir, recursive)
+	}
+	return listDiskFiles(dir, recursive)
+}
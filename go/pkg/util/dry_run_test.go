@@ -0,0 +1,109 @@
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const generatedHeader = "// Code generated by mojo. DO NOT EDIT.\n"
+
+func TestDryRun_Changes(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "go")
+	assert.NoError(t, os.MkdirAll(out, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(out, "same.go"), []byte(generatedHeader+"package a\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(out, "changed.go"), []byte(generatedHeader+"package a\n\nvar A = 1\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(out, "stale.go"), []byte(generatedHeader+"package a\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(out, "user.go"), []byte("package a\n"), 0o644))
+
+	StartDryRun()
+	guard := &PathGuard{OnlyClearGenerated: true, Suffixes: []string{".go"}}
+	files := GeneratedFiles{
+		{Name: "same.go", Content: generatedHeader + "package a\n"},
+		{Name: "changed.go", Content: generatedHeader + "package a\n\nvar A = 2\n"},
+		{Name: "pkg/new.go", Content: generatedHeader + "package pkg\n"},
+	}
+	for _, file := range files {
+		assert.NoError(t, file.WriteTo(out, guard))
+	}
+	assert.True(t, fileExist(filepath.Join(out, "pkg")))
+	changes := StopDryRun().Changes()
+
+	assert.Equal(t, 3, len(changes))
+	assert.Equal(t, FileModified, changes[0].Action)
+	assert.Equal(t, filepath.Join(out, "changed.go"), changes[0].Path)
+	assert.Equal(t, FileCreated, changes[1].Action)
+	assert.Equal(t, filepath.Join(out, "pkg", "new.go"), changes[1].Path)
+	assert.Equal(t, FileDeleted, changes[2].Action)
+	assert.Equal(t, filepath.Join(out, "stale.go"), changes[2].Path)
+
+	// nothing is touched on the disk
+	assert.False(t, fileExist(filepath.Join(out, "pkg")))
+	assert.True(t, fileExist(filepath.Join(out, "stale.go")))
+	content, err := os.ReadFile(filepath.Join(out, "changed.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "var A = 1")
+
+	buffer := bytes.NewBuffer(nil)
+	assert.NoError(t, PrintChanges(buffer, dir, changes, true))
+	assert.Contains(t, buffer.String(), "modify go/changed.go\n")
+	assert.Contains(t, buffer.String(), "create go/pkg/new.go\n")
+	assert.Contains(t, buffer.String(), "delete go/stale.go\n")
+	assert.Contains(t, buffer.String(), "--- a/go/changed.go\n+++ b/go/changed.go\n")
+	assert.Contains(t, buffer.String(), "-var A = 1\n+var A = 2\n")
+	assert.Contains(t, buffer.String(), "--- /dev/null\n+++ b/go/pkg/new.go\n")
+}
+
+func TestDryRun_SkipIfUserCodeMixed(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "user.go"), []byte("package a\n"), 0o644))
+
+	StartDryRun()
+	file := &GeneratedFile{Name: "user.go", Content: generatedHeader + "package a\n", SkipIfUserCodeMixed: true}
+	assert.NoError(t, file.WriteTo(dir, &PathGuard{OnlyClearGenerated: true}))
+	assert.Empty(t, StopDryRun().Changes())
+}
+
+func TestRunInOverlay(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "go"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go", "go.mod"), []byte("module a\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go", "stale.go"), []byte("package a\n"), 0o644))
+
+	StartDryRun()
+	// the file generated in the dry run is visible to the process
+	assert.NoError(t, writeFile(filepath.Join(dir, "go", "a.go"), []byte("package a\n"), 0o644))
+	err := RunInOverlay(dir, func(root string) error {
+		assert.NotEqual(t, dir, root)
+		content, err := os.ReadFile(filepath.Join(root, "go", "a.go"))
+		assert.NoError(t, err)
+		assert.Equal(t, "package a\n", string(content))
+
+		// the util functions write the disk directly in the root
+		assert.NoError(t, writeFile(filepath.Join(root, "go", "go.sum"), []byte("sum\n"), 0o644))
+		assert.NoError(t, removeFile(filepath.Join(root, "go", "stale.go")))
+		return os.WriteFile(filepath.Join(root, "go", "go.mod"), []byte("module a\n\ngo 1.20\n"), 0o644)
+	})
+	assert.NoError(t, err)
+	changes := StopDryRun().Changes()
+
+	assert.Equal(t, 4, len(changes))
+	assert.Equal(t, FileCreated, changes[0].Action)
+	assert.Equal(t, filepath.Join(dir, "go", "a.go"), changes[0].Path)
+	assert.Equal(t, FileModified, changes[1].Action)
+	assert.Equal(t, filepath.Join(dir, "go", "go.mod"), changes[1].Path)
+	assert.Equal(t, FileCreated, changes[2].Action)
+	assert.Equal(t, filepath.Join(dir, "go", "go.sum"), changes[2].Path)
+	assert.Equal(t, FileDeleted, changes[3].Action)
+	assert.Equal(t, filepath.Join(dir, "go", "stale.go"), changes[3].Path)
+
+	// nothing is touched on the disk
+	content, err := os.ReadFile(filepath.Join(dir, "go", "go.mod"))
+	assert.NoError(t, err)
+	assert.Equal(t, "module a\n", string(content))
+	assert.True(t, fileExist(filepath.Join(dir, "go", "stale.go")))
+}
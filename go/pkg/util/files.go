@@ -3,14 +3,10 @@ package util
 import (
 	"bufio"
 	"bytes"
-	"io/fs"
-	"io/ioutil"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/mojo-lang/core/go/pkg/logs"
-	"github.com/mojo-lang/core/go/pkg/mojo/core"
 )
 
 func ClearGeneratedFiles(path string, suffixes ...string) error {
@@ -58,8 +54,8 @@ func hasSuffix(file string, suffixes ...string) bool {
 }
 
 func IsAllGeneratedFile(path string) bool {
-	if core.IsExist(path) {
-		content, err := os.ReadFile(path)
+	if fileExist(path) {
+		content, err := readFile(path)
 		if err != nil {
 			return false
 		}
@@ -76,36 +72,17 @@ func IsAllGeneratedFile(path string) bool {
 }
 
 func clearFiles(path string, recursive bool, filter func(file string) bool) error {
-	var files []string
-	var err error
-	if recursive {
-		err = filepath.Walk(path, func(path string, f os.FileInfo, err error) error {
-			if f != nil && !f.IsDir() && filter(path) {
-				files = append(files, path)
-			}
-			return nil
-		})
-	} else {
-		var fileInfos []fs.FileInfo
-		fileInfos, err = ioutil.ReadDir(path)
-		if err != nil {
-			return err
-		}
-		for _, f := range fileInfos {
-			name := filepath.Join(path, f.Name())
-			if !f.IsDir() && filter(name) {
-				files = append(files, name)
-			}
-		}
-	}
-
+	files, err := listFiles(path, recursive)
 	if err != nil {
-		logs.Errorw("filepath.Walk() failed", "error", err.Error())
+		logs.Errorw("failed to list the files", "path", path, "error", err.Error())
 		return err
 	}
 
 	for _, file := range files {
-		if err = os.Remove(file); err != nil {
+		if !filter(file) {
+			continue
+		}
+		if err = removeFile(file); err != nil {
 			return err
 		}
 	}
@@ -2,12 +2,13 @@ package util
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path"
+	"path/filepath"
 
 	"github.com/mojo-lang/core/go/pkg/logs"
-	"github.com/mojo-lang/core/go/pkg/mojo/core"
 )
 
 type GeneratedFile struct {
@@ -35,10 +36,21 @@ type GeneratedFile struct {
 
 type GeneratedFiles []*GeneratedFile
 
-func (c *GeneratedFile) WriteTo(output string, guard *PathGuard) error {
+// CheckName checks the name is relative and lies in the output directory
+func (c *GeneratedFile) CheckName() error {
 	if len(c.Name) == 0 {
 		return errors.New("not valid file: has no file name")
 	}
+	if !filepath.IsLocal(filepath.FromSlash(c.Name)) {
+		return fmt.Errorf("not valid file name %s: should be relative and in the output directory", c.Name)
+	}
+	return nil
+}
+
+func (c *GeneratedFile) WriteTo(output string, guard *PathGuard) error {
+	if err := c.CheckName(); err != nil {
+		return err
+	}
 	if len(c.Content) == 0 && c.Reader == nil {
 		logs.Warnw("skip an empty file", "name", c.Name)
 		return nil
@@ -48,12 +60,15 @@ func (c *GeneratedFile) WriteTo(output string, guard *PathGuard) error {
 	dir := path.Dir(name)
 
 	if guard != nil {
+		if err := guard.record(output, c.Name); err != nil {
+			return err
+		}
 		if err := guard.Check(dir); err != nil {
 			return err
 		}
 	}
 
-	if core.IsExist(name) {
+	if fileExist(name) {
 		if c.SkipIfExist {
 			return nil
 		}
@@ -76,5 +91,5 @@ func (c *GeneratedFile) WriteTo(output string, guard *PathGuard) error {
 		perm = os.ModePerm
 	}
 
-	return os.WriteFile(name, []byte(c.Content), perm)
+	return writeFile(name, []byte(c.Content), perm)
 }
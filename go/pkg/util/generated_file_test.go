@@ -0,0 +1,25 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratedFile_WriteToOutside(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "out")
+
+	for _, name := range []string{"../victim.txt", "a/../../victim.txt", filepath.Join(dir, "victim.txt")} {
+		assert.Error(t, (&GeneratedFile{Name: name, Content: "x"}).WriteTo(output, nil), name)
+	}
+	_, err := os.Stat(filepath.Join(dir, "victim.txt"))
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, (&GeneratedFile{Name: "a/../b.txt", Content: "b"}).WriteTo(dir, nil))
+	content, err := os.ReadFile(filepath.Join(dir, "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "b", string(content))
+}
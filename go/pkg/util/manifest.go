@@ -0,0 +1,249 @@
+package util
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/mojo-lang/core/go/pkg/logs"
+)
+
+// ManifestFileName the manifest recording the generated files of the targets in the output directory
+const ManifestFileName = ".mojo-generated.json"
+
+// ManifestTarget the files generated by one target, relative to the output directory
+type ManifestTarget struct {
+	// only remove the files which are all generated, the others have been mixed with the user code
+	OnlyGenerated bool     `json:"onlyGenerated,omitempty"`
+	Files         []string `json:"files"`
+}
+
+type Manifest struct {
+	Targets map[string]*ManifestTarget `json:"targets"`
+}
+
+// LoadManifest load the manifest in the output directory, return nil if not exist
+func LoadManifest(dir string) (*Manifest, error) {
+	name := path.Join(dir, ManifestFileName)
+	if !fileExist(name) {
+		return nil, nil
+	}
+
+	content, err := readFile(name)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	if err = json.Unmarshal(content, manifest); err != nil {
+		logs.Errorw("failed to parse the manifest", "file", name, "error", err.Error())
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Save write the manifest to the output directory, remove the manifest file if no target left
+func (m *Manifest) Save(dir string) error {
+	name := path.Join(dir, ManifestFileName)
+	if len(m.Targets) == 0 {
+		if fileExist(name) {
+			return removeFile(name)
+		}
+		return nil
+	}
+
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = createDir(dir); err != nil {
+		return err
+	}
+	return writeFile(name, append(content, '\n'), 0o666)
+}
+
+// Clean remove the generated files of the targets, or all the targets if not specified
+func (m *Manifest) Clean(dir string, targets ...string) error {
+	if len(targets) == 0 {
+		for target := range m.Targets {
+			targets = append(targets, target)
+		}
+	}
+
+	for _, target := range targets {
+		if t := m.Targets[target]; t != nil {
+			if err := removeGeneratedFiles(dir, t.OnlyGenerated, t.Files); err != nil {
+				return err
+			}
+			delete(m.Targets, target)
+		}
+	}
+	return nil
+}
+
+// remove the files and the directories which become empty, but not the output directory,
+// the files out of the output directory in the (hand-edited) manifest are never removed
+func removeGeneratedFiles(dir string, onlyGenerated bool, files []string) error {
+	for _, file := range files {
+		if !filepath.IsLocal(filepath.FromSlash(file)) {
+			logs.Warnw("skip the file out of the output directory in the manifest", "dir", dir, "file", file)
+			continue
+		}
+		name := path.Join(dir, file)
+		if !fileExist(name) {
+			continue
+		}
+		if onlyGenerated && !IsAllGeneratedFile(name) {
+			logs.Infow("keep the generated file mixed with the user code", "file", name)
+			continue
+		}
+		if err := removeFile(name); err != nil {
+			return err
+		}
+		removeEmptyDirs(dir, path.Dir(name))
+	}
+	return nil
+}
+
+func removeEmptyDirs(root string, dir string) {
+	if IsDryRun() {
+		return
+	}
+
+	root = filepath.Clean(root)
+	for dir = filepath.Clean(dir); dir != root && len(dir) > len(root); dir = filepath.Dir(dir) {
+		if entries, err := os.ReadDir(dir); err != nil || len(entries) > 0 {
+			return
+		}
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
+
+type manifestOutput struct {
+	previous *Manifest
+	targets  map[string]*ManifestTarget
+}
+
+// Manifests records the files generated by the targets in each output directory in the build
+type Manifests struct {
+	mutex   sync.Mutex
+	outputs map[string]*manifestOutput
+}
+
+func NewManifests() *Manifests {
+	return &Manifests{outputs: make(map[string]*manifestOutput)}
+}
+
+var manifests *Manifests
+var manifestsMutex sync.RWMutex
+
+// StartManifests enable the process wide manifests, the files written by the PathGuard with the target will be recorded
+func StartManifests() *Manifests {
+	manifestsMutex.Lock()
+	defer manifestsMutex.Unlock()
+	manifests = NewManifests()
+	return manifests
+}
+
+// StopManifests disable the process wide manifests and return it
+func StopManifests() *Manifests {
+	manifestsMutex.Lock()
+	defer manifestsMutex.Unlock()
+	m := manifests
+	manifests = nil
+	return m
+}
+
+func getManifests() *Manifests {
+	manifestsMutex.RLock()
+	defer manifestsMutex.RUnlock()
+	return manifests
+}
+
+// record the files generated by the target, returns true if the output has the previous manifest,
+// so the stale files will be pruned instead of clearing the directories, which may remove the files
+// of the other targets or the user.
+//
+// the target is recorded even without any file, so all its files generated by the previous build will be pruned
+func (m *Manifests) record(output string, target string, onlyGenerated bool, names ...string) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	output = overlayKey(output)
+	out := m.outputs[output]
+	if out == nil {
+		previous, err := LoadManifest(output)
+		if err != nil {
+			return false, err
+		}
+		out = &manifestOutput{previous: previous, targets: make(map[string]*ManifestTarget)}
+		m.outputs[output] = out
+	}
+
+	t := out.targets[target]
+	if t == nil {
+		t = &ManifestTarget{OnlyGenerated: onlyGenerated}
+		out.targets[target] = t
+	}
+	for _, name := range names {
+		t.Files = append(t.Files, path.Clean(name))
+	}
+	return out.previous != nil, nil
+}
+
+// Prune remove the files generated by the previous build but not this one, and save the manifests
+func (m *Manifests) Prune() error {
+	if m == nil {
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for output, out := range m.outputs {
+		generated := make(map[string]bool)
+		for _, t := range out.targets {
+			t.Files = UniqueStringSlice(t.Files)
+			sort.Strings(t.Files)
+			for _, file := range t.Files {
+				generated[file] = true
+			}
+		}
+
+		manifest := out.previous
+		if manifest == nil {
+			manifest = &Manifest{}
+		}
+		if manifest.Targets == nil {
+			manifest.Targets = make(map[string]*ManifestTarget)
+		}
+
+		for target, t := range out.targets {
+			if previous := manifest.Targets[target]; previous != nil {
+				var stale []string
+				for _, file := range previous.Files {
+					if !generated[file] {
+						stale = append(stale, file)
+					}
+				}
+				if err := removeGeneratedFiles(output, previous.OnlyGenerated, stale); err != nil {
+					return err
+				}
+			}
+			if len(t.Files) > 0 {
+				manifest.Targets[target] = t
+			} else {
+				delete(manifest.Targets, target)
+			}
+		}
+
+		if err := manifest.Save(output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
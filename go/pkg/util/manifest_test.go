@@ -0,0 +1,130 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateWithManifests(t *testing.T, output string, target string, files ...*GeneratedFile) {
+	manifests := StartManifests()
+	defer StopManifests()
+
+	guard := &PathGuard{OnlyClearGenerated: true, Target: target}
+	assert.NoError(t, guard.Record(output))
+	for _, file := range files {
+		assert.NoError(t, file.WriteTo(output, guard))
+	}
+	assert.NoError(t, manifests.Prune())
+}
+
+func TestManifests_Prune(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "user.go"), []byte("package a\n"), 0o644))
+
+	generateWithManifests(t, dir, "go",
+		&GeneratedFile{Name: "a.go", Content: generatedHeader + "package a\n"},
+		&GeneratedFile{Name: "b/b.go", Content: generatedHeader + "package b\n"})
+
+	manifest, err := LoadManifest(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "b/b.go"}, manifest.Targets["go"].Files)
+	assert.True(t, manifest.Targets["go"].OnlyGenerated)
+
+	// the b.go is removed from the package, and a hand-edited stale file is in the directory
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "stale.go"), []byte(generatedHeader+"package a\n"), 0o644))
+	generateWithManifests(t, dir, "go",
+		&GeneratedFile{Name: "a.go", Content: generatedHeader + "package a\n"})
+
+	assert.True(t, fileExist(filepath.Join(dir, "a.go")))
+	assert.False(t, fileExist(filepath.Join(dir, "b")))
+	assert.True(t, fileExist(filepath.Join(dir, "user.go")))
+	// not in the previous manifest, so not cleared when the target has the manifest
+	assert.True(t, fileExist(filepath.Join(dir, "stale.go")))
+
+	manifest, err = LoadManifest(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.go"}, manifest.Targets["go"].Files)
+}
+
+func TestManifests_PruneKeepOtherTargets(t *testing.T) {
+	dir := t.TempDir()
+	generateWithManifests(t, dir, "protobuf", &GeneratedFile{Name: "a.proto", Content: generatedHeader})
+	generateWithManifests(t, dir, "openapi", &GeneratedFile{Name: "a.yaml", Content: generatedHeader})
+
+	manifest, err := LoadManifest(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(manifest.Targets))
+	assert.True(t, fileExist(filepath.Join(dir, "a.proto")))
+	assert.True(t, fileExist(filepath.Join(dir, "a.yaml")))
+
+	assert.NoError(t, manifest.Clean(dir, "openapi"))
+	assert.NoError(t, manifest.Save(dir))
+	assert.True(t, fileExist(filepath.Join(dir, "a.proto")))
+	assert.False(t, fileExist(filepath.Join(dir, "a.yaml")))
+
+	assert.NoError(t, manifest.Clean(dir))
+	assert.NoError(t, manifest.Save(dir))
+	assert.False(t, fileExist(filepath.Join(dir, "a.proto")))
+	assert.False(t, fileExist(filepath.Join(dir, ManifestFileName)))
+}
+
+func TestManifest_CleanOutside(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "output")
+	victim := filepath.Join(root, "victim.txt")
+	assert.NoError(t, os.WriteFile(victim, []byte(generatedHeader), 0o644))
+
+	generateWithManifests(t, dir, "go", &GeneratedFile{Name: "a.go", Content: generatedHeader + "package a\n"})
+	manifest, err := LoadManifest(dir)
+	assert.NoError(t, err)
+	manifest.Targets["go"].Files = append(manifest.Targets["go"].Files, "../victim.txt", "a/../../victim.txt", victim)
+	assert.NoError(t, manifest.Save(dir))
+
+	// pruned by the target generates nothing now
+	generateWithManifests(t, dir, "go")
+	assert.False(t, fileExist(filepath.Join(dir, "a.go")))
+	assert.True(t, fileExist(victim))
+
+	manifest.Targets["openapi"] = &ManifestTarget{Files: []string{"../victim.txt", victim}}
+	assert.NoError(t, manifest.Clean(dir))
+	assert.True(t, fileExist(victim))
+}
+
+func TestManifests_PruneNoFile(t *testing.T) {
+	dir := t.TempDir()
+	generateWithManifests(t, dir, "protobuf", &GeneratedFile{Name: "a.proto", Content: generatedHeader})
+	generateWithManifests(t, dir, "openapi", &GeneratedFile{Name: "a.yaml", Content: generatedHeader})
+
+	// the target generates nothing now
+	generateWithManifests(t, dir, "protobuf")
+	assert.False(t, fileExist(filepath.Join(dir, "a.proto")))
+	assert.True(t, fileExist(filepath.Join(dir, "a.yaml")))
+
+	manifest, err := LoadManifest(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, manifest.Targets["protobuf"])
+	assert.NotNil(t, manifest.Targets["openapi"])
+}
+
+func TestPathGuard_Record(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "com"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "com", "AProto.java"), []byte(generatedHeader), 0o644))
+
+	// the files written by the external process
+	manifests := StartManifests()
+	guard := &PathGuard{DisableClear: true, Target: "java"}
+	assert.NoError(t, guard.Record(dir, "com/AProto.java"))
+	assert.NoError(t, manifests.Prune())
+	StopManifests()
+
+	manifest, err := LoadManifest(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"com/AProto.java"}, manifest.Targets["java"].Files)
+
+	generateWithManifests(t, dir, "java")
+	assert.False(t, fileExist(filepath.Join(dir, "com", "AProto.java")))
+}
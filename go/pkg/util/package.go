@@ -15,3 +15,14 @@ func IsPackageProcessed(pkg *lang.Package, plugin string) bool {
 	}
 	return false
 }
+
+const cachedKey = "@cached"
+
+// SetPackageCached mark the package is loaded from the build cache, which has been fully compiled
+func SetPackageCached(pkg *lang.Package) *lang.Package {
+	return SetPackageProcessed(pkg, cachedKey)
+}
+
+func IsPackageCached(pkg *lang.Package) bool {
+	return IsPackageProcessed(pkg, cachedKey)
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DefaultConcurrency the default size of the worker pool, which is the number of the usable CPUs
+func DefaultConcurrency() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// Parallel call the fn with the index from 0 to n-1 in a worker pool with at most `concurrency` workers,
+// concurrency less than 1 means DefaultConcurrency, 1 means calling one after another in the current goroutine.
+//
+// all the calls will be finished when returned, and the error of the smallest index is returned,
+// so the result is the same as calling them sequentially.
+func Parallel(n int, concurrency int, fn func(i int) error) error {
+	return ParallelWorkers(n, concurrency, func(worker int, i int) error {
+		return fn(i)
+	})
+}
+
+// ParallelWorkers is like Parallel, but the fn is also called with the index of the worker calling it,
+// which is from 0 to the size of the worker pool - 1, and always 0 when calling sequentially.
+func ParallelWorkers(n int, concurrency int, fn func(worker int, i int) error) error {
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency()
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	if concurrency <= 1 {
+		for i := 0; i < n; i++ {
+			if err := fn(0, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, n)
+	indices := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go func(worker int) {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = fn(worker, i)
+			}
+		}(w)
+	}
+
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
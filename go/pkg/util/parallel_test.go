@@ -0,0 +1,63 @@
+package util
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallel(t *testing.T) {
+	results := make([]int, 100)
+	err := Parallel(len(results), 4, func(i int) error {
+		results[i] = i * i
+		return nil
+	})
+	assert.NoError(t, err)
+	for i, r := range results {
+		assert.Equal(t, i*i, r)
+	}
+}
+
+func TestParallel_Concurrency(t *testing.T) {
+	var running, max int32
+	err := Parallel(50, 3, func(i int) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, max, int32(3))
+}
+
+func TestParallel_Error(t *testing.T) {
+	var calls int32
+	err := Parallel(10, 4, func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 3 || i == 7 {
+			return errors.New(string(rune('0' + i)))
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "3")
+	assert.Equal(t, int32(10), calls)
+}
+
+func TestParallelWorkers(t *testing.T) {
+	workers := make([]int, 20)
+	err := ParallelWorkers(len(workers), 3, func(worker int, i int) error {
+		workers[i] = worker
+		return nil
+	})
+	assert.NoError(t, err)
+	for _, w := range workers {
+		assert.True(t, w >= 0 && w < 3)
+	}
+}
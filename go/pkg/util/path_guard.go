@@ -1,20 +1,23 @@
 package util
 
-import (
-	"github.com/mojo-lang/core/go/pkg/mojo/core"
-)
-
 type PathGuard struct {
 	DisableClear       bool
 	OnlyClearGenerated bool
 	Suffixes           []string
 
+	// the target generating the files, the files will be recorded to the manifest of the output directory,
+	// and the stale files will be pruned by the manifest instead of clearing the directories
+	Target string
+
 	paths []string
+
+	// the output directory has the previous manifest
+	managed bool
 }
 
 func (g *PathGuard) Check(path string) error {
-	if core.IsExist(path) {
-		if g.DisableClear {
+	if fileExist(path) {
+		if g.DisableClear || g.managed {
 			return nil
 		}
 
@@ -24,7 +27,7 @@ func (g *PathGuard) Check(path string) error {
 		}
 		return nil
 	} else {
-		err := core.CreateDir(path)
+		err := createDir(path)
 		if err != nil {
 			return err
 		}
@@ -58,3 +61,27 @@ func (g *PathGuard) cleared(path string) bool {
 	}
 	return false
 }
+
+// Record the files relative to the output directory, which are generated by the target but not written by the guard,
+// like the ones of the external processes.
+//
+// the output should be recorded even without any file, so the files generated there by the previous build are pruned
+func (g *PathGuard) Record(output string, names ...string) error {
+	return g.record(output, names...)
+}
+
+// record the files to the manifest of the output directory if the manifests is enabled
+func (g *PathGuard) record(output string, names ...string) error {
+	if len(g.Target) == 0 {
+		return nil
+	}
+	if m := getManifests(); m != nil {
+		// never remove the files mixed with the user code if the guard won't clear them
+		managed, err := m.record(output, g.Target, g.OnlyClearGenerated || g.DisableClear, names...)
+		if err != nil {
+			return err
+		}
+		g.managed = managed
+	}
+	return nil
+}
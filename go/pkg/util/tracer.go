@@ -0,0 +1,276 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+const (
+	PluginSpan    = "plugin"
+	GeneratorSpan = "generator"
+	ProcessSpan   = "process"
+)
+
+// Span the timing of one plugin invocation, generator or external process
+type Span struct {
+	Category string
+	Name     string
+	Args     map[string]interface{}
+	Start    time.Time
+	Duration time.Duration
+
+	// the worker which the span runs in, shown as the thread in the Chrome trace
+	Tid int
+
+	// the duration excluding the nested spans
+	Self time.Duration
+
+	tracer    *Tracer
+	parent    *Span
+	ended     bool
+	discarded bool
+}
+
+// End finish the span, it is safe to call on a nil span when the tracing is disabled
+func (s *Span) End() {
+	if s == nil || s.tracer == nil {
+		return
+	}
+	s.tracer.end(s, false)
+}
+
+// Discard finish the span without recording it, like the plugin skipped the package
+func (s *Span) Discard() {
+	if s == nil || s.tracer == nil {
+		return
+	}
+	s.tracer.end(s, true)
+}
+
+// Tracer records the spans, the parent of a span is the one carried in the context which it begins with.
+type Tracer struct {
+	mutex sync.Mutex
+	start time.Time
+	spans []*Span
+}
+
+func NewTracer() *Tracer {
+	return &Tracer{start: time.Now()}
+}
+
+// MainTid the thread id of the spans not begun in any worker
+const MainTid = 1
+
+const spanKey = "@span"
+const tidKey = "@tid"
+
+// WithTid set the thread id of the spans begun in the context, like the worker running the build stage
+func WithTid(ctx context.Context, tid int) context.Context {
+	return context.WithValues(ctx, tidKey, tid)
+}
+
+// ContextSpan the span carried in the context, which is the parent of the spans begun in it
+func ContextSpan(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey).(*Span)
+	return span
+}
+
+func contextTid(ctx context.Context) int {
+	if tid, ok := ctx.Value(tidKey).(int); ok {
+		return tid
+	}
+	return MainTid
+}
+
+var tracer *Tracer
+var tracerMutex sync.RWMutex
+
+// StartTrace enable the process wide tracer, the spans begun by BeginSpan will be recorded
+func StartTrace() *Tracer {
+	tracerMutex.Lock()
+	defer tracerMutex.Unlock()
+	tracer = NewTracer()
+	return tracer
+}
+
+// StopTrace disable the process wide tracer and return it
+func StopTrace() *Tracer {
+	tracerMutex.Lock()
+	defer tracerMutex.Unlock()
+	t := tracer
+	tracer = nil
+	return t
+}
+
+// BeginSpan begin a span in the process wide tracer, return nil if the tracing is disabled.
+// the args are the key value pairs like `"pkg", pkg.FullName`.
+//
+// the span is the child of the one in the ctx, and the returned context carries the span for the nested ones.
+func BeginSpan(ctx context.Context, category string, name string, args ...interface{}) (*Span, context.Context) {
+	tracerMutex.RLock()
+	t := tracer
+	tracerMutex.RUnlock()
+	return t.Begin(ctx, category, name, args...)
+}
+
+func (t *Tracer) Begin(ctx context.Context, category string, name string, args ...interface{}) (*Span, context.Context) {
+	if ctx == nil {
+		ctx = context.Empty()
+	}
+	if t == nil {
+		return nil, ctx
+	}
+
+	span := &Span{
+		Category: category,
+		Name:     name,
+		Tid:      contextTid(ctx),
+		tracer:   t,
+		parent:   ContextSpan(ctx),
+	}
+	for i := 0; i+1 < len(args); i += 2 {
+		if span.Args == nil {
+			span.Args = make(map[string]interface{})
+		}
+		span.Args[fmt.Sprint(args[i])] = args[i+1]
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.spans = append(t.spans, span)
+	span.Start = time.Now()
+	return span, context.WithValues(ctx, spanKey, span)
+}
+
+func (t *Tracer) end(span *Span, discarded bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if span.ended {
+		return
+	}
+
+	span.ended = true
+	span.discarded = discarded
+	if discarded {
+		return
+	}
+	span.Duration = time.Since(span.Start)
+	span.Self += span.Duration
+	if span.parent != nil {
+		span.parent.Self -= span.Duration
+	}
+}
+
+// Spans return the finished spans in the order of beginning
+func (t *Tracer) Spans() []*Span {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var spans []*Span
+	for _, span := range t.spans {
+		if span.ended && !span.discarded {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+type chromeTraceEvent struct {
+	Name      string                 `json:"name"`
+	Category  string                 `json:"cat"`
+	Phase     string                 `json:"ph"`
+	Timestamp int64                  `json:"ts"`
+	Duration  int64                  `json:"dur"`
+	Pid       int                    `json:"pid"`
+	Tid       int                    `json:"tid"`
+	Args      map[string]interface{} `json:"args,omitempty"`
+}
+
+type chromeTrace struct {
+	TraceEvents     []*chromeTraceEvent `json:"traceEvents"`
+	DisplayTimeUnit string              `json:"displayTimeUnit"`
+}
+
+// WriteChromeTrace write the spans in the Chrome trace event format, which could be opened by `chrome://tracing` or Perfetto
+func (t *Tracer) WriteChromeTrace(w io.Writer) error {
+	trace := &chromeTrace{TraceEvents: []*chromeTraceEvent{}, DisplayTimeUnit: "ms"}
+	for _, span := range t.Spans() {
+		trace.TraceEvents = append(trace.TraceEvents, &chromeTraceEvent{
+			Name:      span.Name,
+			Category:  span.Category,
+			Phase:     "X",
+			Timestamp: span.Start.Sub(t.start).Microseconds(),
+			Duration:  span.Duration.Microseconds(),
+			Pid:       1,
+			Tid:       span.Tid,
+			Args:      span.Args,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(trace)
+}
+
+// SpanSummary the aggregated timing of the spans with the same category and name
+type SpanSummary struct {
+	Category string
+	Name     string
+	Count    int
+	Total    time.Duration
+	Self     time.Duration
+	Max      time.Duration
+}
+
+// Summaries aggregate the spans by the category and name, sorted by the self duration descending
+func (t *Tracer) Summaries() []*SpanSummary {
+	var summaries []*SpanSummary
+	index := make(map[string]*SpanSummary)
+	for _, span := range t.Spans() {
+		key := span.Category + ":" + span.Name
+		summary := index[key]
+		if summary == nil {
+			summary = &SpanSummary{Category: span.Category, Name: span.Name}
+			index[key] = summary
+			summaries = append(summaries, summary)
+		}
+
+		summary.Count++
+		summary.Total += span.Duration
+		summary.Self += span.Self
+		if span.Duration > summary.Max {
+			summary.Max = span.Duration
+		}
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Self > summaries[j].Self
+	})
+	return summaries
+}
+
+// PrintSummary print the summaries as a table
+func (t *Tracer) PrintSummary(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "CATEGORY\tNAME\tCOUNT\tTOTAL\tSELF\tMAX")
+	for _, s := range t.Summaries() {
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\t%s\n", s.Category, s.Name, s.Count,
+			formatDuration(s.Total), formatDuration(s.Self), formatDuration(s.Max))
+	}
+	return writer.Flush()
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Microsecond * 10).String()
+}
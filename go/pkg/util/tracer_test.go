@@ -0,0 +1,95 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mojo-lang/mojo/go/pkg/context"
+)
+
+func TestTracer_Summaries(t *testing.T) {
+	tracer := NewTracer()
+
+	outer, ctx := tracer.Begin(context.Empty(), GeneratorSpan, "go")
+	inner, _ := tracer.Begin(ctx, ProcessSpan, "go mod tidy", "dir", "/tmp")
+	time.Sleep(5 * time.Millisecond)
+	inner.End()
+	skipped, _ := tracer.Begin(ctx, PluginSpan, "syntax.CompilePackage")
+	skipped.Discard()
+	outer.End()
+
+	spans := tracer.Spans()
+	assert.Equal(t, 2, len(spans))
+	assert.Equal(t, "/tmp", spans[1].Args["dir"])
+	assert.Equal(t, outer.Duration-inner.Duration, outer.Self)
+	assert.Equal(t, inner.Duration, inner.Self)
+
+	summaries := tracer.Summaries()
+	assert.Equal(t, 2, len(summaries))
+	assert.Equal(t, "go mod tidy", summaries[0].Name)
+	assert.Equal(t, 1, summaries[0].Count)
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, tracer.PrintSummary(out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, 3, len(lines))
+	assert.True(t, strings.HasPrefix(lines[0], "CATEGORY"))
+}
+
+func TestTracer_WriteChromeTrace(t *testing.T) {
+	tracer := NewTracer()
+	span, _ := tracer.Begin(context.Empty(), PluginSpan, "semantic.ParsePackage", "pkg", "mojo.test")
+	span.End()
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, tracer.WriteChromeTrace(out))
+
+	trace := struct {
+		TraceEvents []map[string]interface{} `json:"traceEvents"`
+	}{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &trace))
+	assert.Equal(t, 1, len(trace.TraceEvents))
+	assert.Equal(t, "semantic.ParsePackage", trace.TraceEvents[0]["name"])
+	assert.Equal(t, PluginSpan, trace.TraceEvents[0]["cat"])
+	assert.Equal(t, "X", trace.TraceEvents[0]["ph"])
+	assert.Equal(t, "mojo.test", trace.TraceEvents[0]["args"].(map[string]interface{})["pkg"])
+}
+
+func TestTracer_Workers(t *testing.T) {
+	tracer := NewTracer()
+
+	outer, ctx := tracer.Begin(context.Empty(), GeneratorSpan, "mojo")
+	spans := make([]*Span, 4)
+	err := ParallelWorkers(len(spans), 2, func(worker int, i int) error {
+		var span *Span
+		span, _ = tracer.Begin(WithTid(ctx, worker+2), GeneratorSpan, "openapi")
+		span.End()
+		spans[i] = span
+		return nil
+	})
+	assert.NoError(t, err)
+	inner, _ := tracer.Begin(ctx, PluginSpan, "syntax.ParsePackage")
+	inner.End()
+	outer.End()
+
+	assert.Equal(t, MainTid, outer.Tid)
+	for _, span := range spans {
+		// the concurrent spans are the children of the span in the context, but not the ones begun before them
+		assert.Equal(t, outer, span.parent)
+		assert.True(t, span.Tid == 2 || span.Tid == 3)
+	}
+	assert.Equal(t, outer, inner.parent)
+	assert.Equal(t, MainTid, inner.Tid)
+}
+
+func TestBeginSpan_Disabled(t *testing.T) {
+	span, ctx := BeginSpan(context.Empty(), PluginSpan, "syntax.ParsePackage")
+	assert.Nil(t, span)
+	assert.Nil(t, ContextSpan(ctx))
+	span.End()
+}
@@ -0,0 +1,74 @@
+package util
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// ResettableParser the antlr generated parser, which could reset the token stream to parse again
+type ResettableParser interface {
+	antlr.Parser
+
+	SetTokenStream(input antlr.TokenStream)
+}
+
+// ParseTwoStage parse with the SLL prediction mode and the bail error strategy first, which is much faster
+// and succeeds for most inputs. Only when it fails, parse again in the full LL prediction mode with the listener
+// to report the errors, so the errors and the parse tree are the same as the single full LL parsing.
+//
+// the parse is the entry rule of the grammar, like `parser.MojoFile`.
+func ParseTwoStage[T antlr.ParseTree](parser ResettableParser, listener antlr.ErrorListener, parse func() T) T {
+	stream := parser.GetTokenStream()
+	interpreter := parser.GetInterpreter()
+
+	parser.RemoveErrorListeners()
+	parser.SetErrorHandler(&bailErrorStrategy{DefaultErrorStrategy: antlr.NewDefaultErrorStrategy()})
+	interpreter.SetPredictionMode(antlr.PredictionModeSLL)
+	if tree, ok := parseOrBail(parse); ok {
+		return tree
+	}
+
+	stream.Seek(0)
+	parser.SetTokenStream(stream)
+	parser.SetError(nil)
+	parser.AddErrorListener(listener)
+	parser.SetErrorHandler(antlr.NewDefaultErrorStrategy())
+	interpreter.SetPredictionMode(antlr.PredictionModeLL)
+	return parse()
+}
+
+func parseOrBail[T antlr.ParseTree](parse func() T) (tree T, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, bailed := r.(*parseBailout); !bailed {
+				panic(r)
+			}
+			ok = false
+		}
+	}()
+	return parse(), true
+}
+
+type parseBailout struct{}
+
+// bailErrorStrategy abort the parsing at the first syntax error.
+//
+// the antlr.BailErrorStrategy of the go runtime does not abort, the generated parser keeps going
+// and reports the ParseCancellationException, which panics for its GetMessage is not implemented.
+type bailErrorStrategy struct {
+	*antlr.DefaultErrorStrategy
+}
+
+func (s *bailErrorStrategy) ReportError(antlr.Parser, antlr.RecognitionException) {
+	panic(&parseBailout{})
+}
+
+func (s *bailErrorStrategy) Recover(antlr.Parser, antlr.RecognitionException) {
+	panic(&parseBailout{})
+}
+
+func (s *bailErrorStrategy) RecoverInline(antlr.Parser) antlr.Token {
+	panic(&parseBailout{})
+}
+
+func (s *bailErrorStrategy) Sync(antlr.Parser) {
+}